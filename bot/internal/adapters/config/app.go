@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/spf13/viper"
 )
 
@@ -13,6 +15,9 @@ type AppConfig interface {
 	QRLogoPath() string
 	VersionNotifyOnStartup() bool
 	VersionChannelID() int64
+	WaitlistOfferTTL() time.Duration
+	FeedbackDelay() time.Duration
+	AttendanceReportDelay() time.Duration
 }
 
 type appConfig struct {
@@ -24,6 +29,9 @@ type appConfig struct {
 	qrLogoPath                string
 	versionNotifyOnStartup    bool
 	versionChannelID          int64
+	waitlistOfferTTL          time.Duration
+	feedbackDelay             time.Duration
+	attendanceReportDelay     time.Duration
 }
 
 func NewAppConfig() AppConfig {
@@ -36,6 +44,9 @@ func NewAppConfig() AppConfig {
 		qrLogoPath:                viper.GetString("settings.qr.logo-path"),
 		versionNotifyOnStartup:    viper.GetBool("settings.version.notify-on-startup"),
 		versionChannelID:          viper.GetInt64("settings.version.channel-id"),
+		waitlistOfferTTL:          viper.GetDuration("settings.waitlist.offer-ttl"),
+		feedbackDelay:             viper.GetDuration("settings.feedback.delay"),
+		attendanceReportDelay:     viper.GetDuration("settings.attendance-report.delay"),
 	}
 }
 
@@ -70,3 +81,15 @@ func (cfg *appConfig) VersionNotifyOnStartup() bool {
 func (cfg *appConfig) VersionChannelID() int64 {
 	return cfg.versionChannelID
 }
+
+func (cfg *appConfig) WaitlistOfferTTL() time.Duration {
+	return cfg.waitlistOfferTTL
+}
+
+func (cfg *appConfig) FeedbackDelay() time.Duration {
+	return cfg.feedbackDelay
+}
+
+func (cfg *appConfig) AttendanceReportDelay() time.Duration {
+	return cfg.attendanceReportDelay
+}
@@ -5,7 +5,9 @@ import (
 
 	"github.com/spf13/viper"
 
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/deadline"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/location"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/noshow"
 )
 
 // Config represents the unified configuration for the entire application
@@ -14,10 +16,15 @@ type Config struct {
 	PG        PGConfig
 	RedisConf RedisConfig
 	SMTP      SMTPConfig
+	HTTP      HTTPConfig
 	Bot       BotConfig
 	App       AppConfig
 	Banner    BannerConfig
 	Session   SessionConfig
+	// Deadlines - registration deadlines of the roles
+	Deadlines deadline.Policy
+	// NoShows - restrictions of users who don't come to the events
+	NoShows noshow.Policy
 }
 
 func NewConfig() (*Config, error) {
@@ -39,6 +46,16 @@ func NewConfig() (*Config, error) {
 		return nil, err
 	}
 
+	deadlinePolicy, err := NewDeadlinePolicy()
+	if err != nil {
+		return nil, fmt.Errorf("registration deadlines configuration is invalid: %w", err)
+	}
+
+	noShowPolicy, err := NewNoShowPolicy()
+	if err != nil {
+		return nil, fmt.Errorf("no-shows configuration is invalid: %w", err)
+	}
+
 	bannerCfg := NewBannerConfig()
 	if err := bannerCfg.Validate(); err != nil {
 		return nil, fmt.Errorf("banner configuration validation failed: %w", err)
@@ -49,10 +66,13 @@ func NewConfig() (*Config, error) {
 		PG:        NewPGConfig(),
 		RedisConf: NewRedisConfig(),
 		SMTP:      NewSMTPConfig(),
+		HTTP:      NewHTTPConfig(),
 		Bot:       NewBotConfig(),
 		App:       NewAppConfig(),
 		Banner:    bannerCfg,
 		Session:   NewSessionConfig(),
+		Deadlines: deadlinePolicy,
+		NoShows:   noShowPolicy,
 	}
 
 	location.Init(cfg.App.Timezone())
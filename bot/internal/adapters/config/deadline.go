@@ -0,0 +1,123 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/deadline"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/valueobject"
+)
+
+const deadlinesKey = "settings.registration-deadlines"
+
+type cutoffConfig struct {
+	DaysBefore int    `mapstructure:"days-before"`
+	Time       string `mapstructure:"time"`
+}
+
+type deadlineRuleConfig struct {
+	RegistrationEnd bool                    `mapstructure:"registration-end"`
+	DaysBefore      int                     `mapstructure:"days-before"`
+	Time            string                  `mapstructure:"time"`
+	Weekdays        map[string]cutoffConfig `mapstructure:"weekdays"`
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// NewDeadlinePolicy reads the registration deadline policy: rules by role and the "default" rule for the other roles.
+// If the policy is not configured, deadline.DefaultPolicy is returned
+func NewDeadlinePolicy() (deadline.Policy, error) {
+	if !viper.IsSet(deadlinesKey) {
+		return deadline.DefaultPolicy(), nil
+	}
+
+	var rules map[string]deadlineRuleConfig
+	if err := viper.UnmarshalKey(deadlinesKey, &rules); err != nil {
+		return deadline.Policy{}, err
+	}
+
+	defaultRule, ok := rules["default"]
+	if !ok {
+		return deadline.Policy{}, fmt.Errorf("%s.default is required", deadlinesKey)
+	}
+
+	policy := deadline.Policy{
+		Roles: make(map[valueobject.Role]deadline.Rule, len(rules)),
+	}
+
+	var err error
+	if policy.Default, err = defaultRule.rule(); err != nil {
+		return deadline.Policy{}, fmt.Errorf("%s.default: %w", deadlinesKey, err)
+	}
+
+	for name, ruleConfig := range rules {
+		if name == "default" {
+			continue
+		}
+
+		role := valueobject.Role(name)
+		if !role.IsValid() {
+			return deadline.Policy{}, fmt.Errorf("%s: unknown role %q", deadlinesKey, name)
+		}
+		if policy.Roles[role], err = ruleConfig.rule(); err != nil {
+			return deadline.Policy{}, fmt.Errorf("%s.%s: %w", deadlinesKey, name, err)
+		}
+	}
+
+	return policy, nil
+}
+
+func (cfg deadlineRuleConfig) rule() (deadline.Rule, error) {
+	if cfg.RegistrationEnd {
+		return deadline.Rule{RegistrationEnd: true}, nil
+	}
+
+	cutoff, err := cutoffConfig{DaysBefore: cfg.DaysBefore, Time: cfg.Time}.cutoff()
+	if err != nil {
+		return deadline.Rule{}, err
+	}
+
+	rule := deadline.Rule{
+		Cutoff:   cutoff,
+		Weekdays: make(map[time.Weekday]deadline.Cutoff, len(cfg.Weekdays)),
+	}
+	for name, weekdayConfig := range cfg.Weekdays {
+		weekday, ok := weekdays[strings.ToLower(name)]
+		if !ok {
+			return deadline.Rule{}, fmt.Errorf("unknown weekday %q", name)
+		}
+		if rule.Weekdays[weekday], err = weekdayConfig.cutoff(); err != nil {
+			return deadline.Rule{}, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	return rule, nil
+}
+
+func (cfg cutoffConfig) cutoff() (deadline.Cutoff, error) {
+	if cfg.DaysBefore < 0 {
+		return deadline.Cutoff{}, fmt.Errorf("days-before must not be negative")
+	}
+
+	at, err := time.Parse("15:04", cfg.Time)
+	if err != nil {
+		return deadline.Cutoff{}, fmt.Errorf("time must be in HH:MM format: %w", err)
+	}
+
+	return deadline.Cutoff{
+		DaysBefore: cfg.DaysBefore,
+		Hour:       at.Hour(),
+		Minute:     at.Minute(),
+	}, nil
+}
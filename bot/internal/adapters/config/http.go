@@ -0,0 +1,39 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+type HTTPConfig interface {
+	Address() string
+	PublicURL() string
+	Enabled() bool
+}
+
+type httpConfig struct {
+	address   string
+	publicURL string
+}
+
+func NewHTTPConfig() HTTPConfig {
+	return &httpConfig{
+		address:   viper.GetString("infrastructure.http.address"),
+		publicURL: strings.TrimSuffix(viper.GetString("infrastructure.http.public-url"), "/"),
+	}
+}
+
+func (cfg *httpConfig) Address() string {
+	return cfg.address
+}
+
+// PublicURL returns the base URL of the server used in links sent to users, without the trailing slash
+func (cfg *httpConfig) PublicURL() string {
+	return cfg.publicURL
+}
+
+// Enabled reports whether the HTTP server should be started
+func (cfg *httpConfig) Enabled() bool {
+	return cfg.address != ""
+}
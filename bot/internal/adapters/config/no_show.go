@@ -0,0 +1,47 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/noshow"
+)
+
+const noShowsKey = "settings.no-shows"
+
+type noShowConfig struct {
+	Limit        int  `mapstructure:"limit"`
+	PeriodDays   int  `mapstructure:"period-days"`
+	BlockDays    int  `mapstructure:"block-days"`
+	WaitlistLast bool `mapstructure:"waitlist-last"`
+}
+
+// NewNoShowPolicy reads the no-show policy. If the policy is not configured, no-shows are not tracked
+func NewNoShowPolicy() (noshow.Policy, error) {
+	if !viper.IsSet(noShowsKey) {
+		return noshow.DefaultPolicy(), nil
+	}
+
+	var cfg noShowConfig
+	if err := viper.UnmarshalKey(noShowsKey, &cfg); err != nil {
+		return noshow.Policy{}, err
+	}
+
+	switch {
+	case cfg.Limit < 0:
+		return noshow.Policy{}, fmt.Errorf("%s.limit must not be negative", noShowsKey)
+	case cfg.Limit > 0 && cfg.PeriodDays <= 0:
+		return noshow.Policy{}, fmt.Errorf("%s.period-days must be positive", noShowsKey)
+	case cfg.BlockDays < 0:
+		return noshow.Policy{}, fmt.Errorf("%s.block-days must not be negative", noShowsKey)
+	}
+
+	return noshow.Policy{
+		Limit:        cfg.Limit,
+		Period:       time.Duration(cfg.PeriodDays) * 24 * time.Hour,
+		Block:        time.Duration(cfg.BlockDays) * 24 * time.Hour,
+		WaitlistLast: cfg.WaitlistLast,
+	}, nil
+}
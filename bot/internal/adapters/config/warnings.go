@@ -153,6 +153,7 @@ func (wm *WarningsManager) ValidateConfig(cfg *Config) {
 	wm.CheckEmptySlice("App.PassExcludedRoles", cfg.App.PassExcludedRoles(), "pass role validation may not work")
 	wm.CheckEmptyString("App.EmailConfirmationTemplate", cfg.App.EmailConfirmationTemplate(), "email confirmation may not work")
 	wm.CheckEmptyString("App.QRLogoPath", cfg.App.QRLogoPath(), "QR codes may not have logo")
+	wm.CheckZeroDuration("App.WaitlistOfferTTL", cfg.App.WaitlistOfferTTL(), "waitlist seat offers will expire immediately")
 
 	// SMTP warnings (critical for email functionality)
 	wm.CheckEmptyString("SMTP.Host", cfg.SMTP.Host(), "SMTP functionality may not work")
@@ -162,6 +163,9 @@ func (wm *WarningsManager) ValidateConfig(cfg *Config) {
 	wm.CheckEmptyString("SMTP.Email", cfg.SMTP.Email(), "SMTP functionality may not work")
 	wm.CheckEmptyString("SMTP.Domain", cfg.SMTP.Domain(), "SMTP functionality may not work")
 
+	// HTTP warnings
+	wm.CheckConditionalString("HTTP.PublicURL", cfg.HTTP.PublicURL(), cfg.HTTP.Enabled(), "HTTP server is enabled, calendar feed links will not work")
+
 	// PostgreSQL warnings (critical for database)
 	wm.CheckEmptyString("PostgreSQL.Host", extractHostFromDSN(cfg.PG.DSN()), "database connection may not work")
 	wm.CheckEmptyString("PostgreSQL.User", extractUserFromDSN(cfg.PG.DSN()), "database connection may not work")
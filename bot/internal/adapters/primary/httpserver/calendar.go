@@ -0,0 +1,58 @@
+package httpserver
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/calendar"
+)
+
+const userCalendarName = "Мои мероприятия CU Clubs"
+
+// userCalendar serves the personal calendar feed, the secret token in the URL is the only authorization
+func (s *Server) userCalendar(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutSuffix(r.PathValue("file"), ".ics")
+	if !ok || token == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	user, err := s.userService.GetByCalendarToken(r.Context(), token)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+		s.logger.Errorf("error while getting user by calendar token: %v", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if user.IsBanned {
+		http.NotFound(w, r)
+		return
+	}
+	s.logger.Debugf("(user: %d) calendar feed requested", user.ID)
+
+	events, err := s.userService.GetCalendarEvents(r.Context(), user.ID)
+	if err != nil {
+		s.logger.Errorf("(user: %d) error while getting calendar events: %v", user.ID, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	ics, err := calendar.ExportEventsToICS(userCalendarName, events)
+	if err != nil {
+		s.logger.Errorf("(user: %d) error while exporting calendar feed: %v", user.ID, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Cache-Control", "private, no-cache")
+	if _, err = w.Write(ics); err != nil {
+		s.logger.Errorf("(user: %d) error while writing calendar feed: %v", user.ID, err)
+	}
+}
@@ -0,0 +1,243 @@
+package httpserver
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/dto"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/calendar"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/valueobject"
+)
+
+const (
+	publicCalendarName = "Мероприятия CU Clubs"
+	// publicMaxAge - how long clients and proxies may use the public listings without revalidation
+	publicMaxAge = "public, max-age=300"
+)
+
+type publicClub struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Link        string `json:"link,omitempty"`
+}
+
+type publicEvent struct {
+	ID              string    `json:"id"`
+	ClubID          string    `json:"club_id"`
+	Name            string    `json:"name"`
+	Description     string    `json:"description"`
+	Location        string    `json:"location"`
+	Format          string    `json:"format"`
+	StartTime       time.Time `json:"start_time"`
+	EndTime         time.Time `json:"end_time,omitzero"`
+	RegistrationEnd time.Time `json:"registration_end"`
+	MaxParticipants int       `json:"max_participants"`
+	AllowedRoles    []string  `json:"allowed_roles"`
+	Categories      []string  `json:"categories"`
+	// Link - deep link that opens the event in the bot
+	Link string `json:"link"`
+}
+
+func (s *Server) newPublicEvents(events []entity.Event) []publicEvent {
+	result := make([]publicEvent, len(events))
+	for i, event := range events {
+		result[i] = publicEvent{
+			ID:              event.ID,
+			ClubID:          event.ClubID,
+			Name:            event.Name,
+			Description:     event.Description,
+			Location:        event.Location,
+			Format:          string(event.Format),
+			StartTime:       event.StartTime,
+			EndTime:         event.EndTime,
+			RegistrationEnd: event.RegistrationEnd,
+			MaxParticipants: event.MaxParticipants,
+			AllowedRoles:    event.AllowedRoles,
+			Categories:      event.Categories,
+			Link:            event.Link(s.botName),
+		}
+		if result[i].AllowedRoles == nil {
+			result[i].AllowedRoles = []string{}
+		}
+		if result[i].Categories == nil {
+			result[i].Categories = []string{}
+		}
+	}
+	return result
+}
+
+// publicEvents returns upcoming events of all shown clubs,
+// the optional role query parameter keeps only events available for the role
+func (s *Server) publicEvents(w http.ResponseWriter, r *http.Request) {
+	events, ok := s.getPublicEvents(w, r, "")
+	if !ok {
+		return
+	}
+
+	s.writeJSON(w, r, struct {
+		Events []publicEvent `json:"events"`
+	}{
+		Events: s.newPublicEvents(events),
+	})
+}
+
+// clubEvents returns upcoming events of the club, including the co-hosted ones
+func (s *Server) clubEvents(w http.ResponseWriter, r *http.Request) {
+	club, ok := s.getPublicClub(w, r, r.PathValue("clubID"))
+	if !ok {
+		return
+	}
+
+	events, ok := s.getPublicEvents(w, r, club.ID)
+	if !ok {
+		return
+	}
+
+	s.writeJSON(w, r, struct {
+		Club   publicClub    `json:"club"`
+		Events []publicEvent `json:"events"`
+	}{
+		Club: publicClub{
+			ID:          club.ID,
+			Name:        club.Name,
+			Description: club.Description,
+			Link:        club.Link,
+		},
+		Events: s.newPublicEvents(events),
+	})
+}
+
+func (s *Server) publicCalendar(w http.ResponseWriter, r *http.Request) {
+	events, ok := s.getPublicEvents(w, r, "")
+	if !ok {
+		return
+	}
+
+	s.writeCalendar(w, r, publicCalendarName, events)
+}
+
+func (s *Server) clubCalendar(w http.ResponseWriter, r *http.Request) {
+	clubID, ok := strings.CutSuffix(r.PathValue("file"), ".ics")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	club, ok := s.getPublicClub(w, r, clubID)
+	if !ok {
+		return
+	}
+
+	events, ok := s.getPublicEvents(w, r, club.ID)
+	if !ok {
+		return
+	}
+
+	s.writeCalendar(w, r, club.Name, events)
+}
+
+// getPublicClub returns the club if it is shown to users, otherwise it writes 404
+func (s *Server) getPublicClub(w http.ResponseWriter, r *http.Request, clubID string) (*entity.Club, bool) {
+	if uuid.Validate(clubID) != nil {
+		http.NotFound(w, r)
+		return nil, false
+	}
+
+	club, err := s.clubService.Get(r.Context(), clubID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			http.NotFound(w, r)
+			return nil, false
+		}
+		s.logger.Errorf("error while getting club (club_id=%s): %v", clubID, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return nil, false
+	}
+	if !club.ShouldShow {
+		http.NotFound(w, r)
+		return nil, false
+	}
+
+	return club, true
+}
+
+// getPublicEvents returns upcoming public events of the club (of all clubs if clubID is empty),
+// on error it writes the response itself
+func (s *Server) getPublicEvents(w http.ResponseWriter, r *http.Request, clubID string) ([]entity.Event, bool) {
+	role := valueobject.Role(r.URL.Query().Get("role"))
+	if role != "" && !role.IsValid() {
+		http.Error(w, "unknown role", http.StatusBadRequest)
+		return nil, false
+	}
+
+	events, err := s.eventService.GetPublic(r.Context(), role, dto.EventFilter{ClubID: clubID})
+	if err != nil {
+		s.logger.Errorf("error while getting public events (club_id=%s): %v", clubID, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return nil, false
+	}
+
+	return events, true
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, r *http.Request, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		s.logger.Errorf("error while encoding %s: %v", r.URL.Path, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	s.writeCacheable(w, r, "application/json; charset=utf-8", body)
+}
+
+func (s *Server) writeCalendar(w http.ResponseWriter, r *http.Request, name string, events []entity.Event) {
+	ics, err := calendar.ExportEventsToICS(name, events)
+	if err != nil {
+		s.logger.Errorf("error while exporting %s: %v", r.URL.Path, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	s.writeCacheable(w, r, "text/calendar; charset=utf-8", ics)
+}
+
+// writeCacheable writes the body with an ETag computed from its content,
+// clients that already have the same content get 304 Not Modified
+func (s *Server) writeCacheable(w http.ResponseWriter, r *http.Request, contentType string, body []byte) {
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", publicMaxAge)
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	if _, err := w.Write(body); err != nil {
+		s.logger.Errorf("error while writing %s: %v", r.URL.Path, err)
+	}
+}
+
+// etagMatches checks the If-None-Match header, which may contain several (weak) ETags or *
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/ports/primary"
+	"github.com/Badsnus/cu-clubs-bot/bot/pkg/logger"
+	"github.com/Badsnus/cu-clubs-bot/bot/pkg/logger/types"
+)
+
+// Server serves calendar feeds and the public events API over HTTP next to the bot
+type Server struct {
+	server  *http.Server
+	logger  *types.Logger
+	botName string
+
+	userService  primary.UserService
+	eventService primary.EventService
+	clubService  primary.ClubService
+}
+
+func New(
+	address string,
+	botName string,
+	userSvc primary.UserService,
+	eventSvc primary.EventService,
+	clubSvc primary.ClubService,
+) (*Server, error) {
+	httpLogger, err := logger.Named("http")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		logger:       httpLogger,
+		botName:      botName,
+		userService:  userSvc,
+		eventService: eventSvc,
+		clubService:  clubSvc,
+	}
+	s.server = &http.Server{
+		Addr:              address,
+		Handler:           s.routes(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	return s, nil
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /calendar/{file}", s.userCalendar)
+
+	mux.HandleFunc("GET /api/events", s.publicEvents)
+	mux.HandleFunc("GET /api/clubs/{clubID}/events", s.clubEvents)
+	mux.HandleFunc("GET /calendar/events.ics", s.publicCalendar)
+	mux.HandleFunc("GET /calendar/clubs/{file}", s.clubCalendar)
+	return mux
+}
+
+// Start serves requests in the background until Shutdown is called
+func (s *Server) Start() {
+	go func() {
+		s.logger.Infof("HTTP server listening on %s", s.server.Addr)
+		if err := s.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Errorf("HTTP server stopped: %v", err)
+		}
+	}()
+}
+
+// Shutdown stops accepting new requests and waits for the active ones to finish
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
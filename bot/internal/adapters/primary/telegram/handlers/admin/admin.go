@@ -32,12 +32,14 @@ type Handler struct {
 	adminUserService primary.UserService
 	clubService      primary.ClubService
 	clubOwnerService primary.ClubOwnerService
+	venueService     primary.VenueService
 }
 
 func New(
 	userSvc primary.UserService,
 	clubSvc primary.ClubService,
 	clubOwnerSvc primary.ClubOwnerService,
+	venueSvc primary.VenueService,
 	b *tele.Bot,
 	lt *layout.Layout,
 	lg *types.Logger,
@@ -51,6 +53,7 @@ func New(
 		adminUserService: userSvc,
 		clubService:      clubSvc,
 		clubOwnerService: clubOwnerSvc,
+		venueService:     venueSvc,
 	}
 }
 
@@ -866,6 +869,54 @@ func (h Handler) banUser(c tele.Context) error {
 	)
 }
 
+func (h Handler) resetNoShows(c tele.Context) error {
+	_ = c.Delete()
+	if c.Message() == nil || c.Message().Payload == "" {
+		return c.Send(
+			h.layout.Text(c, "invalid_reset_no_shows_data"),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+	userID, err := strconv.ParseInt(c.Message().Payload, 10, 64)
+	if err != nil {
+		return c.Send(
+			h.layout.Text(c, "invalid_reset_no_shows_data"),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+
+	h.logger.Infof("(user: %d) reset no-shows of user: %d", c.Sender().ID, userID)
+	user, err := h.adminUserService.ResetNoShows(context.Background(), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Send(
+				h.layout.Text(c, "user_not_found", struct {
+					ID int64
+				}{
+					ID: userID,
+				}),
+				h.layout.Markup(c, "core:hide"),
+			)
+		}
+		h.logger.Errorf("(user: %d) error while reset no-shows: %v", c.Sender().ID, err)
+		return c.Send(
+			h.layout.Text(c, "technical_issues", err.Error()),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+
+	return c.Send(
+		h.layout.Text(c, "user_no_shows_reset", struct {
+			FIO string
+			ID  int64
+		}{
+			FIO: user.FIO.String(),
+			ID:  user.ID,
+		}),
+		h.layout.Markup(c, "core:hide"),
+	)
+}
+
 func (h Handler) AdminSetup(group *tele.Group) {
 	group.Handle(h.layout.Callback("mainMenu:admin_menu"), h.adminMenu)
 	group.Handle(h.layout.Callback("admin:back_to_menu"), h.adminMenu)
@@ -882,5 +933,14 @@ func (h Handler) AdminSetup(group *tele.Group) {
 	group.Handle(h.layout.Callback("admin:club:roles"), h.manageRoles)
 	group.Handle(h.layout.Callback("admin:club:roles:role"), h.manageRoles)
 	group.Handle(h.layout.Callback("admin:club:delete"), h.deleteClub)
+	group.Handle(h.layout.Callback("admin:venues"), h.venuesList)
+	group.Handle(h.layout.Callback("admin:venues:back"), h.venuesList)
+	group.Handle(h.layout.Callback("admin:create_venue"), h.createVenue)
+	group.Handle(h.layout.Callback("admin:venues:venue"), h.venueMenu)
+	group.Handle(h.layout.Callback("admin:venue:on_campus"), h.venueMenu)
+	group.Handle(h.layout.Callback("admin:venue:back"), h.venueMenu)
+	group.Handle(h.layout.Callback("admin:venue:capacity"), h.editVenueCapacity)
+	group.Handle(h.layout.Callback("admin:venue:delete"), h.deleteVenue)
 	group.Handle("/ban", h.banUser)
+	group.Handle("/reset_noshows", h.resetNoShows)
 }
@@ -0,0 +1,253 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/nlypage/intele/collector"
+	tele "gopkg.in/telebot.v3"
+	"gorm.io/gorm"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/banner"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/validator"
+)
+
+func (h Handler) venuesList(c tele.Context) error {
+	h.logger.Infof("(user: %d) edit venues list", c.Sender().ID)
+
+	venues, err := h.venueService.GetAll(context.Background())
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get venues: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.Menu.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "admin:backToMenu"),
+		)
+	}
+
+	markup := c.Bot().NewMarkup()
+	var rows []tele.Row
+	for _, venue := range venues {
+		rows = append(rows, markup.Row(*h.layout.Button(c, "admin:venues:venue", venue)))
+	}
+	rows = append(
+		rows,
+		markup.Row(*h.layout.Button(c, "admin:create_venue")),
+		markup.Row(*h.layout.Button(c, "admin:back_to_menu")),
+	)
+	markup.Inline(rows...)
+
+	return c.Edit(
+		banner.Menu.Caption(h.layout.Text(c, "venues_list", len(venues))),
+		markup,
+	)
+}
+
+func (h Handler) venueMenu(c tele.Context) error {
+	venueID := c.Callback().Data
+	h.logger.Infof("(user: %d) edit venue menu (venue_id=%s)", c.Sender().ID, venueID)
+
+	venue, err := h.venueService.Get(context.Background(), venueID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get venue: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.Menu.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "admin:venues:back"),
+		)
+	}
+
+	if c.Callback().Unique == "admin_venue_onCampus" {
+		venue.OnCampus = !venue.OnCampus
+		venue, err = h.venueService.Update(context.Background(), venue)
+		if err != nil {
+			h.logger.Errorf("(user: %d) error while update venue: %v", c.Sender().ID, err)
+			return c.Edit(
+				banner.Menu.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+				h.layout.Markup(c, "admin:venues:back"),
+			)
+		}
+	}
+
+	return c.Edit(
+		banner.Menu.Caption(h.layout.Text(c, "admin_venue_menu_text", venue)),
+		h.layout.Markup(c, "admin:venue:menu", venue),
+	)
+}
+
+func (h Handler) createVenue(c tele.Context) error {
+	h.logger.Infof("(user: %d) create new venue request", c.Sender().ID)
+	inputCollector := collector.New()
+	inputCollector.Collect(c.Message())
+	backMarkup := h.layout.Markup(c, "admin:venues:back")
+
+	_ = c.Edit(
+		banner.Menu.Caption(h.layout.Text(c, "input_venue_name")),
+		backMarkup,
+	)
+	name, ok := h.inputVenueValue(c, inputCollector, "input_venue_name", "invalid_venue_name", validator.VenueName, backMarkup)
+	if !ok {
+		return nil
+	}
+
+	_ = inputCollector.Send(c,
+		banner.Menu.Caption(h.layout.Text(c, "input_venue_building")),
+		backMarkup,
+	)
+	building, ok := h.inputVenueValue(c, inputCollector, "input_venue_building", "invalid_venue_building", validator.VenueBuilding, backMarkup)
+	if !ok {
+		return nil
+	}
+
+	_ = inputCollector.Send(c,
+		banner.Menu.Caption(h.layout.Text(c, "input_venue_capacity")),
+		backMarkup,
+	)
+	capacityStr, ok := h.inputVenueValue(c, inputCollector, "input_venue_capacity", "invalid_venue_capacity", validator.VenueCapacity, backMarkup)
+	if !ok {
+		return nil
+	}
+	capacity, _ := strconv.Atoi(capacityStr)
+
+	venue, err := h.venueService.Create(context.Background(), &entity.Venue{
+		Name:     name,
+		Building: building,
+		Capacity: capacity,
+		OnCampus: true,
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return c.Send(
+				banner.Menu.Caption(h.layout.Text(c, "venue_already_exists")),
+				backMarkup,
+			)
+		}
+
+		h.logger.Errorf("(user: %d) error while create new venue: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.Menu.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			backMarkup,
+		)
+	}
+
+	h.logger.Infof("(user: %d) new venue created: %s", c.Sender().ID, venue.Name)
+	return c.Send(
+		banner.Menu.Caption(h.layout.Text(c, "admin_venue_menu_text", venue)),
+		h.layout.Markup(c, "admin:venue:menu", venue),
+	)
+}
+
+func (h Handler) editVenueCapacity(c tele.Context) error {
+	venueID := c.Callback().Data
+	h.logger.Infof("(user: %d) edit venue capacity (venue_id=%s)", c.Sender().ID, venueID)
+	inputCollector := collector.New()
+	inputCollector.Collect(c.Message())
+	backMarkup := h.layout.Markup(c, "admin:venue:back", struct {
+		ID string
+	}{
+		ID: venueID,
+	})
+
+	venue, err := h.venueService.Get(context.Background(), venueID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get venue: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.Menu.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "admin:venues:back"),
+		)
+	}
+
+	_ = c.Edit(
+		banner.Menu.Caption(h.layout.Text(c, "input_venue_capacity")),
+		backMarkup,
+	)
+	capacityStr, ok := h.inputVenueValue(c, inputCollector, "input_venue_capacity", "invalid_venue_capacity", validator.VenueCapacity, backMarkup)
+	if !ok {
+		return nil
+	}
+
+	venue.Capacity, _ = strconv.Atoi(capacityStr)
+	venue, err = h.venueService.Update(context.Background(), venue)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while update venue: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.Menu.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			backMarkup,
+		)
+	}
+
+	return c.Send(
+		banner.Menu.Caption(h.layout.Text(c, "admin_venue_menu_text", venue)),
+		h.layout.Markup(c, "admin:venue:menu", venue),
+	)
+}
+
+func (h Handler) deleteVenue(c tele.Context) error {
+	venueID := c.Callback().Data
+	h.logger.Infof("(user: %d) delete venue (venue_id=%s)", c.Sender().ID, venueID)
+
+	venue, err := h.venueService.Get(context.Background(), venueID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get venue: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.Menu.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "admin:venues:back"),
+		)
+	}
+
+	if err = h.venueService.Delete(context.Background(), venue.ID); err != nil {
+		h.logger.Errorf("(user: %d) error while delete venue: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.Menu.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "admin:venues:back"),
+		)
+	}
+
+	h.logger.Infof("(user: %d) venue deleted (venue_id=%s)", c.Sender().ID, venueID)
+	return c.Edit(
+		banner.Menu.Caption(h.layout.Text(c, "venue_deleted", venue)),
+		h.layout.Markup(c, "admin:venues:back"),
+	)
+}
+
+// inputVenueValue waits for a valid value from the admin, the prompt must be sent by the caller.
+// Returns false if the input was canceled
+func (h Handler) inputVenueValue(
+	c tele.Context,
+	inputCollector *collector.MessageCollector,
+	promptKey string,
+	errorKey string,
+	validate func(string, map[string]interface{}) bool,
+	markup *tele.ReplyMarkup,
+) (string, bool) {
+	for {
+		response, errGet := h.input.Get(context.Background(), c.Sender().ID, 0)
+		if response.Message != nil {
+			inputCollector.Collect(response.Message)
+		}
+		switch {
+		case response.Canceled:
+			_ = inputCollector.Clear(c, collector.ClearOptions{IgnoreErrors: true, ExcludeLast: true})
+			return "", false
+		case errGet != nil:
+			h.logger.Errorf("(user: %d) error while input (%s): %v", c.Sender().ID, promptKey, errGet)
+			_ = inputCollector.Send(c,
+				banner.Menu.Caption(h.layout.Text(c, "input_error", h.layout.Text(c, promptKey))),
+				markup,
+			)
+		case response.Message == nil:
+			_ = inputCollector.Send(c,
+				banner.Menu.Caption(h.layout.Text(c, "input_error", h.layout.Text(c, promptKey))),
+				markup,
+			)
+		case !validate(response.Message.Text, nil):
+			_ = inputCollector.Send(c,
+				banner.Menu.Caption(h.layout.Text(c, errorKey)),
+				markup,
+			)
+		default:
+			_ = inputCollector.Clear(c, collector.ClearOptions{IgnoreErrors: true})
+			return response.Message.Text, true
+		}
+	}
+}
@@ -0,0 +1,288 @@
+package clubowner
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/nlypage/intele/collector"
+	tele "gopkg.in/telebot.v3"
+	"gorm.io/gorm"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/common/errorz"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/banner"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/location"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/validator"
+)
+
+// toggleEventApproval switches whether new registrations on the event have to be approved.
+// Registrations that are already pending keep waiting for the owner's decision.
+func (h Handler) toggleEventApproval(c tele.Context) error {
+	data := strings.Split(c.Callback().Data, " ")
+	if len(data) != 2 {
+		return errorz.ErrInvalidCallbackData
+	}
+
+	eventID := data[0]
+	page := data[1]
+	h.logger.Infof("(user: %d) toggle event approval (event_id=%s)", c.Sender().ID, eventID)
+
+	event, err := h.eventService.Get(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+				ID   string
+				Page string
+			}{
+				ID:   eventID,
+				Page: page,
+			}),
+		)
+	}
+
+	event.ApprovalRequired = !event.ApprovalRequired
+	if _, err = h.eventService.Update(context.Background(), event); err != nil {
+		h.logger.Errorf("(user: %d) error while update event: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+				ID   string
+				Page string
+			}{
+				ID:   eventID,
+				Page: page,
+			}),
+		)
+	}
+
+	return h.eventSettings(c)
+}
+
+// approvalData parses "eventID userID" callback data of the approval card
+func approvalData(data string) (string, int64, error) {
+	parts := strings.Split(data, " ")
+	if len(parts) != 2 {
+		return "", 0, errorz.ErrInvalidCallbackData
+	}
+
+	userID, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return "", 0, errorz.ErrInvalidCallbackData
+	}
+
+	return parts[0], userID, nil
+}
+
+// approvalRequest loads the event and the user of the approval card and checks that the sender hosts the event
+func (h Handler) approvalRequest(c tele.Context) (*entity.Event, *entity.User, error) {
+	eventID, userID, err := approvalData(c.Callback().Data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	event, err := h.eventService.Get(context.Background(), eventID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, _, err = h.eventClubID(context.Background(), c.Sender().ID, event); err != nil {
+		return nil, nil, err
+	}
+
+	user, err := h.userService.Get(context.Background(), userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return event, user, nil
+}
+
+func (h Handler) approvalRequestText(c tele.Context, event *entity.Event, user *entity.User) string {
+	return h.layout.Text(c, "approval_request", struct {
+		Name      string
+		StartTime string
+		FIO       string
+		Username  string
+		Email     string
+		Role      string
+	}{
+		Name:      event.Name,
+		StartTime: event.StartTime.In(location.Location()).Format("02.01.2006 15:04"),
+		FIO:       user.FIO.String(),
+		Username:  user.Username,
+		Email:     user.Email.String(),
+		Role:      user.Role.String(),
+	})
+}
+
+// approvalAnsweredText replaces the approval card once the owner has made a decision
+func (h Handler) approvalAnsweredText(c tele.Context, event *entity.Event, user *entity.User, approved bool, reason string) string {
+	return h.layout.Text(c, "approval_answered", struct {
+		Name     string
+		FIO      string
+		Approved bool
+		Reason   string
+	}{
+		Name:     event.Name,
+		FIO:      user.FIO.String(),
+		Approved: approved,
+		Reason:   reason,
+	})
+}
+
+func (h Handler) approvalCard(c tele.Context) error {
+	event, user, err := h.approvalRequest(c)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get approval request: %v", c.Sender().ID, err)
+		return c.Edit(
+			h.layout.Text(c, "technical_issues", err.Error()),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+
+	return c.Edit(
+		h.approvalRequestText(c, event, user),
+		h.layout.Markup(c, "clubOwner:approval", struct {
+			ID     string
+			UserID int64
+		}{
+			ID:     event.ID,
+			UserID: user.ID,
+		}),
+	)
+}
+
+func (h Handler) approveRegistration(c tele.Context) error {
+	event, user, err := h.approvalRequest(c)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get approval request: %v", c.Sender().ID, err)
+		return c.Edit(
+			h.layout.Text(c, "technical_issues", err.Error()),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+	h.logger.Infof("(user: %d) approve registration (event_id=%s, user_id=%d)", c.Sender().ID, event.ID, user.ID)
+
+	_, err = h.eventParticipantService.Approve(context.Background(), event.ID, user.ID)
+	if err != nil {
+		if errors.Is(err, errorz.ErrNotPending) || errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Edit(
+				h.layout.Text(c, "approval_already_processed"),
+				h.layout.Markup(c, "core:hide"),
+			)
+		}
+		h.logger.Errorf("(user: %d) error while approve registration: %v", c.Sender().ID, err)
+		return c.Edit(
+			h.layout.Text(c, "technical_issues", err.Error()),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+
+	return c.Edit(
+		h.approvalAnsweredText(c, event, user, true, ""),
+		h.layout.Markup(c, "core:hide"),
+	)
+}
+
+func (h Handler) rejectRegistration(c tele.Context) error {
+	event, user, err := h.approvalRequest(c)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get approval request: %v", c.Sender().ID, err)
+		return c.Edit(
+			h.layout.Text(c, "technical_issues", err.Error()),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+	h.logger.Infof("(user: %d) reject registration (event_id=%s, user_id=%d)", c.Sender().ID, event.ID, user.ID)
+
+	participant, err := h.eventParticipantService.Get(context.Background(), event.ID, user.ID)
+	if err != nil || !participant.IsPending() {
+		return c.Edit(
+			h.layout.Text(c, "approval_already_processed"),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+
+	skipBtn := h.layout.Button(c, "clubOwner:approval:reject:skip")
+	backBtn := h.layout.Button(c, "clubOwner:approval:reject:back", struct {
+		ID     string
+		UserID int64
+	}{
+		ID:     event.ID,
+		UserID: user.ID,
+	})
+	markup := c.Bot().NewMarkup()
+	markup.Inline(markup.Row(*skipBtn), markup.Row(*backBtn))
+
+	inputCollector := collector.New()
+	_ = c.Edit(
+		h.layout.Text(c, "input_approval_reject_reason", struct {
+			FIO string
+		}{
+			FIO: user.FIO.String(),
+		}),
+		markup,
+	)
+	inputCollector.Collect(c.Message())
+
+	var (
+		reason string
+		done   bool
+	)
+	for !done {
+		response, errGet := h.input.Get(context.Background(), c.Sender().ID, 0, skipBtn)
+		if response.Message != nil {
+			inputCollector.Collect(response.Message)
+		}
+		switch {
+		case response.Canceled:
+			_ = inputCollector.Clear(c, collector.ClearOptions{IgnoreErrors: true, ExcludeLast: true})
+			return nil
+		case errGet != nil:
+			h.logger.Errorf("(user: %d) error while input reject reason: %v", c.Sender().ID, errGet)
+			_ = inputCollector.Send(c,
+				h.layout.Text(c, "input_error", h.layout.Text(c, "input_approval_reject_reason", struct {
+					FIO string
+				}{
+					FIO: user.FIO.String(),
+				})),
+				markup,
+			)
+		case response.Callback != nil:
+			done = true
+		case !validator.ApprovalRejectReason(response.Message.Text, nil):
+			_ = inputCollector.Send(c,
+				h.layout.Text(c, "invalid_approval_reject_reason"),
+				markup,
+			)
+		default:
+			reason = strings.TrimSpace(response.Message.Text)
+			done = true
+		}
+	}
+	_ = inputCollector.Clear(c, collector.ClearOptions{IgnoreErrors: true})
+
+	err = h.eventParticipantService.Reject(context.Background(), event.ID, user.ID, reason)
+	if err != nil {
+		if errors.Is(err, errorz.ErrNotPending) || errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Send(
+				h.layout.Text(c, "approval_already_processed"),
+				h.layout.Markup(c, "core:hide"),
+			)
+		}
+		h.logger.Errorf("(user: %d) error while reject registration: %v", c.Sender().ID, err)
+		return c.Send(
+			h.layout.Text(c, "technical_issues", err.Error()),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+
+	return c.Send(
+		h.approvalAnsweredText(c, event, user, false, reason),
+		h.layout.Markup(c, "core:hide"),
+	)
+}
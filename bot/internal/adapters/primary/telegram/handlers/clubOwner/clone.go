@@ -0,0 +1,145 @@
+package clubowner
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/nlypage/intele/collector"
+	tele "gopkg.in/telebot.v3"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/common/errorz"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/banner"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/location"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/validator"
+)
+
+// cloneEvent creates a draft from the event, the owner only enters the dates of the new event
+func (h Handler) cloneEvent(c tele.Context) error {
+	data := strings.Split(c.Callback().Data, " ")
+	if len(data) != 2 {
+		return errorz.ErrInvalidCallbackData
+	}
+
+	eventID := data[0]
+	page := data[1]
+	h.logger.Infof("(user: %d) clone event (event_id=%s)", c.Sender().ID, eventID)
+
+	backMarkup := h.layout.Markup(c, "clubOwner:event:back", struct {
+		ID   string
+		Page string
+	}{
+		ID:   eventID,
+		Page: page,
+	})
+
+	event, err := h.eventService.Get(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			backMarkup,
+		)
+	}
+
+	if _, isPrimary, errHost := h.eventClubID(context.Background(), c.Sender().ID, event); errHost != nil || !isPrimary {
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", errorz.ErrNotEventHost.Error())),
+			backMarkup,
+		)
+	}
+
+	questions, err := h.eventParticipantService.GetQuestions(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event questions: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			backMarkup,
+		)
+	}
+
+	const timeLayout = "02.01.2006 15:04"
+
+	inputCollector := collector.New()
+	startTimeStr, ok := h.inputEventValue(c, inputCollector, eventID, page, "clubOwner:event:back",
+		"input_event_start_time",
+		struct{}{},
+		"invalid_event_start_time",
+		validator.EventStartTime,
+		nil,
+	)
+	if !ok {
+		return nil
+	}
+
+	// Окончание спрашиваем, только если оно было указано у исходного мероприятия
+	var endTimeStr string
+	if !event.EndTime.IsZero() {
+		endTimeStr, ok = h.inputEventValue(c, inputCollector, eventID, page, "clubOwner:event:back",
+			"input_event_end_time",
+			struct{}{},
+			"invalid_event_end_time",
+			validator.EventEndTime,
+			map[string]interface{}{
+				"startTime": startTimeStr,
+			},
+		)
+		if !ok {
+			return nil
+		}
+	}
+
+	registrationEndStr, ok := h.inputEventValue(c, inputCollector, eventID, page, "clubOwner:event:back",
+		"input_event_registered_end_time",
+		struct {
+			MaxRegisteredEndTime string
+		}{
+			MaxRegisteredEndTime: startTimeStr,
+		},
+		"invalid_event_registered_end_time",
+		validator.EventRegisteredEndTime,
+		map[string]interface{}{
+			"startTime": startTimeStr,
+		},
+	)
+	if !ok {
+		return nil
+	}
+
+	clone := event.Duplicate(questions)
+
+	startTime, _ := time.ParseInLocation(timeLayout, startTimeStr, location.Location())
+	clone.StartTime = startTime.UTC()
+	if endTimeStr != "" {
+		endTime, _ := time.ParseInLocation(timeLayout, endTimeStr, location.Location())
+		clone.EndTime = endTime.UTC()
+	}
+	registrationEnd, _ := time.ParseInLocation(timeLayout, registrationEndStr, location.Location())
+	clone.RegistrationEnd = registrationEnd.UTC()
+
+	created, err := h.eventService.Create(context.Background(), &clone)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while create event clone: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.ClubOwner.Caption(h.eventSaveErrorText(c, err)),
+			backMarkup,
+		)
+	}
+
+	return c.Send(
+		banner.ClubOwner.Caption(h.layout.Text(c, "event_cloned", struct {
+			Name      string
+			StartTime string
+		}{
+			Name:      created.Name,
+			StartTime: created.StartTime.In(location.Location()).Format(timeLayout),
+		})),
+		h.layout.Markup(c, "clubOwner:event:cloned", struct {
+			ID   string
+			Page string
+		}{
+			ID:   created.ID,
+			Page: page,
+		}),
+	)
+}
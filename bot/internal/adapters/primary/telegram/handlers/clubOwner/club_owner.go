@@ -20,11 +20,14 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/adapters/primary/telegram/handlers/middlewares"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/adapters/secondary/redis/callbacks"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/adapters/secondary/redis/events"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/common/errorz"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/dto"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/banner"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/calendar"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/export"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/location"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/validator"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/ports/primary"
@@ -37,15 +40,20 @@ type Handler struct {
 	logger *types.Logger
 	input  *intele.InputManager
 
-	eventsStorage *events.Storage
+	eventsStorage    *events.Storage
+	callbacksStorage callbacks.CallbackStorage
 
 	clubService             primary.ClubService
 	clubOwnerService        primary.ClubOwnerService
 	userService             primary.UserService
 	eventService            primary.EventService
 	eventParticipantService primary.EventParticipantService
+	eventCoHostService      primary.EventCoHostService
+	feedbackService         primary.FeedbackService
+	eventPublishService     primary.EventPublishService
 	qrService               primary.QrService
 	notificationService     primary.NotifyService
+	venueService            primary.VenueService
 
 	mailingChannelID       int64
 	avatarChannelID        int64
@@ -59,13 +67,18 @@ func NewHandler(
 	lg *types.Logger,
 	in *intele.InputManager,
 	eventsStorage *events.Storage,
+	callbacksStorage callbacks.CallbackStorage,
 	clubSvc primary.ClubService,
 	clubOwnerSvc primary.ClubOwnerService,
 	userSvc primary.UserService,
 	eventSvc primary.EventService,
 	eventParticipantSvc primary.EventParticipantService,
+	eventCoHostSvc primary.EventCoHostService,
+	feedbackSvc primary.FeedbackService,
+	eventPublishSvc primary.EventPublishService,
 	qrSvc primary.QrService,
 	notifySvc primary.NotifyService,
+	venueSvc primary.VenueService,
 	mailingChannelID int64,
 	avatarChannelID int64,
 	introChannelID int64,
@@ -77,15 +90,20 @@ func NewHandler(
 		logger: lg,
 		input:  in,
 
-		eventsStorage: eventsStorage,
+		eventsStorage:    eventsStorage,
+		callbacksStorage: callbacksStorage,
 
 		clubService:             clubSvc,
 		clubOwnerService:        clubOwnerSvc,
 		userService:             userSvc,
 		eventService:            eventSvc,
 		eventParticipantService: eventParticipantSvc,
+		eventCoHostService:      eventCoHostSvc,
+		feedbackService:         feedbackSvc,
+		eventPublishService:     eventPublishSvc,
 		qrService:               qrSvc,
 		notificationService:     notifySvc,
+		venueService:            venueSvc,
 
 		mailingChannelID:       mailingChannelID,
 		avatarChannelID:        avatarChannelID,
@@ -198,6 +216,11 @@ func (h Handler) clubMenu(c tele.Context) error {
 		)
 	}
 
+	feedback, err := h.feedbackService.GetClubSummary(context.Background(), club.ID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get club feedback summary: %v", c.Sender().ID, err)
+	}
+
 	_ = c.Delete()
 
 	if clubIntro != nil {
@@ -220,11 +243,13 @@ func (h Handler) clubMenu(c tele.Context) error {
 				FileReader: clubAvatar.FileReader,
 			},
 			Caption: h.layout.Text(c, "club_owner_club_menu_text", struct {
-				Club   entity.Club
-				Owners []dto.ClubOwner
+				Club     entity.Club
+				Owners   []dto.ClubOwner
+				Feedback dto.FeedbackSummary
 			}{
-				Club:   *club,
-				Owners: clubOwners,
+				Club:     *club,
+				Owners:   clubOwners,
+				Feedback: feedback,
 			}),
 		}
 
@@ -236,11 +261,13 @@ func (h Handler) clubMenu(c tele.Context) error {
 
 	return c.Send(
 		banner.ClubOwner.Caption(h.layout.Text(c, "club_owner_club_menu_text", struct {
-			Club   entity.Club
-			Owners []dto.ClubOwner
+			Club     entity.Club
+			Owners   []dto.ClubOwner
+			Feedback dto.FeedbackSummary
 		}{
-			Club:   *club,
-			Owners: clubOwners,
+			Club:     *club,
+			Owners:   clubOwners,
+			Feedback: feedback,
 		})),
 		menuMarkup,
 	)
@@ -1329,6 +1356,7 @@ func (h Handler) createEvent(c tele.Context) error {
 	h.logger.Infof("(user: %d) create new event request(club=%s)", c.Sender().ID, clubID)
 
 	h.eventsStorage.Clear(c.Sender().ID)
+	h.eventsStorage.ClearSeries(c.Sender().ID)
 
 	club, err := h.clubService.Get(context.Background(), clubID)
 	if err != nil {
@@ -1343,6 +1371,16 @@ func (h Handler) createEvent(c tele.Context) error {
 		)
 	}
 
+	venues, err := h.venueService.GetAll(context.Background())
+	if err != nil {
+		// Без каталога площадок место проведения можно ввести текстом
+		h.logger.Errorf("(user: %d) error while get venues: %v", c.Sender().ID, err)
+	}
+	var venueBtn *tele.Btn
+	if len(venues) > 0 {
+		venueBtn = h.layout.Button(c, "clubOwner:event:venue", venues[0])
+	}
+
 	inputCollector := collector.New()
 	inputCollector.Collect(c.Message())
 
@@ -1356,6 +1394,7 @@ func (h Handler) createEvent(c tele.Context) error {
 		validator   func(string, map[string]interface{}) bool
 		paramsFunc  func(map[string]interface{}) map[string]interface{}
 		callbackBtn *tele.Btn
+		buttons     [][]tele.InlineButton
 	}
 
 	steps = []struct {
@@ -1366,6 +1405,7 @@ func (h Handler) createEvent(c tele.Context) error {
 		validator   func(string, map[string]interface{}) bool
 		paramsFunc  func(map[string]interface{}) map[string]interface{}
 		callbackBtn *tele.Btn
+		buttons     [][]tele.InlineButton
 	}{
 		{
 			promptKey: "input_event_name",
@@ -1394,13 +1434,18 @@ func (h Handler) createEvent(c tele.Context) error {
 		{
 			promptKey: "input_event_location",
 			objectFunc: func() interface{} {
-				return struct{}{}
+				return struct {
+					HasVenues bool
+				}{
+					HasVenues: len(venues) > 0,
+				}
 			},
 			errorKey:    "invalid_event_location",
 			result:      new(string),
 			validator:   validator.EventLocation,
 			paramsFunc:  nil,
-			callbackBtn: nil,
+			callbackBtn: venueBtn,
+			buttons:     h.venueButtons(c, venues),
 		},
 		{
 			promptKey: "input_event_start_time",
@@ -1465,12 +1510,28 @@ func (h Handler) createEvent(c tele.Context) error {
 		{
 			promptKey: "input_max_participants",
 			objectFunc: func() interface{} {
-				return struct{}{}
+				var capacity int
+				if venue := findVenue(venues, *steps[2].result); venue != nil {
+					capacity = venue.Capacity
+				}
+				return struct {
+					Capacity int
+				}{
+					Capacity: capacity,
+				}
+			},
+			errorKey:  "invalid_max_participants",
+			result:    new(string),
+			validator: validator.EventMaxParticipants,
+			paramsFunc: func(params map[string]interface{}) map[string]interface{} {
+				if params == nil {
+					params = make(map[string]interface{})
+				}
+				if venue := findVenue(venues, *steps[2].result); venue != nil {
+					params["capacity"] = venue.Capacity
+				}
+				return params
 			},
-			errorKey:    "invalid_max_participants",
-			result:      new(string),
-			validator:   validator.EventMaxParticipants,
-			paramsFunc:  nil,
 			callbackBtn: nil,
 		},
 		{
@@ -1486,7 +1547,8 @@ func (h Handler) createEvent(c tele.Context) error {
 		},
 	}
 
-	for _, step := range steps {
+	for i := 0; i < len(steps); i++ {
+		step := steps[i]
 		done := false
 
 		var params map[string]interface{}
@@ -1499,7 +1561,14 @@ func (h Handler) createEvent(c tele.Context) error {
 		}{
 			ID: club.ID,
 		})
-		if step.callbackBtn != nil {
+		// Несколько кнопок с одним unique (площадки) заменяют строку с callbackBtn
+		switch {
+		case step.buttons != nil:
+			markup.InlineKeyboard = append(
+				slices.Clone(step.buttons),
+				markup.InlineKeyboard...,
+			)
+		case step.callbackBtn != nil:
 			markup.InlineKeyboard = append(
 				[][]tele.InlineButton{{*step.callbackBtn.Inline()}},
 				markup.InlineKeyboard...,
@@ -1539,7 +1608,8 @@ func (h Handler) createEvent(c tele.Context) error {
 					}),
 				)
 			case response.Callback != nil:
-				*step.result = ""
+				// У кнопок пропуска нет данных, а кнопки площадок передают её id
+				*step.result = response.Callback.Data
 				_ = inputCollector.Clear(c, collector.ClearOptions{IgnoreErrors: true})
 				done = true
 			case !step.validator(response.Message.Text, params):
@@ -1557,6 +1627,21 @@ func (h Handler) createEvent(c tele.Context) error {
 				done = true
 			}
 		}
+
+		// Когда известно время, проверяем, что площадка свободна, иначе просим ввести время заново
+		if i == 4 {
+			if booked := h.venueBookedText(c, findVenue(venues, *steps[2].result), *steps[3].result, *steps[4].result); booked != "" {
+				_ = inputCollector.Send(c,
+					banner.ClubOwner.Caption(booked),
+					h.layout.Markup(c, "clubOwner:club:back", struct {
+						ID string
+					}{
+						ID: club.ID,
+					}),
+				)
+				i = 2
+			}
+		}
 	}
 
 	// Результаты ввода
@@ -1565,11 +1650,8 @@ func (h Handler) createEvent(c tele.Context) error {
 	var (
 		eventDescription             string
 		eventStartTime               time.Time
-		eventStartTimeStr            string
 		eventEndTime                 time.Time
-		eventEndTimeStr              string
 		eventRegistrationEndTime     time.Time
-		eventRegistrationEndTimeStr  string
 		eventAfterRegistrationText   string
 		eventMaxParticipants         int
 		eventMaxExpectedParticipants int
@@ -1577,33 +1659,18 @@ func (h Handler) createEvent(c tele.Context) error {
 
 	eventDescription = *steps[1].result
 	eventStartTime, _ = time.ParseInLocation(timeLayout, *steps[3].result, location.Location())
-	eventStartTimeStr = eventStartTime.Format(timeLayout)
 
 	eventEndTime, err = time.ParseInLocation(timeLayout, *steps[4].result, location.Location())
-	eventEndTimeStr = eventEndTime.Format(timeLayout)
 	if err != nil {
 		eventEndTime = time.Time{}
-		eventEndTimeStr = ""
 	}
 
 	eventRegistrationEndTime, _ = time.ParseInLocation(timeLayout, *steps[5].result, location.Location())
-	eventRegistrationEndTimeStr = eventRegistrationEndTime.Format(timeLayout)
 
 	eventAfterRegistrationText = *steps[6].result
 	eventMaxParticipants, _ = strconv.Atoi(*steps[7].result)
 	eventMaxExpectedParticipants, _ = strconv.Atoi(*steps[8].result)
 
-	locationSubstrings := h.passLocationSubstrings
-	passRequired := len(locationSubstrings) == 0
-	if !passRequired {
-		for _, substring := range locationSubstrings {
-			if strings.Contains(strings.ToLower(*steps[2].result), strings.ToLower(substring)) {
-				passRequired = true
-				break
-			}
-		}
-	}
-
 	event := entity.Event{
 		ClubID:                club.ID,
 		Name:                  *steps[0].result,
@@ -1615,62 +1682,15 @@ func (h Handler) createEvent(c tele.Context) error {
 		AfterRegistrationText: eventAfterRegistrationText,
 		MaxParticipants:       eventMaxParticipants,
 		ExpectedParticipants:  eventMaxExpectedParticipants,
-		PassRequired:          passRequired,
-	}
-	h.eventsStorage.Set(c.Sender().ID, event, 0)
-
-	markup := h.layout.Markup(c, "clubOwner:createClub:confirm", struct {
-		ID string
-	}{
-		ID: clubID,
-	})
-
-	var row []tele.InlineButton
-	for _, role := range club.AllowedRoles {
-		row = append(row, []tele.InlineButton{*h.layout.Button(c, "clubOwner:create_event:role", struct {
-			Role     entity.Role
-			ID       string
-			RoleName string
-			Allowed  bool
-		}{
-			Role:     entity.Role(role),
-			ID:       club.ID,
-			RoleName: h.layout.Text(c, role),
-			Allowed:  slices.Contains(event.AllowedRoles, role),
-		}).Inline()}...)
+		PassRequired:          h.isPassRequired(*steps[2].result),
 	}
-
-	markup.InlineKeyboard = append(
-		[][]tele.InlineButton{row},
-		markup.InlineKeyboard...,
-	)
-
-	confirmationPayload := struct {
-		Name                  string
-		Description           string
-		Location              string
-		StartTime             string
-		EndTime               string
-		RegistrationEnd       string
-		AfterRegistrationText string
-		MaxParticipants       int
-		ExpectedParticipants  int
-	}{
-		Name:                  event.Name,
-		Description:           event.Description,
-		Location:              event.Location,
-		StartTime:             eventStartTimeStr,
-		EndTime:               eventEndTimeStr,
-		RegistrationEnd:       eventRegistrationEndTimeStr,
-		AfterRegistrationText: event.AfterRegistrationText,
-		MaxParticipants:       event.MaxParticipants,
-		ExpectedParticipants:  event.ExpectedParticipants,
+	if venue := findVenue(venues, *steps[2].result); venue != nil {
+		event.SetVenue(venue)
 	}
+	h.eventsStorage.Set(c.Sender().ID, event, 0)
 
-	return c.Send(
-		banner.ClubOwner.Caption(h.layout.Text(c, "event_confirmation", confirmationPayload)),
-		markup,
-	)
+	caption, markup := h.eventConfirmation(c, club, event)
+	return c.Send(caption, markup)
 }
 
 func (h Handler) eventAllowedRoles(c tele.Context) error {
@@ -1724,6 +1744,159 @@ func (h Handler) eventAllowedRoles(c tele.Context) error {
 
 	h.eventsStorage.Set(c.Sender().ID, event, 0)
 
+	caption, markup := h.eventConfirmation(c, club, event)
+	return c.Edit(caption, markup)
+}
+
+func (h Handler) eventCategories(c tele.Context) error {
+	clubID := c.Callback().Data
+	if clubID == "" {
+		return errorz.ErrInvalidCallbackData
+	}
+
+	h.logger.Infof("(user: %d) edit event categories (club_id=%s)", c.Sender().ID, clubID)
+
+	event, err := h.eventsStorage.Get(c.Sender().ID)
+	if err != nil {
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:club:back", struct {
+				ID string
+			}{
+				ID: clubID,
+			}),
+		)
+	}
+
+	caption, markup := h.eventCategoriesMenu(c, clubID, event)
+	return c.Edit(caption, markup)
+}
+
+func (h Handler) eventCategory(c tele.Context) error {
+	data := strings.Split(c.Callback().Data, " ")
+	if len(data) != 2 {
+		return errorz.ErrInvalidCallbackData
+	}
+	clubID, category := data[0], entity.EventCategory(data[1])
+	if !category.IsValid() {
+		return errorz.ErrInvalidCallbackData
+	}
+
+	event, err := h.eventsStorage.Get(c.Sender().ID)
+	if err != nil {
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:club:back", struct {
+				ID string
+			}{
+				ID: clubID,
+			}),
+		)
+	}
+
+	event.ToggleCategory(category)
+	h.eventsStorage.Set(c.Sender().ID, event, 0)
+
+	caption, markup := h.eventCategoriesMenu(c, clubID, event)
+	return c.Edit(caption, markup)
+}
+
+func (h Handler) eventCategoriesDone(c tele.Context) error {
+	clubID := c.Callback().Data
+	if clubID == "" {
+		return errorz.ErrInvalidCallbackData
+	}
+
+	club, err := h.clubService.Get(context.Background(), clubID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get club: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:club:back", struct {
+				ID string
+			}{
+				ID: clubID,
+			}),
+		)
+	}
+
+	event, err := h.eventsStorage.Get(c.Sender().ID)
+	if err != nil {
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:club:back", struct {
+				ID string
+			}{
+				ID: clubID,
+			}),
+		)
+	}
+
+	caption, markup := h.eventConfirmation(c, club, event)
+	return c.Edit(caption, markup)
+}
+
+// eventCategoriesMenu returns the caption and the markup of the categories choice of the created event
+func (h Handler) eventCategoriesMenu(c tele.Context, clubID string, event entity.Event) (interface{}, *tele.ReplyMarkup) {
+	markup := h.layout.Markup(c, "clubOwner:create_event:categories", struct {
+		ID string
+	}{
+		ID: clubID,
+	})
+
+	var rows [][]tele.InlineButton
+	for i, category := range entity.EventCategories {
+		if i%2 == 0 {
+			rows = append(rows, nil)
+		}
+		rows[len(rows)-1] = append(rows[len(rows)-1], *h.layout.Button(c, "clubOwner:create_event:category", struct {
+			ID       string
+			Category entity.EventCategory
+			Name     string
+			Selected bool
+		}{
+			ID:       clubID,
+			Category: category,
+			Name:     h.layout.Text(c, "category_"+category.String()),
+			Selected: event.HasCategory(category),
+		}).Inline())
+	}
+	markup.InlineKeyboard = append(rows, markup.InlineKeyboard...)
+
+	return banner.ClubOwner.Caption(h.layout.Text(c, "event_categories_text")), markup
+}
+
+// eventCategoryNames returns comma separated names of the event categories
+func (h Handler) eventCategoryNames(c tele.Context, event entity.Event) string {
+	names := make([]string, 0, len(event.Categories))
+	for _, category := range entity.EventCategories {
+		if event.HasCategory(category) {
+			names = append(names, h.layout.Text(c, "category_"+category.String()))
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// isPassRequired checks if the event in the given location requires passes
+//
+// If no pass location substrings are configured, passes are required for every event
+func (h Handler) isPassRequired(eventLocation string) bool {
+	if len(h.passLocationSubstrings) == 0 {
+		return true
+	}
+
+	for _, substring := range h.passLocationSubstrings {
+		if strings.Contains(strings.ToLower(eventLocation), strings.ToLower(substring)) {
+			return true
+		}
+	}
+	return false
+}
+
+// eventConfirmation returns the caption and the markup of the event creation confirmation
+func (h Handler) eventConfirmation(c tele.Context, club *entity.Club, event entity.Event) (interface{}, *tele.ReplyMarkup) {
+	const timeLayout = "02.01.2006 15:04"
+
 	markup := h.layout.Markup(c, "clubOwner:createClub:confirm", struct {
 		ID string
 	}{
@@ -1731,7 +1904,7 @@ func (h Handler) eventAllowedRoles(c tele.Context) error {
 	})
 
 	var row []tele.InlineButton
-	for _, role = range club.AllowedRoles {
+	for _, role := range club.AllowedRoles {
 		row = append(row, []tele.InlineButton{*h.layout.Button(c, "clubOwner:create_event:role", struct {
 			Role     entity.Role
 			ID       string
@@ -1750,11 +1923,15 @@ func (h Handler) eventAllowedRoles(c tele.Context) error {
 		markup.InlineKeyboard...,
 	)
 
-	const timeLayout = "02.01.2006 15:04"
-
-	eventTimeStr := event.EndTime.Format(timeLayout)
+	endTime := event.EndTime.In(location.Location()).Format(timeLayout)
 	if event.EndTime.Year() == 1 {
-		eventTimeStr = ""
+		endTime = ""
+	}
+
+	var recurrence string
+	series, err := h.eventsStorage.GetSeries(c.Sender().ID)
+	if err == nil && series.IsBounded() {
+		recurrence = h.recurrenceSummary(c, series)
 	}
 
 	confirmationPayload := struct {
@@ -1767,22 +1944,25 @@ func (h Handler) eventAllowedRoles(c tele.Context) error {
 		AfterRegistrationText string
 		MaxParticipants       int
 		ExpectedParticipants  int
+		Recurrence            string
+		Categories            string
+		QuestionsCount        int
 	}{
 		Name:                  event.Name,
 		Description:           event.Description,
 		Location:              event.Location,
-		StartTime:             event.StartTime.Format(timeLayout),
-		EndTime:               eventTimeStr,
-		RegistrationEnd:       event.RegistrationEnd.Format(timeLayout),
+		StartTime:             event.StartTime.In(location.Location()).Format(timeLayout),
+		EndTime:               endTime,
+		RegistrationEnd:       event.RegistrationEnd.In(location.Location()).Format(timeLayout),
 		AfterRegistrationText: event.AfterRegistrationText,
 		MaxParticipants:       event.MaxParticipants,
 		ExpectedParticipants:  event.ExpectedParticipants,
+		Recurrence:            recurrence,
+		Categories:            h.eventCategoryNames(c, event),
+		QuestionsCount:        len(event.Questions),
 	}
 
-	return c.Edit(
-		banner.ClubOwner.Caption(h.layout.Text(c, "event_confirmation", confirmationPayload)),
-		markup,
-	)
+	return banner.ClubOwner.Caption(h.layout.Text(c, "event_confirmation", confirmationPayload)), markup
 }
 
 func (h Handler) confirmEventCreation(c tele.Context) error {
@@ -1813,11 +1993,54 @@ func (h Handler) confirmEventCreation(c tele.Context) error {
 	event.StartTime = event.StartTime.UTC()
 	event.EndTime = event.EndTime.UTC()
 	event.RegistrationEnd = event.RegistrationEnd.UTC()
+	if c.Callback().Unique == "cOwner_evDraft" {
+		event.Status = entity.EventStatusDraft
+	}
+
+	series, errSeries := h.eventsStorage.GetSeries(c.Sender().ID)
+	if errSeries == nil && series.IsBounded() {
+		h.logger.Infof("(user: %d) create event series (club_id=%s)", c.Sender().ID, clubID)
+
+		createdSeries, err := h.eventService.CreateSeries(context.Background(), event, &series)
+		if err != nil {
+			h.logger.Errorf("(user: %d) error while create event series: %v", c.Sender().ID, err)
+			return c.Edit(
+				banner.ClubOwner.Caption(h.eventSaveErrorText(c, err)),
+				h.layout.Markup(c, "clubOwner:club:back", struct {
+					ID string
+				}{
+					ID: clubID,
+				}),
+			)
+		}
+
+		h.eventsStorage.Clear(c.Sender().ID)
+		h.eventsStorage.ClearSeries(c.Sender().ID)
+
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "event_series_created", struct {
+				Name       string
+				Count      int
+				Recurrence string
+				IsDraft    bool
+			}{
+				Name:       event.Name,
+				Count:      len(createdSeries.Events),
+				Recurrence: h.recurrenceSummary(c, *createdSeries),
+				IsDraft:    event.IsDraft(),
+			})),
+			h.layout.Markup(c, "clubOwner:club:back", struct {
+				ID string
+			}{
+				ID: clubID,
+			}))
+	}
 
 	_, err = h.eventService.Create(context.Background(), &event)
 	if err != nil {
+		h.logger.Errorf("(user: %d) error while create event: %v", c.Sender().ID, err)
 		return c.Edit(
-			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			banner.ClubOwner.Caption(h.eventSaveErrorText(c, err)),
 			h.layout.Markup(c, "clubOwner:club:back", struct {
 				ID string
 			}{
@@ -1830,9 +2053,11 @@ func (h Handler) confirmEventCreation(c tele.Context) error {
 
 	return c.Edit(
 		banner.ClubOwner.Caption(h.layout.Text(c, "event_created", struct {
-			Name string
+			Name    string
+			IsDraft bool
 		}{
-			Name: event.Name,
+			Name:    event.Name,
+			IsDraft: event.IsDraft(),
 		})),
 		h.layout.Markup(c, "clubOwner:club:back", struct {
 			ID string
@@ -1841,26 +2066,380 @@ func (h Handler) confirmEventCreation(c tele.Context) error {
 		}))
 }
 
-func (h Handler) eventsList(c tele.Context) error {
-	const eventsOnPage = 5
-	h.logger.Infof("(user: %d) edit events list", c.Sender().ID)
+func (h Handler) eventRecurrence(c tele.Context) error {
+	clubID := c.Callback().Data
+	if clubID == "" {
+		return errorz.ErrInvalidCallbackData
+	}
 
-	var (
-		p           int
-		prevPage    int
-		nextPage    int
-		err         error
-		eventsCount int64
-		e           []entity.Event
-		rows        []tele.Row
-		menuRow     tele.Row
-	)
+	h.logger.Infof("(user: %d) edit event recurrence (club_id=%s)", c.Sender().ID, clubID)
 
-	clubID, p, err := parseEventCallback(c.Callback().Data)
+	event, err := h.eventsStorage.Get(c.Sender().ID)
 	if err != nil {
-		return err
-	}
-
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:club:back", struct {
+				ID string
+			}{
+				ID: clubID,
+			}),
+		)
+	}
+
+	series, err := h.eventsStorage.GetSeries(c.Sender().ID)
+	if err != nil {
+		series = entity.EventSeries{
+			Frequency: entity.RecurrenceWeekly,
+			Weekdays:  []int64{int64(event.StartTime.In(location.Location()).Weekday())},
+			StartTime: event.StartTime,
+		}
+		h.eventsStorage.SetSeries(c.Sender().ID, series, 0)
+	}
+
+	caption, markup := h.eventRecurrenceMenu(c, clubID, series)
+	return c.Edit(caption, markup)
+}
+
+func (h Handler) eventRecurrenceFrequency(c tele.Context) error {
+	data := strings.Split(c.Callback().Data, " ")
+	if len(data) != 2 {
+		return errorz.ErrInvalidCallbackData
+	}
+	clubID, frequency := data[0], entity.RecurrenceFrequency(data[1])
+	if frequency != entity.RecurrenceWeekly && frequency != entity.RecurrenceBiweekly {
+		return errorz.ErrInvalidCallbackData
+	}
+
+	series, err := h.eventsStorage.GetSeries(c.Sender().ID)
+	if err != nil {
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:club:back", struct {
+				ID string
+			}{
+				ID: clubID,
+			}),
+		)
+	}
+
+	if series.Frequency == frequency {
+		return c.Respond()
+	}
+
+	series.Frequency = frequency
+	h.eventsStorage.SetSeries(c.Sender().ID, series, 0)
+
+	caption, markup := h.eventRecurrenceMenu(c, clubID, series)
+	return c.Edit(caption, markup)
+}
+
+func (h Handler) eventRecurrenceWeekday(c tele.Context) error {
+	data := strings.Split(c.Callback().Data, " ")
+	if len(data) != 2 {
+		return errorz.ErrInvalidCallbackData
+	}
+	clubID := data[0]
+	weekday, err := strconv.Atoi(data[1])
+	if err != nil || weekday < int(time.Sunday) || weekday > int(time.Saturday) {
+		return errorz.ErrInvalidCallbackData
+	}
+	day := time.Weekday(weekday)
+
+	series, err := h.eventsStorage.GetSeries(c.Sender().ID)
+	if err != nil {
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:club:back", struct {
+				ID string
+			}{
+				ID: clubID,
+			}),
+		)
+	}
+
+	if len(series.Weekdays) == 1 && series.HasWeekday(day) {
+		return c.Respond(&tele.CallbackResponse{
+			Text:      h.layout.Text(c, "recurrence_weekday_required"),
+			ShowAlert: true,
+		})
+	}
+
+	series.ToggleWeekday(day)
+	h.eventsStorage.SetSeries(c.Sender().ID, series, 0)
+
+	caption, markup := h.eventRecurrenceMenu(c, clubID, series)
+	return c.Edit(caption, markup)
+}
+
+func (h Handler) eventRecurrenceEnd(c tele.Context) error {
+	clubID := c.Callback().Data
+	if clubID == "" {
+		return errorz.ErrInvalidCallbackData
+	}
+
+	h.logger.Infof("(user: %d) input event recurrence end (club_id=%s)", c.Sender().ID, clubID)
+
+	club, err := h.clubService.Get(context.Background(), clubID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get club: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:club:back", struct {
+				ID string
+			}{
+				ID: clubID,
+			}),
+		)
+	}
+
+	event, err := h.eventsStorage.Get(c.Sender().ID)
+	if err != nil {
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:club:back", struct {
+				ID string
+			}{
+				ID: clubID,
+			}),
+		)
+	}
+
+	series, err := h.eventsStorage.GetSeries(c.Sender().ID)
+	if err != nil {
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:club:back", struct {
+				ID string
+			}{
+				ID: clubID,
+			}),
+		)
+	}
+
+	backMarkup := h.layout.Markup(c, "clubOwner:create_event:repeat:back", struct {
+		ID string
+	}{
+		ID: clubID,
+	})
+	prompt := h.layout.Text(c, "input_event_recurrence_end", struct {
+		MaxCount int
+	}{
+		MaxCount: entity.MaxSeriesOccurrences,
+	})
+
+	inputCollector := collector.New()
+	_ = c.Edit(banner.ClubOwner.Caption(prompt), backMarkup)
+	inputCollector.Collect(c.Message())
+
+	params := map[string]interface{}{
+		"maxCount":  entity.MaxSeriesOccurrences,
+		"startTime": event.StartTime,
+	}
+
+	var (
+		end  string
+		done bool
+	)
+	for {
+		response, errGet := h.input.Get(context.Background(), c.Sender().ID, 0)
+		if response.Message != nil {
+			inputCollector.Collect(response.Message)
+		}
+		switch {
+		case response.Canceled:
+			_ = inputCollector.Clear(c, collector.ClearOptions{IgnoreErrors: true, ExcludeLast: true})
+			return nil
+		case errGet != nil:
+			h.logger.Errorf("(user: %d) error while input event recurrence end: %v", c.Sender().ID, errGet)
+			_ = inputCollector.Send(c,
+				banner.ClubOwner.Caption(h.layout.Text(c, "input_error", prompt)),
+				backMarkup,
+			)
+		case response.Message == nil:
+			_ = inputCollector.Send(c,
+				banner.ClubOwner.Caption(h.layout.Text(c, "input_error", prompt)),
+				backMarkup,
+			)
+		case !validator.EventRecurrenceEnd(response.Message.Text, params):
+			_ = inputCollector.Send(c,
+				banner.ClubOwner.Caption(h.layout.Text(c, "invalid_event_recurrence_end", struct {
+					MaxCount int
+				}{
+					MaxCount: entity.MaxSeriesOccurrences,
+				})),
+				backMarkup,
+			)
+		case validator.EventRecurrenceEnd(response.Message.Text, params):
+			end = response.Message.Text
+			_ = inputCollector.Clear(c, collector.ClearOptions{IgnoreErrors: true})
+			done = true
+		}
+		if done {
+			break
+		}
+	}
+
+	if count, errAtoi := strconv.Atoi(end); errAtoi == nil {
+		series.Count = count
+		series.Until = nil
+	} else {
+		untilDate, _ := time.ParseInLocation("02.01.2006", end, location.Location())
+		// Серия заканчивается в конце указанного дня
+		until := time.Date(untilDate.Year(), untilDate.Month(), untilDate.Day(), 23, 59, 59, 0, location.Location())
+		series.Count = 0
+		series.Until = &until
+	}
+	h.eventsStorage.SetSeries(c.Sender().ID, series, 0)
+
+	caption, markup := h.eventConfirmation(c, club, event)
+	return c.Send(caption, markup)
+}
+
+func (h Handler) eventRecurrenceDisable(c tele.Context) error {
+	clubID := c.Callback().Data
+	if clubID == "" {
+		return errorz.ErrInvalidCallbackData
+	}
+
+	h.logger.Infof("(user: %d) disable event recurrence (club_id=%s)", c.Sender().ID, clubID)
+
+	h.eventsStorage.ClearSeries(c.Sender().ID)
+
+	club, err := h.clubService.Get(context.Background(), clubID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get club: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:club:back", struct {
+				ID string
+			}{
+				ID: clubID,
+			}),
+		)
+	}
+
+	event, err := h.eventsStorage.Get(c.Sender().ID)
+	if err != nil {
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:club:back", struct {
+				ID string
+			}{
+				ID: clubID,
+			}),
+		)
+	}
+
+	caption, markup := h.eventConfirmation(c, club, event)
+	return c.Edit(caption, markup)
+}
+
+// eventRecurrenceMenu returns the caption and the markup of the recurrence settings of the created event
+func (h Handler) eventRecurrenceMenu(c tele.Context, clubID string, series entity.EventSeries) (interface{}, *tele.ReplyMarkup) {
+	markup := h.layout.Markup(c, "clubOwner:create_event:repeat", struct {
+		ID string
+	}{
+		ID: clubID,
+	})
+
+	var frequencyRow []tele.InlineButton
+	for _, frequency := range []entity.RecurrenceFrequency{entity.RecurrenceWeekly, entity.RecurrenceBiweekly} {
+		frequencyRow = append(frequencyRow, *h.layout.Button(c, "clubOwner:create_event:repeat:frequency", struct {
+			ID        string
+			Frequency entity.RecurrenceFrequency
+			Name      string
+			Selected  bool
+		}{
+			ID:        clubID,
+			Frequency: frequency,
+			Name:      h.layout.Text(c, "recurrence_"+string(frequency)),
+			Selected:  series.Frequency == frequency,
+		}).Inline())
+	}
+
+	weekdays := []time.Weekday{
+		time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday,
+	}
+	weekdayRows := make([][]tele.InlineButton, 2)
+	for i, day := range weekdays {
+		weekdayRows[i/4] = append(weekdayRows[i/4], *h.layout.Button(c, "clubOwner:create_event:repeat:weekday", struct {
+			ID       string
+			Weekday  int
+			Name     string
+			Selected bool
+		}{
+			ID:       clubID,
+			Weekday:  int(day),
+			Name:     h.layout.Text(c, "weekday_"+strconv.Itoa(int(day))),
+			Selected: series.HasWeekday(day),
+		}).Inline())
+	}
+
+	markup.InlineKeyboard = append(
+		append([][]tele.InlineButton{frequencyRow}, weekdayRows...),
+		markup.InlineKeyboard...,
+	)
+
+	return banner.ClubOwner.Caption(h.layout.Text(c, "event_recurrence", struct {
+		Frequency string
+		Weekdays  string
+		StartTime string
+	}{
+		Frequency: h.layout.Text(c, "recurrence_"+string(series.Frequency)),
+		Weekdays:  h.recurrenceWeekdays(c, series),
+		StartTime: series.StartTime.In(location.Location()).Format("02.01.2006 15:04"),
+	})), markup
+}
+
+// recurrenceSummary returns a short description of the recurrence rule, e.g. "каждую неделю (Пн, Ср), до 30.05.2025"
+func (h Handler) recurrenceSummary(c tele.Context, series entity.EventSeries) string {
+	var until string
+	if series.Until != nil {
+		until = series.Until.In(location.Location()).Format("02.01.2006")
+	}
+
+	return h.layout.Text(c, "event_recurrence_summary", struct {
+		Frequency string
+		Weekdays  string
+		Until     string
+		Count     int
+	}{
+		Frequency: h.layout.Text(c, "recurrence_"+string(series.Frequency)),
+		Weekdays:  h.recurrenceWeekdays(c, series),
+		Until:     until,
+		Count:     series.Count,
+	})
+}
+
+func (h Handler) recurrenceWeekdays(c tele.Context, series entity.EventSeries) string {
+	days := series.Days()
+	names := make([]string, 0, len(days))
+	for _, day := range days {
+		names = append(names, h.layout.Text(c, "weekday_"+strconv.Itoa(int(day))))
+	}
+	return strings.Join(names, ", ")
+}
+
+func (h Handler) eventsList(c tele.Context) error {
+	const eventsOnPage = 5
+	h.logger.Infof("(user: %d) edit events list", c.Sender().ID)
+
+	var (
+		p           int
+		prevPage    int
+		nextPage    int
+		err         error
+		eventsCount int64
+		e           []entity.Event
+		rows        []tele.Row
+		menuRow     tele.Row
+	)
+
+	clubID, p, err := parseEventCallback(c.Callback().Data)
+	if err != nil {
+		return err
+	}
+
 	eventsCount, err = h.eventService.CountByClubID(context.Background(), clubID)
 	if err != nil {
 		h.logger.Errorf("(user: %d) error while get events count: %v", c.Sender().ID, err)
@@ -1895,15 +2474,19 @@ func (h Handler) eventsList(c tele.Context) error {
 	markup := c.Bot().NewMarkup()
 	for _, event := range e {
 		rows = append(rows, markup.Row(*h.layout.Button(c, "clubOwner:events:event", struct {
-			ID     string
-			Page   int
-			Name   string
-			IsOver bool
-		}{
-			ID:     event.ID,
-			Page:   p,
-			Name:   event.Name,
-			IsOver: event.IsOver(0),
+			ID          string
+			Page        int
+			Name        string
+			IsOver      bool
+			IsCancelled bool
+			IsDraft     bool
+		}{
+			ID:          event.ID,
+			Page:        p,
+			Name:        event.Name,
+			IsOver:      event.IsOver(0),
+			IsCancelled: event.IsCancelled(),
+			IsDraft:     event.IsDraft(),
 		})))
 	}
 	pagesCount := (int(eventsCount) - 1) / eventsOnPage
@@ -1996,6 +2579,18 @@ func (h Handler) event(c tele.Context) error {
 		)
 	}
 
+	clubID, isPrimary, err := h.eventClubID(context.Background(), c.Sender().ID, event)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event club: %v", c.Sender().ID, err)
+		backMarkup := c.Bot().NewMarkup()
+		backMarkup.Inline(backMarkup.Row(*h.layout.Button(c, "clubOwner:myClubs:back")))
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			backMarkup,
+		)
+	}
+
+	// QR codes are allowed by the primary club of the event
 	club, err := h.clubService.Get(context.Background(), event.ClubID)
 	if err != nil {
 		h.logger.Errorf("(user: %d) error while get club: %v", c.Sender().ID, err)
@@ -2005,7 +2600,7 @@ func (h Handler) event(c tele.Context) error {
 				ID   string
 				Page string
 			}{
-				ID:   event.ClubID,
+				ID:   clubID,
 				Page: page,
 			}),
 		)
@@ -2020,7 +2615,7 @@ func (h Handler) event(c tele.Context) error {
 				ID   string
 				Page string
 			}{
-				ID:   event.ClubID,
+				ID:   clubID,
 				Page: page,
 			}),
 		)
@@ -2035,40 +2630,68 @@ func (h Handler) event(c tele.Context) error {
 				ID   string
 				Page string
 			}{
-				ID:   event.ClubID,
+				ID:   clubID,
 				Page: page,
 			}),
 		)
 	}
 
-	eventMarkup := h.layout.Markup(c, "clubOwner:event:menu", struct {
+	menuKey := "clubOwner:event:menu"
+	switch {
+	case !isPrimary:
+		menuKey = "clubOwner:event:menu:co_host"
+	case event.IsCancelled():
+		menuKey = "clubOwner:event:menu:cancelled"
+	case event.IsDraft():
+		menuKey = "clubOwner:event:menu:draft"
+	}
+	eventMarkup := h.layout.Markup(c, menuKey, struct {
 		ID     string
 		ClubID string
 		Page   string
 	}{
 		ID:     eventID,
-		ClubID: event.ClubID,
+		ClubID: clubID,
 		Page:   page,
 	})
 
-	if club.QrAllowed {
+	if club.QrAllowed && !event.IsCancelled() && !event.IsDraft() {
 		eventMarkup.InlineKeyboard = append(
-			[][]tele.InlineButton{{*h.layout.Button(c, "clubOwner:event:qr", struct {
-				ID   string
-				Page string
-			}{
-				ID:   eventID,
-				Page: page,
-			}).Inline()}},
-			eventMarkup.InlineKeyboard...,
-		)
-	}
-
+			[][]tele.InlineButton{{
+				*h.layout.Button(c, "clubOwner:event:qr", struct {
+					ID   string
+					Page string
+				}{
+					ID:   eventID,
+					Page: page,
+				}).Inline(),
+				*h.layout.Button(c, "clubOwner:event:exit_qr", struct {
+					ID   string
+					Page string
+				}{
+					ID:   eventID,
+					Page: page,
+				}).Inline(),
+			}},
+			eventMarkup.InlineKeyboard...,
+		)
+	}
+
+	feedback, err := h.feedbackService.GetEventSummary(context.Background(), event.ID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event feedback summary: %v", c.Sender().ID, err)
+	}
+
 	endTime := event.EndTime.In(location.Location()).Format("02.01.2006 15:04")
 	if event.EndTime.Year() == 1 {
 		endTime = ""
 	}
 
+	var publishAt string
+	if event.PublishAt != nil {
+		publishAt = event.PublishAt.In(location.Location()).Format("02.01.2006 15:04")
+	}
+
 	return c.Edit(
 		banner.Events.Caption(h.layout.Text(c, "club_owner_event_text", struct {
 			Name                  string
@@ -2083,6 +2706,13 @@ func (h Handler) event(c tele.Context) error {
 			AfterRegistrationText string
 			IsRegistered          bool
 			Link                  string
+			IsCancelled           bool
+			CancelReason          string
+			IsDraft               bool
+			PublishAt             string
+			Format                string
+			MeetingURL            string
+			Feedback              dto.FeedbackSummary
 		}{
 			Name:                  event.Name,
 			Description:           event.Description,
@@ -2095,6 +2725,13 @@ func (h Handler) event(c tele.Context) error {
 			VisitedCount:          visitedUsersCount,
 			AfterRegistrationText: event.AfterRegistrationText,
 			Link:                  event.Link(c.Bot().Me.Username),
+			IsCancelled:           event.IsCancelled(),
+			CancelReason:          event.CancelReason,
+			IsDraft:               event.IsDraft(),
+			PublishAt:             publishAt,
+			Format:                event.Format.String(),
+			MeetingURL:            event.MeetingURL,
+			Feedback:              feedback,
 		})),
 		eventMarkup,
 	)
@@ -2155,11 +2792,21 @@ func (h Handler) eventSettings(c tele.Context) error {
 		)
 	}
 
+	feedback, err := h.feedbackService.GetEventSummary(context.Background(), event.ID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event feedback summary: %v", c.Sender().ID, err)
+	}
+
 	endTime := event.EndTime.In(location.Location()).Format("02.01.2006 15:04")
 	if event.EndTime.Year() == 1 {
 		endTime = ""
 	}
 
+	var publishAt string
+	if event.PublishAt != nil {
+		publishAt = event.PublishAt.In(location.Location()).Format("02.01.2006 15:04")
+	}
+
 	return c.Edit(
 		banner.ClubOwner.Caption(h.layout.Text(c, "club_owner_event_text", struct {
 			Name                  string
@@ -2174,6 +2821,13 @@ func (h Handler) eventSettings(c tele.Context) error {
 			AfterRegistrationText string
 			IsRegistered          bool
 			Link                  string
+			IsCancelled           bool
+			CancelReason          string
+			IsDraft               bool
+			PublishAt             string
+			Format                string
+			MeetingURL            string
+			Feedback              dto.FeedbackSummary
 		}{
 			Name:                  event.Name,
 			Description:           event.Description,
@@ -2186,13 +2840,24 @@ func (h Handler) eventSettings(c tele.Context) error {
 			VisitedCount:          visitedUsersCount,
 			AfterRegistrationText: event.AfterRegistrationText,
 			Link:                  event.Link(c.Bot().Me.Username),
+			IsCancelled:           event.IsCancelled(),
+			CancelReason:          event.CancelReason,
+			IsDraft:               event.IsDraft(),
+			PublishAt:             publishAt,
+			Format:                event.Format.String(),
+			MeetingURL:            event.MeetingURL,
+			Feedback:              feedback,
 		})),
 		h.layout.Markup(c, "clubOwner:event:settings", struct {
-			ID   string
-			Page string
+			ID               string
+			Page             string
+			ApprovalRequired bool
+			FormatKey        string
 		}{
-			ID:   eventID,
-			Page: page,
+			ID:               eventID,
+			Page:             page,
+			ApprovalRequired: event.ApprovalRequired,
+			FormatKey:        "event_format_" + event.Format.String(),
 		}))
 }
 
@@ -2239,8 +2904,6 @@ func (h Handler) editEventName(c tele.Context) error {
 		done      bool
 	)
 
-	oldName := event.Name
-
 	for {
 		response, errGet := h.input.Get(context.Background(), c.Sender().ID, 0)
 		if response.Message != nil {
@@ -2294,10 +2957,9 @@ func (h Handler) editEventName(c tele.Context) error {
 		}
 	}
 
-	event.Name = eventName
-	_, err = h.eventService.Update(context.Background(), event)
+	events, err := h.eventsToEdit(c, inputCollector, event, page)
 	if err != nil {
-		h.logger.Errorf("(user: %d) error while update event name: %v", c.Sender().ID, err)
+		h.logger.Errorf("(user: %d) error while get events to edit: %v", c.Sender().ID, err)
 		return c.Send(
 			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
 			h.layout.Markup(c, "clubOwner:event:settings:back", struct {
@@ -2309,22 +2971,44 @@ func (h Handler) editEventName(c tele.Context) error {
 			}),
 		)
 	}
+	if len(events) == 0 {
+		return nil
+	}
 
-	err = h.notificationService.SendEventUpdate(eventID,
-		h.layout.Text(c, "event_notification_update", struct {
-			Name                  string
-			OldName               string
-			Description           string
-			AfterRegistrationText string
-			MaxParticipants       int
-		}{
-			Name:    event.Name,
-			OldName: oldName,
-		}),
-		h.layout.Markup(c, "core:hide"),
-	)
-	if err != nil {
-		h.logger.Errorf("(user: %d) error while send event update notification: %v", c.Sender().ID, err)
+	for _, e := range events {
+		oldName := e.Name
+		e.Name = eventName
+		_, err = h.eventService.Update(context.Background(), &e)
+		if err != nil {
+			h.logger.Errorf("(user: %d) error while update event name: %v", c.Sender().ID, err)
+			return c.Send(
+				banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+				h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+					ID   string
+					Page string
+				}{
+					ID:   eventID,
+					Page: page,
+				}),
+			)
+		}
+
+		err = h.notificationService.SendEventUpdate(e.ID,
+			h.layout.Text(c, "event_notification_update", struct {
+				Name                  string
+				OldName               string
+				Description           string
+				AfterRegistrationText string
+				MaxParticipants       int
+			}{
+				Name:    e.Name,
+				OldName: oldName,
+			}),
+			h.layout.Markup(c, "core:hide"),
+		)
+		if err != nil {
+			h.logger.Errorf("(user: %d) error while send event update notification: %v", c.Sender().ID, err)
+		}
 	}
 
 	return c.Send(
@@ -2437,10 +3121,9 @@ func (h Handler) editEventDescription(c tele.Context) error {
 		}
 	}
 
-	event.Description = eventDescription
-	_, err = h.eventService.Update(context.Background(), event)
+	events, err := h.eventsToEdit(c, inputCollector, event, page)
 	if err != nil {
-		h.logger.Errorf("(user: %d) error while update event description: %v", c.Sender().ID, err)
+		h.logger.Errorf("(user: %d) error while get events to edit: %v", c.Sender().ID, err)
 		return c.Send(
 			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
 			h.layout.Markup(c, "clubOwner:event:settings:back", struct {
@@ -2452,22 +3135,43 @@ func (h Handler) editEventDescription(c tele.Context) error {
 			}),
 		)
 	}
+	if len(events) == 0 {
+		return nil
+	}
 
-	err = h.notificationService.SendEventUpdate(eventID,
-		h.layout.Text(c, "event_notification_update", struct {
-			Name                  string
-			OldName               string
-			Description           string
-			AfterRegistrationText string
-			MaxParticipants       int
-		}{
-			Name:        event.Name,
-			Description: event.Description,
-		}),
-		h.layout.Markup(c, "core:hide"),
-	)
-	if err != nil {
-		h.logger.Errorf("(user: %d) error while send event update notification: %v", c.Sender().ID, err)
+	for _, e := range events {
+		e.Description = eventDescription
+		_, err = h.eventService.Update(context.Background(), &e)
+		if err != nil {
+			h.logger.Errorf("(user: %d) error while update event description: %v", c.Sender().ID, err)
+			return c.Send(
+				banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+				h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+					ID   string
+					Page string
+				}{
+					ID:   eventID,
+					Page: page,
+				}),
+			)
+		}
+
+		err = h.notificationService.SendEventUpdate(e.ID,
+			h.layout.Text(c, "event_notification_update", struct {
+				Name                  string
+				OldName               string
+				Description           string
+				AfterRegistrationText string
+				MaxParticipants       int
+			}{
+				Name:        e.Name,
+				Description: e.Description,
+			}),
+			h.layout.Markup(c, "core:hide"),
+		)
+		if err != nil {
+			h.logger.Errorf("(user: %d) error while send event update notification: %v", c.Sender().ID, err)
+		}
 	}
 
 	return c.Send(
@@ -2580,10 +3284,9 @@ func (h Handler) editEventAfterRegistrationText(c tele.Context) error {
 		}
 	}
 
-	event.AfterRegistrationText = eventAfterRegistrationText
-	_, err = h.eventService.Update(context.Background(), event)
+	events, err := h.eventsToEdit(c, inputCollector, event, page)
 	if err != nil {
-		h.logger.Errorf("(user: %d) error while update event after registration text: %v", c.Sender().ID, err)
+		h.logger.Errorf("(user: %d) error while get events to edit: %v", c.Sender().ID, err)
 		return c.Send(
 			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
 			h.layout.Markup(c, "clubOwner:event:settings:back", struct {
@@ -2595,22 +3298,43 @@ func (h Handler) editEventAfterRegistrationText(c tele.Context) error {
 			}),
 		)
 	}
+	if len(events) == 0 {
+		return nil
+	}
 
-	err = h.notificationService.SendEventUpdate(eventID,
-		h.layout.Text(c, "event_notification_update", struct {
-			Name                  string
-			OldName               string
-			Description           string
-			AfterRegistrationText string
-			MaxParticipants       int
-		}{
-			Name:                  event.Name,
-			AfterRegistrationText: event.AfterRegistrationText,
-		}),
-		h.layout.Markup(c, "core:hide"),
-	)
-	if err != nil {
-		h.logger.Errorf("(user: %d) error while send event update notification: %v", c.Sender().ID, err)
+	for _, e := range events {
+		e.AfterRegistrationText = eventAfterRegistrationText
+		_, err = h.eventService.Update(context.Background(), &e)
+		if err != nil {
+			h.logger.Errorf("(user: %d) error while update event after registration text: %v", c.Sender().ID, err)
+			return c.Send(
+				banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+				h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+					ID   string
+					Page string
+				}{
+					ID:   eventID,
+					Page: page,
+				}),
+			)
+		}
+
+		err = h.notificationService.SendEventUpdate(e.ID,
+			h.layout.Text(c, "event_notification_update", struct {
+				Name                  string
+				OldName               string
+				Description           string
+				AfterRegistrationText string
+				MaxParticipants       int
+			}{
+				Name:                  e.Name,
+				AfterRegistrationText: e.AfterRegistrationText,
+			}),
+			h.layout.Markup(c, "core:hide"),
+		)
+		if err != nil {
+			h.logger.Errorf("(user: %d) error while send event update notification: %v", c.Sender().ID, err)
+		}
 	}
 
 	return c.Send(
@@ -2696,97 +3420,791 @@ func (h Handler) editEventMaxParticipants(c tele.Context) error {
 		switch {
 		case response.Canceled:
 			_ = inputCollector.Clear(c, collector.ClearOptions{IgnoreErrors: true, ExcludeLast: true})
-			return nil
+			return nil
+		case errGet != nil:
+			h.logger.Errorf("(user: %d) error while input event after registration text: %v", c.Sender().ID, errGet)
+			_ = inputCollector.Send(c,
+				banner.ClubOwner.Caption(h.layout.Text(c, "input_error", h.layout.Text(c, "input_edit_max_participants"))),
+				h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+					ID   string
+					Page string
+				}{
+					ID:   eventID,
+					Page: page,
+				}),
+			)
+		case response.Message == nil:
+			h.logger.Errorf("(user: %d) error while input event after registration text: %v", c.Sender().ID, errGet)
+			_ = inputCollector.Send(c,
+				banner.ClubOwner.Caption(h.layout.Text(c, "input_error", h.layout.Text(c, "input_edit_max_participants"))),
+				h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+					ID   string
+					Page string
+				}{
+					ID:   eventID,
+					Page: page,
+				}),
+			)
+		case !validator.EventEditMaxParticipants(response.Message.Text, params):
+			_ = inputCollector.Send(c,
+				banner.ClubOwner.Caption(h.layout.Text(c, "invalid_edit_max_participants")),
+				h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+					ID   string
+					Page string
+				}{
+					ID:   eventID,
+					Page: page,
+				}),
+			)
+		case validator.EventEditMaxParticipants(response.Message.Text, params):
+			maxParticipants, _ = strconv.Atoi(response.Message.Text)
+			_ = inputCollector.Clear(c, collector.ClearOptions{IgnoreErrors: true})
+			done = true
+		}
+		if done {
+			break
+		}
+	}
+
+	events, err := h.eventsToEdit(c, inputCollector, event, page)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get events to edit: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+				ID   string
+				Page string
+			}{
+				ID:   eventID,
+				Page: page,
+			}),
+		)
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	for _, e := range events {
+		// Лимит участников можно только увеличить, поэтому события серии с большим лимитом не трогаем
+		if e.ID != event.ID && maxParticipants != 0 && (e.MaxParticipants == 0 || e.MaxParticipants >= maxParticipants) {
+			continue
+		}
+
+		e.MaxParticipants = maxParticipants
+		_, err = h.eventService.Update(context.Background(), &e)
+		if err != nil {
+			h.logger.Errorf("(user: %d) error while update event max participants: %v", c.Sender().ID, err)
+			return c.Send(
+				banner.ClubOwner.Caption(h.eventSaveErrorText(c, err)),
+				h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+					ID   string
+					Page string
+				}{
+					ID:   eventID,
+					Page: page,
+				}),
+			)
+		}
+
+		err = h.notificationService.SendEventUpdate(e.ID,
+			h.layout.Text(c, "event_notification_update", struct {
+				Name                  string
+				OldName               string
+				Description           string
+				AfterRegistrationText string
+				MaxParticipants       int
+				ParticipantsChanged   bool
+			}{
+				Name:                e.Name,
+				MaxParticipants:     e.MaxParticipants,
+				ParticipantsChanged: true,
+			}),
+			h.layout.Markup(c, "core:hide"),
+		)
+		if err != nil {
+			h.logger.Errorf("(user: %d) error while send event update notification: %v", c.Sender().ID, err)
+		}
+	}
+
+	return c.Send(
+		banner.ClubOwner.Caption(h.layout.Text(c, "event_max_participants_changed")),
+		h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+			ID   string
+			Page string
+		}{
+			ID:   eventID,
+			Page: page,
+		}),
+	)
+}
+
+func (h Handler) editEventStartTime(c tele.Context) error {
+	data := strings.Split(c.Callback().Data, " ")
+	if len(data) != 2 {
+		return errorz.ErrInvalidCallbackData
+	}
+
+	eventID := data[0]
+	page := data[1]
+	h.logger.Infof("(user: %d) edit event start time", c.Sender().ID)
+
+	event, err := h.eventService.Get(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+				ID   string
+				Page string
+			}{
+				ID:   eventID,
+				Page: page,
+			}),
+		)
+	}
+
+	inputCollector := collector.New()
+	startTimeStr, ok := h.inputEventValue(c, inputCollector, eventID, page, "clubOwner:event:settings:back",
+		"input_edit_event_start_time",
+		struct{}{},
+		"invalid_event_start_time",
+		validator.EventStartTime,
+		nil,
+	)
+	if !ok {
+		return nil
+	}
+	startTime, _ := time.ParseInLocation("02.01.2006 15:04", startTimeStr, location.Location())
+
+	events, all, err := h.eventsToEditScope(c, inputCollector, event, page)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get events to edit: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+				ID   string
+				Page string
+			}{
+				ID:   eventID,
+				Page: page,
+			}),
+		)
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	// Мероприятие переносится целиком: окончание и завершение регистрации сдвигаются вместе с началом
+	shift := startTime.Sub(event.StartTime)
+	for _, e := range events {
+		previous := e
+		e.StartTime = e.StartTime.Add(shift).UTC()
+		if e.EndTime.Year() != 1 {
+			e.EndTime = e.EndTime.Add(shift).UTC()
+		}
+		e.RegistrationEnd = e.RegistrationEnd.Add(shift).UTC()
+
+		updatedEvent, err := h.eventService.Update(context.Background(), &e)
+		if err != nil {
+			h.logger.Errorf("(user: %d) error while update event start time: %v", c.Sender().ID, err)
+			return c.Send(
+				banner.ClubOwner.Caption(h.eventSaveErrorText(c, err)),
+				h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+					ID   string
+					Page string
+				}{
+					ID:   eventID,
+					Page: page,
+				}),
+			)
+		}
+
+		if err = h.sendEventScheduleUpdate(c, *updatedEvent, previous, "event_field_start_time"); err != nil {
+			h.logger.Errorf("(user: %d) error while send event update notification: %v", c.Sender().ID, err)
+		}
+	}
+
+	// Серия переносится вместе с событиями, иначе в календаре останется старое правило повторения
+	if all {
+		eventIDs := make([]string, 0, len(events))
+		for _, e := range events {
+			eventIDs = append(eventIDs, e.ID)
+		}
+		_, err = h.eventService.RescheduleSeries(context.Background(), *event.SeriesID, event.StartTime, startTime, eventIDs)
+		if err != nil {
+			h.logger.Errorf("(user: %d) error while reschedule event series: %v", c.Sender().ID, err)
+			return c.Send(
+				banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+				h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+					ID   string
+					Page string
+				}{
+					ID:   eventID,
+					Page: page,
+				}),
+			)
+		}
+	}
+
+	return c.Send(
+		banner.ClubOwner.Caption(h.layout.Text(c, "event_start_time_changed")),
+		h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+			ID   string
+			Page string
+		}{
+			ID:   eventID,
+			Page: page,
+		}),
+	)
+}
+
+func (h Handler) editEventEndTime(c tele.Context) error {
+	data := strings.Split(c.Callback().Data, " ")
+	if len(data) != 2 {
+		return errorz.ErrInvalidCallbackData
+	}
+
+	eventID := data[0]
+	page := data[1]
+	h.logger.Infof("(user: %d) edit event end time", c.Sender().ID)
+
+	event, err := h.eventService.Get(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+				ID   string
+				Page string
+			}{
+				ID:   eventID,
+				Page: page,
+			}),
+		)
+	}
+
+	inputCollector := collector.New()
+	endTimeStr, ok := h.inputEventValue(c, inputCollector, eventID, page, "clubOwner:event:settings:back",
+		"input_event_end_time",
+		struct{}{},
+		"invalid_event_end_time",
+		validator.EventEndTime,
+		map[string]interface{}{
+			"startTime": event.StartTime.In(location.Location()).Format("02.01.2006 15:04"),
+		},
+	)
+	if !ok {
+		return nil
+	}
+	endTime, _ := time.ParseInLocation("02.01.2006 15:04", endTimeStr, location.Location())
+
+	events, err := h.eventsToEdit(c, inputCollector, event, page)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get events to edit: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+				ID   string
+				Page string
+			}{
+				ID:   eventID,
+				Page: page,
+			}),
+		)
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	duration := endTime.Sub(event.StartTime)
+	for _, e := range events {
+		previous := e
+		e.EndTime = e.StartTime.Add(duration).UTC()
+
+		updatedEvent, err := h.eventService.Update(context.Background(), &e)
+		if err != nil {
+			h.logger.Errorf("(user: %d) error while update event end time: %v", c.Sender().ID, err)
+			return c.Send(
+				banner.ClubOwner.Caption(h.eventSaveErrorText(c, err)),
+				h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+					ID   string
+					Page string
+				}{
+					ID:   eventID,
+					Page: page,
+				}),
+			)
+		}
+
+		if err = h.sendEventScheduleUpdate(c, *updatedEvent, previous, "event_field_end_time"); err != nil {
+			h.logger.Errorf("(user: %d) error while send event update notification: %v", c.Sender().ID, err)
+		}
+	}
+
+	return c.Send(
+		banner.ClubOwner.Caption(h.layout.Text(c, "event_end_time_changed")),
+		h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+			ID   string
+			Page string
+		}{
+			ID:   eventID,
+			Page: page,
+		}),
+	)
+}
+
+func (h Handler) editEventLocation(c tele.Context) error {
+	data := strings.Split(c.Callback().Data, " ")
+	if len(data) != 2 {
+		return errorz.ErrInvalidCallbackData
+	}
+
+	eventID := data[0]
+	page := data[1]
+	h.logger.Infof("(user: %d) edit event location", c.Sender().ID)
+
+	event, err := h.eventService.Get(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+				ID   string
+				Page string
+			}{
+				ID:   eventID,
+				Page: page,
+			}),
+		)
+	}
+
+	inputCollector := collector.New()
+	eventLocation, venue, ok := h.inputEventLocation(c, inputCollector, eventID, page)
+	if !ok {
+		return nil
+	}
+
+	events, err := h.eventsToEdit(c, inputCollector, event, page)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get events to edit: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+				ID   string
+				Page string
+			}{
+				ID:   eventID,
+				Page: page,
+			}),
+		)
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	for _, e := range events {
+		previous := e
+		passRequired := e.PassRequired
+		if venue != nil {
+			e.SetVenue(venue)
+		} else {
+			e.Location = eventLocation
+			e.PassRequired = h.isPassRequired(eventLocation)
+			e.VenueID = nil
+		}
+
+		updatedEvent, err := h.eventService.Update(context.Background(), &e)
+		if err != nil {
+			h.logger.Errorf("(user: %d) error while update event location: %v", c.Sender().ID, err)
+			return c.Send(
+				banner.ClubOwner.Caption(h.eventSaveErrorText(c, err)),
+				h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+					ID   string
+					Page string
+				}{
+					ID:   eventID,
+					Page: page,
+				}),
+			)
+		}
+
+		if passRequired != e.PassRequired {
+			if err = h.eventParticipantService.SyncPasses(context.Background(), e.ID); err != nil {
+				h.logger.Errorf("(user: %d) error while sync event passes: %v", c.Sender().ID, err)
+			}
+		}
+
+		if err = h.sendEventScheduleUpdate(c, *updatedEvent, previous, "event_field_location"); err != nil {
+			h.logger.Errorf("(user: %d) error while send event update notification: %v", c.Sender().ID, err)
+		}
+	}
+
+	return c.Send(
+		banner.ClubOwner.Caption(h.layout.Text(c, "event_location_changed")),
+		h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+			ID   string
+			Page string
+		}{
+			ID:   eventID,
+			Page: page,
+		}),
+	)
+}
+
+func (h Handler) editEventMaxGuests(c tele.Context) error {
+	data := strings.Split(c.Callback().Data, " ")
+	if len(data) != 2 {
+		return errorz.ErrInvalidCallbackData
+	}
+
+	eventID := data[0]
+	page := data[1]
+	h.logger.Infof("(user: %d) edit event max guests", c.Sender().ID)
+
+	event, err := h.eventService.Get(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+				ID   string
+				Page string
+			}{
+				ID:   eventID,
+				Page: page,
+			}),
+		)
+	}
+
+	inputCollector := collector.New()
+	maxGuestsStr, ok := h.inputEventValue(c, inputCollector, eventID, page, "clubOwner:event:settings:back",
+		"input_event_max_guests",
+		struct {
+			MaxGuests int
+			Limit     int
+		}{
+			MaxGuests: event.MaxGuests,
+			Limit:     entity.MaxEventGuests,
+		},
+		"invalid_event_max_guests",
+		validator.EventMaxGuests,
+		map[string]interface{}{"maxGuests": entity.MaxEventGuests},
+	)
+	if !ok {
+		return nil
+	}
+	maxGuests, _ := strconv.Atoi(maxGuestsStr)
+
+	events, err := h.eventsToEdit(c, inputCollector, event, page)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get events to edit: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+				ID   string
+				Page string
+			}{
+				ID:   eventID,
+				Page: page,
+			}),
+		)
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	// Уже добавленные гости остаются, новый лимит действует только на новых
+	for _, e := range events {
+		e.MaxGuests = maxGuests
+		if _, err := h.eventService.Update(context.Background(), &e); err != nil {
+			h.logger.Errorf("(user: %d) error while update event max guests: %v", c.Sender().ID, err)
+			return c.Send(
+				banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+				h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+					ID   string
+					Page string
+				}{
+					ID:   eventID,
+					Page: page,
+				}),
+			)
+		}
+	}
+
+	return c.Send(
+		banner.ClubOwner.Caption(h.layout.Text(c, "event_max_guests_changed")),
+		h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+			ID   string
+			Page string
+		}{
+			ID:   eventID,
+			Page: page,
+		}),
+	)
+}
+
+func (h Handler) editEventRegistrationEnd(c tele.Context) error {
+	data := strings.Split(c.Callback().Data, " ")
+	if len(data) != 2 {
+		return errorz.ErrInvalidCallbackData
+	}
+
+	eventID := data[0]
+	page := data[1]
+	h.logger.Infof("(user: %d) edit event registration end", c.Sender().ID)
+
+	event, err := h.eventService.Get(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+				ID   string
+				Page string
+			}{
+				ID:   eventID,
+				Page: page,
+			}),
+		)
+	}
+
+	startTimeStr := event.StartTime.In(location.Location()).Format("02.01.2006 15:04")
+
+	inputCollector := collector.New()
+	registrationEndStr, ok := h.inputEventValue(c, inputCollector, eventID, page, "clubOwner:event:settings:back",
+		"input_event_registered_end_time",
+		struct {
+			MaxRegisteredEndTime string
+		}{
+			MaxRegisteredEndTime: startTimeStr,
+		},
+		"invalid_event_registered_end_time",
+		validator.EventRegisteredEndTime,
+		map[string]interface{}{
+			"startTime": startTimeStr,
+		},
+	)
+	if !ok {
+		return nil
+	}
+	registrationEnd, _ := time.ParseInLocation("02.01.2006 15:04", registrationEndStr, location.Location())
+
+	events, err := h.eventsToEdit(c, inputCollector, event, page)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get events to edit: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+				ID   string
+				Page string
+			}{
+				ID:   eventID,
+				Page: page,
+			}),
+		)
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	beforeStart := event.StartTime.Sub(registrationEnd)
+	for _, e := range events {
+		previous := e
+		e.RegistrationEnd = e.StartTime.Add(-beforeStart).UTC()
+
+		updatedEvent, err := h.eventService.Update(context.Background(), &e)
+		if err != nil {
+			h.logger.Errorf("(user: %d) error while update event registration end: %v", c.Sender().ID, err)
+			return c.Send(
+				banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+				h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+					ID   string
+					Page string
+				}{
+					ID:   eventID,
+					Page: page,
+				}),
+			)
+		}
+
+		if err = h.sendEventScheduleUpdate(c, *updatedEvent, previous, "event_field_registration_end"); err != nil {
+			h.logger.Errorf("(user: %d) error while send event update notification: %v", c.Sender().ID, err)
+		}
+	}
+
+	return c.Send(
+		banner.ClubOwner.Caption(h.layout.Text(c, "event_registration_end_changed")),
+		h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+			ID   string
+			Page string
+		}{
+			ID:   eventID,
+			Page: page,
+		}),
+	)
+}
+
+// inputEventValue asks the owner for a new value of the event field until a valid value is sent
+//
+// Returns false if the input was canceled
+func (h Handler) inputEventValue(
+	c tele.Context,
+	inputCollector *collector.MessageCollector,
+	eventID, page string,
+	backMarkupKey string,
+	promptKey string,
+	prompt interface{},
+	errorKey string,
+	validate func(string, map[string]interface{}) bool,
+	params map[string]interface{},
+) (string, bool) {
+	markup := h.layout.Markup(c, backMarkupKey, struct {
+		ID   string
+		Page string
+	}{
+		ID:   eventID,
+		Page: page,
+	})
+
+	_ = c.Edit(
+		banner.ClubOwner.Caption(h.layout.Text(c, promptKey, prompt)),
+		markup,
+	)
+	inputCollector.Collect(c.Message())
+
+	for {
+		response, errGet := h.input.Get(context.Background(), c.Sender().ID, 0)
+		if response.Message != nil {
+			inputCollector.Collect(response.Message)
+		}
+		switch {
+		case response.Canceled:
+			_ = inputCollector.Clear(c, collector.ClearOptions{IgnoreErrors: true, ExcludeLast: true})
+			return "", false
 		case errGet != nil:
-			h.logger.Errorf("(user: %d) error while input event after registration text: %v", c.Sender().ID, errGet)
+			h.logger.Errorf("(user: %d) error while input (%s): %v", c.Sender().ID, promptKey, errGet)
 			_ = inputCollector.Send(c,
-				banner.ClubOwner.Caption(h.layout.Text(c, "input_error", h.layout.Text(c, "input_edit_max_participants"))),
-				h.layout.Markup(c, "clubOwner:event:settings:back", struct {
-					ID   string
-					Page string
-				}{
-					ID:   eventID,
-					Page: page,
-				}),
+				banner.ClubOwner.Caption(h.layout.Text(c, "input_error", h.layout.Text(c, promptKey, prompt))),
+				markup,
 			)
 		case response.Message == nil:
-			h.logger.Errorf("(user: %d) error while input event after registration text: %v", c.Sender().ID, errGet)
 			_ = inputCollector.Send(c,
-				banner.ClubOwner.Caption(h.layout.Text(c, "input_error", h.layout.Text(c, "input_edit_max_participants"))),
-				h.layout.Markup(c, "clubOwner:event:settings:back", struct {
-					ID   string
-					Page string
-				}{
-					ID:   eventID,
-					Page: page,
-				}),
+				banner.ClubOwner.Caption(h.layout.Text(c, "input_error", h.layout.Text(c, promptKey, prompt))),
+				markup,
 			)
-		case !validator.EventEditMaxParticipants(response.Message.Text, params):
+		case !validate(response.Message.Text, params):
 			_ = inputCollector.Send(c,
-				banner.ClubOwner.Caption(h.layout.Text(c, "invalid_edit_max_participants")),
-				h.layout.Markup(c, "clubOwner:event:settings:back", struct {
-					ID   string
-					Page string
-				}{
-					ID:   eventID,
-					Page: page,
-				}),
+				banner.ClubOwner.Caption(h.layout.Text(c, errorKey, prompt)),
+				markup,
 			)
-		case validator.EventEditMaxParticipants(response.Message.Text, params):
-			maxParticipants, _ = strconv.Atoi(response.Message.Text)
+		default:
 			_ = inputCollector.Clear(c, collector.ClearOptions{IgnoreErrors: true})
-			done = true
-		}
-		if done {
-			break
+			return response.Message.Text, true
 		}
 	}
+}
 
-	event.MaxParticipants = maxParticipants
-	_, err = h.eventService.Update(context.Background(), event)
+// sendEventScheduleUpdate notifies participants that the time or the location of the event has changed
+// and attaches the updated .ics file, so the event can be updated in their calendars.
+// previous is the event before the change, it identifies the occurrence of a recurring event in the calendars
+func (h Handler) sendEventScheduleUpdate(c tele.Context, event entity.Event, previous entity.Event, fieldKey string) error {
+	ics, err := calendar.ExportEventUpdateToICS(event, previous)
 	if err != nil {
-		h.logger.Errorf("(user: %d) error while update event max participants: %v", c.Sender().ID, err)
-		return c.Send(
-			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
-			h.layout.Markup(c, "clubOwner:event:settings:back", struct {
-				ID   string
-				Page string
-			}{
-				ID:   eventID,
-				Page: page,
-			}),
-		)
+		return err
 	}
 
-	err = h.notificationService.SendEventUpdate(eventID,
-		h.layout.Text(c, "event_notification_update", struct {
-			Name                  string
-			OldName               string
-			Description           string
-			AfterRegistrationText string
-			MaxParticipants       int
-			ParticipantsChanged   bool
-		}{
-			Name:                event.Name,
-			MaxParticipants:     event.MaxParticipants,
-			ParticipantsChanged: true,
-		}),
-		h.layout.Markup(c, "core:hide"),
-	)
-	if err != nil {
-		h.logger.Errorf("(user: %d) error while send event update notification: %v", c.Sender().ID, err)
+	endTime := event.EndTime.In(location.Location()).Format("02.01.2006 15:04")
+	if event.EndTime.Year() == 1 {
+		endTime = ""
 	}
 
-	return c.Send(
-		banner.ClubOwner.Caption(h.layout.Text(c, "event_max_participants_changed")),
-		h.layout.Markup(c, "clubOwner:event:settings:back", struct {
-			ID   string
-			Page string
+	doc := &tele.Document{
+		File: tele.FromReader(bytes.NewReader(ics)),
+		Caption: h.layout.Text(c, "event_notification_reschedule", struct {
+			Name            string
+			Field           string
+			Location        string
+			StartTime       string
+			EndTime         string
+			RegistrationEnd string
 		}{
-			ID:   eventID,
-			Page: page,
+			Name:            event.Name,
+			Field:           h.layout.Text(c, fieldKey),
+			Location:        event.Location,
+			StartTime:       event.StartTime.In(location.Location()).Format("02.01.2006 15:04"),
+			EndTime:         endTime,
+			RegistrationEnd: event.RegistrationEnd.In(location.Location()).Format("02.01.2006 15:04"),
 		}),
+		FileName: event.Name + ".ics",
+	}
+
+	return h.notificationService.SendEventUpdate(event.ID, doc, h.layout.Markup(c, "core:hide"))
+}
+
+// eventsToEdit returns the events the change should be applied to
+//
+// For an occurrence of a recurring event the owner chooses whether to change only this event
+// or this and all future events of the series. Empty result means that the input was canceled
+func (h Handler) eventsToEdit(
+	c tele.Context,
+	inputCollector *collector.MessageCollector,
+	event *entity.Event,
+	page string,
+) ([]entity.Event, error) {
+	events, _, err := h.eventsToEditScope(c, inputCollector, event, page)
+	return events, err
+}
+
+// eventsToEditScope is eventsToEdit that also reports if all future events of the series were chosen
+func (h Handler) eventsToEditScope(
+	c tele.Context,
+	inputCollector *collector.MessageCollector,
+	event *entity.Event,
+	page string,
+) ([]entity.Event, bool, error) {
+	if !event.IsRecurring() {
+		return []entity.Event{*event}, false, nil
+	}
+
+	markup := h.layout.Markup(c, "clubOwner:event:edit_scope", struct {
+		ID   string
+		Page string
+	}{
+		ID:   event.ID,
+		Page: page,
+	})
+	_ = inputCollector.Send(c,
+		banner.ClubOwner.Caption(h.layout.Text(c, "event_edit_scope")),
+		markup,
 	)
+
+	oneBtn := h.layout.Button(c, "clubOwner:event:edit_scope:one")
+	allBtn := h.layout.Button(c, "clubOwner:event:edit_scope:all")
+	for {
+		response, errGet := h.input.Get(context.Background(), c.Sender().ID, 0, oneBtn, allBtn)
+		if response.Message != nil {
+			inputCollector.Collect(response.Message)
+		}
+		switch {
+		case response.Canceled:
+			_ = inputCollector.Clear(c, collector.ClearOptions{IgnoreErrors: true, ExcludeLast: true})
+			return nil, false, nil
+		case errGet != nil:
+			return nil, false, errGet
+		case response.Callback == nil:
+			_ = inputCollector.Send(c,
+				banner.ClubOwner.Caption(h.layout.Text(c, "event_edit_scope")),
+				markup,
+			)
+		case response.Callback.Unique == allBtn.Unique:
+			_ = inputCollector.Clear(c, collector.ClearOptions{IgnoreErrors: true})
+			events, err := h.eventService.GetFutureBySeriesID(context.Background(), *event.SeriesID, event.StartTime)
+			return events, true, err
+		default:
+			_ = inputCollector.Clear(c, collector.ClearOptions{IgnoreErrors: true})
+			return []entity.Event{*event}, false, nil
+		}
+	}
 }
 
 func (h Handler) eventMailing(c tele.Context) error {
@@ -2820,7 +4238,16 @@ func (h Handler) mailingRegistered(c tele.Context) error {
 		)
 	}
 
-	club, err := h.clubService.Get(context.Background(), event.ClubID)
+	clubID, _, err := h.eventClubID(context.Background(), c.Sender().ID, event)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event club: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "mainMenu:back"),
+		)
+	}
+
+	club, err := h.clubService.Get(context.Background(), clubID)
 	if err != nil {
 		h.logger.Errorf("(user: %d) error while get club: %v", c.Sender().ID, err)
 		return c.Send(
@@ -3058,7 +4485,16 @@ func (h Handler) mailingVisited(c tele.Context) error {
 		)
 	}
 
-	club, err := h.clubService.Get(context.Background(), event.ClubID)
+	clubID, _, err := h.eventClubID(context.Background(), c.Sender().ID, event)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event club: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "mainMenu:back"),
+		)
+	}
+
+	club, err := h.clubService.Get(context.Background(), clubID)
 	if err != nil {
 		h.logger.Errorf("(user: %d) error while get club: %v", c.Sender().ID, err)
 		return c.Send(
@@ -3282,7 +4718,7 @@ func (h Handler) mailingVisited(c tele.Context) error {
 	)
 }
 
-func (h Handler) deleteEvent(c tele.Context) error {
+func (h Handler) cancelEvent(c tele.Context) error {
 	data := strings.Split(c.Callback().Data, " ")
 	if len(data) != 2 {
 		return errorz.ErrInvalidCallbackData
@@ -3290,7 +4726,7 @@ func (h Handler) deleteEvent(c tele.Context) error {
 
 	eventID := data[0]
 	page := data[1]
-	h.logger.Infof("(user: %d) delete event(eventID=%s) request", c.Sender().ID, eventID)
+	h.logger.Infof("(user: %d) cancel event(eventID=%s) request", c.Sender().ID, eventID)
 
 	event, err := h.eventService.Get(context.Background(), eventID)
 	if err != nil {
@@ -3308,12 +4744,12 @@ func (h Handler) deleteEvent(c tele.Context) error {
 	}
 
 	return c.Edit(
-		banner.ClubOwner.Caption(h.layout.Text(c, "delete_event_text", struct {
+		banner.ClubOwner.Caption(h.layout.Text(c, "cancel_event_text", struct {
 			Name string
 		}{
 			Name: event.Name,
 		})),
-		h.layout.Markup(c, "clubOwner:event:delete", struct {
+		h.layout.Markup(c, "clubOwner:event:cancel", struct {
 			ID   string
 			Page string
 		}{
@@ -3323,7 +4759,7 @@ func (h Handler) deleteEvent(c tele.Context) error {
 	)
 }
 
-func (h Handler) acceptEventDelete(c tele.Context) error {
+func (h Handler) acceptEventCancel(c tele.Context) error {
 	data := strings.Split(c.Callback().Data, " ")
 	if len(data) != 2 {
 		return errorz.ErrInvalidCallbackData
@@ -3331,14 +4767,14 @@ func (h Handler) acceptEventDelete(c tele.Context) error {
 
 	eventID := data[0]
 	page := data[1]
-	h.logger.Infof("(user: %d) delete event(eventID=%s)", c.Sender().ID, eventID)
+	h.logger.Infof("(user: %d) cancel event(eventID=%s)", c.Sender().ID, eventID)
 
 	event, err := h.eventService.Get(context.Background(), eventID)
 	if err != nil {
-		h.logger.Errorf("(user: %d) error while delete event: %v", c.Sender().ID, err)
+		h.logger.Errorf("(user: %d) error while get event: %v", c.Sender().ID, err)
 		return c.Edit(
 			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
-			h.layout.Markup(c, "clubOwner:event:delete:back", struct {
+			h.layout.Markup(c, "clubOwner:event:back", struct {
 				ID   string
 				Page string
 			}{
@@ -3348,12 +4784,28 @@ func (h Handler) acceptEventDelete(c tele.Context) error {
 		)
 	}
 
-	err = h.eventService.Delete(context.Background(), eventID)
+	inputCollector := collector.New()
+	reason, ok := h.inputEventValue(c, inputCollector, eventID, page, "clubOwner:event:back",
+		"input_event_cancel_reason",
+		struct {
+			Name string
+		}{
+			Name: event.Name,
+		},
+		"invalid_event_cancel_reason",
+		validator.EventCancelReason,
+		nil,
+	)
+	if !ok {
+		return nil
+	}
+
+	event, err = h.eventService.Cancel(context.Background(), eventID, reason)
 	if err != nil {
-		h.logger.Errorf("(user: %d) error while delete event: %v", c.Sender().ID, err)
-		return c.Edit(
+		h.logger.Errorf("(user: %d) error while cancel event: %v", c.Sender().ID, err)
+		return c.Send(
 			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
-			h.layout.Markup(c, "clubOwner:event:delete:back", struct {
+			h.layout.Markup(c, "clubOwner:event:back", struct {
 				ID   string
 				Page string
 			}{
@@ -3363,35 +4815,52 @@ func (h Handler) acceptEventDelete(c tele.Context) error {
 		)
 	}
 
-	err = h.notificationService.SendEventUpdate(eventID,
-		h.layout.Text(c, "event_notification_delete", struct {
+	if err = h.sendEventCancellation(c, *event); err != nil {
+		h.logger.Errorf("(user: %d) error while send event cancel notification: %v", c.Sender().ID, err)
+	}
+
+	return c.Send(
+		banner.ClubOwner.Caption(h.layout.Text(c, "event_cancelled", struct {
 			Name string
 		}{
 			Name: event.Name,
+		})),
+		h.layout.Markup(c, "clubOwner:event:back", struct {
+			ID   string
+			Page string
+		}{
+			ID:   eventID,
+			Page: page,
 		}),
-		h.layout.Markup(c, "core:hide"),
 	)
+}
+
+// sendEventCancellation notifies participants that the event is cancelled
+// and attaches the .ics cancellation, so the event is removed from their calendars
+func (h Handler) sendEventCancellation(c tele.Context, event entity.Event) error {
+	ics, err := calendar.ExportEventCancellationToICS(event)
 	if err != nil {
-		h.logger.Errorf("(user: %d) error while send event delete notification: %v", c.Sender().ID, err)
+		return err
 	}
 
-	return c.Edit(
-		banner.ClubOwner.Caption(h.layout.Text(c, "event_deleted", struct {
-			Name string
-		}{
-			Name: event.Name,
-		})),
-		h.layout.Markup(c, "clubOwner:event:delete:back", struct {
-			ClubID string
-			Page   string
+	doc := &tele.Document{
+		File: tele.FromReader(bytes.NewReader(ics)),
+		Caption: h.layout.Text(c, "event_notification_cancel", struct {
+			Name      string
+			StartTime string
+			Reason    string
 		}{
-			ClubID: event.ClubID,
-			Page:   page,
+			Name:      event.Name,
+			StartTime: event.StartTime.In(location.Location()).Format("02.01.2006 15:04"),
+			Reason:    event.CancelReason,
 		}),
-	)
+		FileName: event.Name + ".ics",
+	}
+
+	return h.notificationService.SendEventUpdate(event.ID, doc, h.layout.Markup(c, "core:hide"))
 }
 
-func (h Handler) declineEventDelete(c tele.Context) error {
+func (h Handler) declineEventCancel(c tele.Context) error {
 	data := strings.Split(c.Callback().Data, " ")
 	if len(data) != 2 {
 		return errorz.ErrInvalidCallbackData
@@ -3399,13 +4868,13 @@ func (h Handler) declineEventDelete(c tele.Context) error {
 
 	eventID := data[0]
 	page := data[1]
-	h.logger.Infof("(user: %d) decline delete event(eventID=%s)", c.Sender().ID, eventID)
+	h.logger.Infof("(user: %d) decline cancel event(eventID=%s)", c.Sender().ID, eventID)
 
 	event, err := h.eventService.Get(context.Background(), eventID)
 	if err != nil {
 		return c.Edit(
 			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
-			h.layout.Markup(c, "clubOwner:event:delete:back", struct {
+			h.layout.Markup(c, "clubOwner:event:back", struct {
 				ID   string
 				Page string
 			}{
@@ -3419,7 +4888,7 @@ func (h Handler) declineEventDelete(c tele.Context) error {
 	if err != nil {
 		return c.Edit(
 			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
-			h.layout.Markup(c, "clubOwner:event:delete:back", struct {
+			h.layout.Markup(c, "clubOwner:event:back", struct {
 				ID   string
 				Page string
 			}{
@@ -3459,7 +4928,14 @@ func (h Handler) declineEventDelete(c tele.Context) error {
 		)
 	}
 
-	eventMarkup := h.layout.Markup(c, "clubOwner:event:menu", struct {
+	menuKey := "clubOwner:event:menu"
+	switch {
+	case event.IsCancelled():
+		menuKey = "clubOwner:event:menu:cancelled"
+	case event.IsDraft():
+		menuKey = "clubOwner:event:menu:draft"
+	}
+	eventMarkup := h.layout.Markup(c, menuKey, struct {
 		ID     string
 		ClubID string
 		Page   string
@@ -3469,24 +4945,43 @@ func (h Handler) declineEventDelete(c tele.Context) error {
 		Page:   page,
 	})
 
-	if club.QrAllowed {
+	if club.QrAllowed && !event.IsCancelled() && !event.IsDraft() {
 		eventMarkup.InlineKeyboard = append(
-			[][]tele.InlineButton{{*h.layout.Button(c, "clubOwner:event:qr", struct {
-				ID   string
-				Page string
-			}{
-				ID:   eventID,
-				Page: page,
-			}).Inline()}},
+			[][]tele.InlineButton{{
+				*h.layout.Button(c, "clubOwner:event:qr", struct {
+					ID   string
+					Page string
+				}{
+					ID:   eventID,
+					Page: page,
+				}).Inline(),
+				*h.layout.Button(c, "clubOwner:event:exit_qr", struct {
+					ID   string
+					Page string
+				}{
+					ID:   eventID,
+					Page: page,
+				}).Inline(),
+			}},
 			eventMarkup.InlineKeyboard...,
 		)
 	}
 
+	feedback, err := h.feedbackService.GetEventSummary(context.Background(), event.ID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event feedback summary: %v", c.Sender().ID, err)
+	}
+
 	endTime := event.EndTime.In(location.Location()).Format("02.01.2006 15:04")
 	if event.EndTime.Year() == 1 {
 		endTime = ""
 	}
 
+	var publishAt string
+	if event.PublishAt != nil {
+		publishAt = event.PublishAt.In(location.Location()).Format("02.01.2006 15:04")
+	}
+
 	return c.Edit(
 		banner.ClubOwner.Caption(h.layout.Text(c, "club_owner_event_text", struct {
 			Name                  string
@@ -3501,6 +4996,13 @@ func (h Handler) declineEventDelete(c tele.Context) error {
 			AfterRegistrationText string
 			IsRegistered          bool
 			Link                  string
+			IsCancelled           bool
+			CancelReason          string
+			IsDraft               bool
+			PublishAt             string
+			Format                string
+			MeetingURL            string
+			Feedback              dto.FeedbackSummary
 		}{
 			Name:                  event.Name,
 			Description:           event.Description,
@@ -3513,6 +5015,13 @@ func (h Handler) declineEventDelete(c tele.Context) error {
 			VisitedCount:          visitedUsersCount,
 			AfterRegistrationText: event.AfterRegistrationText,
 			Link:                  event.Link(c.Bot().Me.Username),
+			IsCancelled:           event.IsCancelled(),
+			CancelReason:          event.CancelReason,
+			IsDraft:               event.IsDraft(),
+			PublishAt:             publishAt,
+			Format:                event.Format.String(),
+			MeetingURL:            event.MeetingURL,
+			Feedback:              feedback,
 		})),
 		eventMarkup,
 	)
@@ -3582,7 +5091,52 @@ func (h Handler) registeredUsers(c tele.Context) error {
 		)
 	}
 
-	buffer, err := usersToXLSX(users)
+	waitlist, err := h.eventParticipantService.GetWaitlist(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event waitlist: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+
+	questions, err := h.eventParticipantService.GetQuestions(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event registration form: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+
+	answers, err := h.eventParticipantService.GetAnswersByEventID(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event registration answers: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+
+	guests, err := h.eventParticipantService.GetGuestsByEventID(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event guests: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+
+	roleSeats, err := h.eventParticipantService.GetSeatsByRole(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event seats by role: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+
+	buffer, err := export.UsersToXLSX(users, waitlist, guests, questions, answers)
 	if err != nil {
 		return c.Send(
 			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
@@ -3591,8 +5145,14 @@ func (h Handler) registeredUsers(c tele.Context) error {
 	}
 
 	file := &tele.Document{
-		File:     tele.FromReader(buffer),
-		Caption:  h.layout.Text(c, "registered_users_text"),
+		File: tele.FromReader(buffer),
+		Caption: h.layout.Text(c, "registered_users_text", struct {
+			RoleSeats     []dto.RoleSeats
+			WaitlistCount int
+		}{
+			RoleSeats:     roleSeats,
+			WaitlistCount: len(waitlist),
+		}),
 		FileName: "users.xlsx",
 	}
 
@@ -3602,7 +5162,67 @@ func (h Handler) registeredUsers(c tele.Context) error {
 	)
 }
 
+func (h Handler) eventFeedback(c tele.Context) error {
+	data := strings.Split(c.Callback().Data, " ")
+	if len(data) != 2 {
+		return errorz.ErrInvalidCallbackData
+	}
+
+	eventID := data[0]
+	h.logger.Infof("(user: %d) export event feedback (event_id=%s)", c.Sender().ID, eventID)
+
+	feedback, err := h.feedbackService.GetByEventID(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event feedback: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+	if len(feedback) == 0 {
+		return c.Respond(&tele.CallbackResponse{
+			Text:      h.layout.Text(c, "no_event_feedback"),
+			ShowAlert: true,
+		})
+	}
+
+	summary, err := h.feedbackService.GetEventSummary(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event feedback summary: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+
+	buffer, err := feedbackToXLSX(feedback)
+	if err != nil {
+		return c.Send(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+
+	return c.Send(
+		&tele.Document{
+			File:     tele.FromReader(buffer),
+			Caption:  h.layout.Text(c, "event_feedback_text", summary),
+			FileName: "feedback.xlsx",
+		},
+		h.layout.Markup(c, "core:hide"),
+	)
+}
+
 func (h Handler) eventQRCode(c tele.Context) error {
+	return h.showEventQR(c, h.qrService.GetEventQR, "event_qr_text")
+}
+
+func (h Handler) eventExitQRCode(c tele.Context) error {
+	return h.showEventQR(c, h.qrService.GetEventExitQR, "event_exit_qr_text")
+}
+
+// showEventQR sends the entry or the exit QR code of the event returned by getQR
+func (h Handler) showEventQR(c tele.Context, getQR func(ctx context.Context, eventID string) (tele.File, error), captionKey string) error {
 	data := strings.Split(c.Callback().Data, " ")
 	if len(data) != 2 {
 		return errorz.ErrInvalidCallbackData
@@ -3656,7 +5276,7 @@ func (h Handler) eventQRCode(c tele.Context) error {
 	}
 
 	loading, _ := c.Bot().Send(c.Chat(), h.layout.Text(c, "loading"))
-	file, err := h.qrService.GetEventQR(context.Background(), eventID)
+	file, err := getQR(context.Background(), eventID)
 	if err != nil {
 		h.logger.Errorf("(user: %d) error while get event QR: %v", c.Sender().ID, err)
 		return c.Edit(
@@ -3675,7 +5295,7 @@ func (h Handler) eventQRCode(c tele.Context) error {
 	return c.Edit(
 		&tele.Photo{
 			File:    file,
-			Caption: h.layout.Text(c, "event_qr_text"),
+			Caption: h.layout.Text(c, captionKey),
 		},
 		h.layout.Markup(c, "clubOwner:event:back", struct {
 			ID   string
@@ -3697,7 +5317,23 @@ func (h Handler) ClubOwnerSetup(group *tele.Group, middle *middlewares.Handler)
 	group.Handle(h.layout.Callback("clubOwner:club:create_event"), h.createEvent)
 	group.Handle(h.layout.Callback("clubOwner:create_event:refill"), h.createEvent)
 	group.Handle(h.layout.Callback("clubOwner:create_event:confirm"), h.confirmEventCreation)
+	group.Handle(h.layout.Callback("clubOwner:create_event:draft"), h.confirmEventCreation)
 	group.Handle(h.layout.Callback("clubOwner:create_event:role"), h.eventAllowedRoles)
+	group.Handle(h.layout.Callback("clubOwner:create_event:categories"), h.eventCategories)
+	group.Handle(h.layout.Callback("clubOwner:create_event:category"), h.eventCategory)
+	group.Handle(h.layout.Callback("clubOwner:create_event:categories:done"), h.eventCategoriesDone)
+	group.Handle(h.layout.Callback("clubOwner:create_event:form"), h.eventForm)
+	group.Handle(h.layout.Callback("clubOwner:create_event:form:back"), h.eventForm)
+	group.Handle(h.layout.Callback("clubOwner:create_event:form:add"), h.eventFormAdd)
+	group.Handle(h.layout.Callback("clubOwner:create_event:form:required"), h.eventFormQuestion)
+	group.Handle(h.layout.Callback("clubOwner:create_event:form:delete"), h.eventFormQuestion)
+	group.Handle(h.layout.Callback("clubOwner:create_event:form:done"), h.eventCategoriesDone)
+	group.Handle(h.layout.Callback("clubOwner:create_event:repeat"), h.eventRecurrence)
+	group.Handle(h.layout.Callback("clubOwner:create_event:repeat:back"), h.eventRecurrence)
+	group.Handle(h.layout.Callback("clubOwner:create_event:repeat:frequency"), h.eventRecurrenceFrequency)
+	group.Handle(h.layout.Callback("clubOwner:create_event:repeat:weekday"), h.eventRecurrenceWeekday)
+	group.Handle(h.layout.Callback("clubOwner:create_event:repeat:end"), h.eventRecurrenceEnd)
+	group.Handle(h.layout.Callback("clubOwner:create_event:repeat:off"), h.eventRecurrenceDisable)
 	group.Handle(h.layout.Callback("clubOwner:club:back"), h.clubMenu)
 
 	group.Handle(h.layout.Callback("clubOwner:club:events"), h.eventsList)
@@ -3712,12 +5348,53 @@ func (h Handler) ClubOwnerSetup(group *tele.Group, middle *middlewares.Handler)
 	group.Handle(h.layout.Callback("clubOwner:event:settings:edit_description"), h.editEventDescription)
 	group.Handle(h.layout.Callback("clubOwner:event:settings:edit_after_reg_text"), h.editEventAfterRegistrationText)
 	group.Handle(h.layout.Callback("clubOwner:event:settings:edit:max_participants"), h.editEventMaxParticipants)
-	group.Handle(h.layout.Callback("clubOwner:event:delete"), h.deleteEvent)
-	group.Handle(h.layout.Callback("clubOwner:event:delete:accept"), h.acceptEventDelete)
-	group.Handle(h.layout.Callback("clubOwner:event:delete:decline"), h.declineEventDelete)
+	group.Handle(h.layout.Callback("clubOwner:event:settings:edit:max_guests"), h.editEventMaxGuests)
+	group.Handle(h.layout.Callback("clubOwner:event:settings:edit_start_time"), h.editEventStartTime)
+	group.Handle(h.layout.Callback("clubOwner:event:settings:edit_end_time"), h.editEventEndTime)
+	group.Handle(h.layout.Callback("clubOwner:event:settings:edit_location"), h.editEventLocation)
+	group.Handle(h.layout.Callback("clubOwner:event:settings:edit_reg_end"), h.editEventRegistrationEnd)
+	group.Handle(h.layout.Callback("clubOwner:event:cancel"), h.cancelEvent)
+	group.Handle(h.layout.Callback("clubOwner:event:cancel:accept"), h.acceptEventCancel)
+	group.Handle(h.layout.Callback("clubOwner:event:cancel:decline"), h.declineEventCancel)
 
 	group.Handle(h.layout.Callback("clubOwner:event:users"), h.registeredUsers)
+	group.Handle(h.layout.Callback("clubOwner:event:co_hosts"), h.eventCoHosts)
+	group.Handle(h.layout.Callback("clubOwner:event:co_hosts:back"), h.eventCoHosts)
+	group.Handle(h.layout.Callback("clubOwner:event:co_hosts:invite"), h.coHostClubs)
+	group.Handle(h.layout.Callback("clubOwner:event:co_hosts:prev_page"), h.coHostClubs)
+	group.Handle(h.layout.Callback("clubOwner:event:co_hosts:next_page"), h.coHostClubs)
+	group.Handle(h.layout.Callback("clubOwner:event:co_hosts:club"), h.inviteCoHost)
+	group.Handle(h.layout.Callback("clubOwner:event:co_host"), h.coHost)
+	group.Handle(h.layout.Callback("clubOwner:event:co_host:remove"), h.removeCoHost)
+	group.Handle(h.layout.Callback("clubOwner:co_host:accept"), h.answerCoHostInvitation)
+	group.Handle(h.layout.Callback("clubOwner:co_host:decline"), h.answerCoHostInvitation)
+	group.Handle(h.layout.Callback("clubOwner:event:settings:approval"), h.toggleEventApproval)
+	group.Handle(h.layout.Callback("clubOwner:event:settings:format"), h.toggleEventFormat)
+	group.Handle(h.layout.Callback("clubOwner:event:settings:edit_meeting_url"), h.editEventMeetingURL)
+	group.Handle(h.layout.Callback("clubOwner:event:settings:deadlines"), h.eventDeadlines)
+	group.Handle(h.layout.Callback("clubOwner:event:deadlines:back"), h.eventDeadlines)
+	group.Handle(h.layout.Callback("clubOwner:event:deadline"), h.editEventDeadline)
+	group.Handle(h.layout.Callback("clubOwner:event:deadline:reset"), h.resetEventDeadline)
+	group.Handle(h.layout.Callback("clubOwner:event:settings:reminders"), h.eventReminders)
+	group.Handle(h.layout.Callback("clubOwner:event:reminder"), h.toggleEventReminder)
+	group.Handle(h.layout.Callback("clubOwner:event:settings:quotas"), h.eventQuotas)
+	group.Handle(h.layout.Callback("clubOwner:event:quotas:back"), h.eventQuotas)
+	group.Handle(h.layout.Callback("clubOwner:event:quota"), h.editEventQuota)
+	group.Handle(h.layout.Callback("clubOwner:event:preview"), h.eventPreview)
+	group.Handle(h.layout.Callback("clubOwner:event:clone"), h.cloneEvent)
+	group.Handle(h.layout.Callback("clubOwner:event:clone:open"), h.event)
+	group.Handle(h.layout.Callback("clubOwner:event:publish"), h.eventPublish)
+	group.Handle(h.layout.Callback("clubOwner:event:publish:back"), h.eventPublish)
+	group.Handle(h.layout.Callback("clubOwner:event:publish:now"), h.eventPublishNow)
+	group.Handle(h.layout.Callback("clubOwner:event:publish:schedule"), h.eventPublishSchedule)
+	group.Handle(h.layout.Callback("clubOwner:event:publish:unschedule"), h.eventPublishSettings)
+	group.Handle(h.layout.Callback("clubOwner:event:publish:announce"), h.eventPublishSettings)
+	group.Handle(h.layout.Callback("clubOwner:approval:approve"), h.approveRegistration)
+	group.Handle(h.layout.Callback("clubOwner:approval:reject"), h.rejectRegistration)
+	group.Handle(h.layout.Callback("clubOwner:approval:reject:back"), h.approvalCard)
 	group.Handle(h.layout.Callback("clubOwner:event:qr"), h.eventQRCode)
+	group.Handle(h.layout.Callback("clubOwner:event:exit_qr"), h.eventExitQRCode)
+	group.Handle(h.layout.Callback("clubOwner:event:feedback"), h.eventFeedback)
 
 	group.Handle(h.layout.Callback("clubOwner:event:mailing"), h.eventMailing)
 	group.Handle(h.layout.Callback("clubOwner:event:mailing:back"), h.eventMailing)
@@ -3764,7 +5441,7 @@ func parseEventCallback(callbackData string) (string, int, error) {
 	return clubID, p, nil
 }
 
-func usersToXLSX(users []dto.EventUser) (*bytes.Buffer, error) {
+func feedbackToXLSX(feedback []entity.EventFeedback) (*bytes.Buffer, error) {
 	f := excelize.NewFile()
 
 	sheet := "Sheet1"
@@ -3772,17 +5449,21 @@ func usersToXLSX(users []dto.EventUser) (*bytes.Buffer, error) {
 	_ = f.SetCellValue(sheet, "B1", "Фамилия")
 	_ = f.SetCellValue(sheet, "C1", "Имя")
 	_ = f.SetCellValue(sheet, "D1", "Username")
-	_ = f.SetCellValue(sheet, "E1", "Посетил")
+	_ = f.SetCellValue(sheet, "E1", "Оценка")
+	_ = f.SetCellValue(sheet, "F1", "Комментарий")
+	_ = f.SetCellValue(sheet, "G1", "Дата")
 
-	for i, user := range users {
-		fio := strings.Split(user.User.FIO.String(), " ")
+	for i, entry := range feedback {
+		fio := strings.Split(entry.User.FIO.String(), " ")
 
 		row := i + 2
-		_ = f.SetCellValue(sheet, "A"+strconv.Itoa(row), user.User.ID)
+		_ = f.SetCellValue(sheet, "A"+strconv.Itoa(row), entry.User.ID)
 		_ = f.SetCellValue(sheet, "B"+strconv.Itoa(row), fio[0])
 		_ = f.SetCellValue(sheet, "C"+strconv.Itoa(row), fio[1])
-		_ = f.SetCellValue(sheet, "D"+strconv.Itoa(row), user.User.Username)
-		_ = f.SetCellValue(sheet, "e"+strconv.Itoa(row), user.UserVisit)
+		_ = f.SetCellValue(sheet, "D"+strconv.Itoa(row), entry.User.Username)
+		_ = f.SetCellValue(sheet, "E"+strconv.Itoa(row), entry.Rating)
+		_ = f.SetCellValue(sheet, "F"+strconv.Itoa(row), entry.Comment)
+		_ = f.SetCellValue(sheet, "G"+strconv.Itoa(row), entry.UpdatedAt.In(location.Location()).Format("02.01.2006 15:04"))
 	}
 
 	var buf bytes.Buffer
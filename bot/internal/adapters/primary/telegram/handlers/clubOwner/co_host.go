@@ -0,0 +1,580 @@
+package clubowner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tele "gopkg.in/telebot.v3"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/common/errorz"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/banner"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/location"
+)
+
+const (
+	// coHostInvitationTTL - how long co-host club owners can answer the invitation
+	coHostInvitationTTL = 7 * 24 * time.Hour
+	// coHostCallbackTTL - lifetime of the callbacks in co-host menus
+	coHostCallbackTTL = 30 * time.Minute
+)
+
+// eventClubID returns the club on behalf of which the user manages the event
+// and whether it is the primary club of the event (otherwise it is an accepted co-host)
+func (h Handler) eventClubID(ctx context.Context, userID int64, event *entity.Event) (string, bool, error) {
+	clubs, err := h.clubService.GetByOwnerID(ctx, userID)
+	if err != nil {
+		return "", false, err
+	}
+
+	owned := make(map[string]bool, len(clubs))
+	for _, club := range clubs {
+		owned[club.ID] = true
+	}
+	if owned[event.ClubID] {
+		return event.ClubID, true, nil
+	}
+
+	coHosts, err := h.eventCoHostService.GetByEventID(ctx, event.ID)
+	if err != nil {
+		return "", false, err
+	}
+	for _, coHost := range coHosts {
+		if coHost.Accepted && owned[coHost.ClubID] {
+			return coHost.ClubID, false, nil
+		}
+	}
+
+	return "", false, errorz.ErrNotEventHost
+}
+
+func (h Handler) eventCoHosts(c tele.Context) error {
+	data := strings.Split(c.Callback().Data, " ")
+	if len(data) != 2 {
+		return errorz.ErrInvalidCallbackData
+	}
+
+	eventID := data[0]
+	page := data[1]
+	h.logger.Infof("(user: %d) event co-hosts (event_id=%s)", c.Sender().ID, eventID)
+
+	caption, markup, err := h.eventCoHostsMenu(c, eventID, page)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event co-hosts: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:event:back", struct {
+				ID   string
+				Page string
+			}{
+				ID:   eventID,
+				Page: page,
+			}),
+		)
+	}
+
+	return c.Edit(caption, markup)
+}
+
+func (h Handler) eventCoHostsMenu(c tele.Context, eventID, page string) (interface{}, *tele.ReplyMarkup, error) {
+	event, err := h.eventService.Get(context.Background(), eventID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	coHosts, err := h.eventCoHostService.GetByEventID(context.Background(), event.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	markup := c.Bot().NewMarkup()
+	var rows []tele.Row
+	for _, coHost := range coHosts {
+		callbackID, errSet := h.callbacksStorage.Set(fmt.Sprintf("%s %s %s", event.ID, page, coHost.ClubID), coHostCallbackTTL)
+		if errSet != nil {
+			return nil, nil, errSet
+		}
+		rows = append(rows, markup.Row(*h.layout.Button(c, "clubOwner:event:co_host", struct {
+			CallbackID string
+			Name       string
+			Accepted   bool
+		}{
+			CallbackID: callbackID,
+			Name:       coHost.Club.Name,
+			Accepted:   coHost.Accepted,
+		})))
+	}
+
+	if !event.IsCancelled() {
+		rows = append(rows, markup.Row(*h.layout.Button(c, "clubOwner:event:co_hosts:invite", struct {
+			ID       string
+			Page     string
+			ClubPage int
+		}{
+			ID:       event.ID,
+			Page:     page,
+			ClubPage: 0,
+		})))
+	}
+	rows = append(rows, markup.Row(*h.layout.Button(c, "clubOwner:event:back", struct {
+		ID   string
+		Page string
+	}{
+		ID:   event.ID,
+		Page: page,
+	})))
+	markup.Inline(rows...)
+
+	return banner.ClubOwner.Caption(h.layout.Text(c, "event_co_hosts_text", struct {
+		Name         string
+		CoHostsCount int
+	}{
+		Name:         event.Name,
+		CoHostsCount: len(coHosts),
+	})), markup, nil
+}
+
+func (h Handler) coHostClubs(c tele.Context) error {
+	const clubsOnPage = 5
+
+	data := strings.Split(c.Callback().Data, " ")
+	if len(data) != 3 {
+		return errorz.ErrInvalidCallbackData
+	}
+
+	eventID := data[0]
+	page := data[1]
+	p, err := strconv.Atoi(data[2])
+	if err != nil {
+		return errorz.ErrInvalidCallbackData
+	}
+	h.logger.Infof("(user: %d) co-host clubs list (event_id=%s, page=%d)", c.Sender().ID, eventID, p)
+
+	backMarkup := h.layout.Markup(c, "clubOwner:event:co_hosts:back", struct {
+		ID   string
+		Page string
+	}{
+		ID:   eventID,
+		Page: page,
+	})
+
+	event, err := h.eventService.Get(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event: %v", c.Sender().ID, err)
+		return c.Edit(banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())), backMarkup)
+	}
+
+	clubsCount, err := h.clubService.Count(context.Background())
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get clubs count: %v", c.Sender().ID, err)
+		return c.Edit(banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())), backMarkup)
+	}
+
+	clubs, err := h.clubService.GetWithPagination(context.Background(), clubsOnPage, p*clubsOnPage, "name ASC")
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get clubs: %v", c.Sender().ID, err)
+		return c.Edit(banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())), backMarkup)
+	}
+
+	markup := c.Bot().NewMarkup()
+	var rows []tele.Row
+	for _, club := range clubs {
+		if club.ID == event.ClubID {
+			continue
+		}
+		callbackID, errSet := h.callbacksStorage.Set(fmt.Sprintf("%s %s %s", event.ID, page, club.ID), coHostCallbackTTL)
+		if errSet != nil {
+			h.logger.Errorf("(user: %d) error while setting callback: %v", c.Sender().ID, errSet)
+			continue
+		}
+		rows = append(rows, markup.Row(*h.layout.Button(c, "clubOwner:event:co_hosts:club", struct {
+			CallbackID string
+			Name       string
+		}{
+			CallbackID: callbackID,
+			Name:       club.Name,
+		})))
+	}
+
+	pagesCount := (int(clubsCount) - 1) / clubsOnPage
+	prevPage, nextPage := p-1, p+1
+	if p == 0 {
+		prevPage = pagesCount
+	}
+	if p >= pagesCount {
+		nextPage = 0
+	}
+
+	rows = append(
+		rows,
+		markup.Row(
+			*h.layout.Button(c, "clubOwner:event:co_hosts:prev_page", struct {
+				ID       string
+				Page     string
+				ClubPage int
+			}{
+				ID:       event.ID,
+				Page:     page,
+				ClubPage: prevPage,
+			}),
+			*h.layout.Button(c, "core:page_counter", struct {
+				Page       int
+				PagesCount int
+			}{
+				Page:       p + 1,
+				PagesCount: pagesCount + 1,
+			}),
+			*h.layout.Button(c, "clubOwner:event:co_hosts:next_page", struct {
+				ID       string
+				Page     string
+				ClubPage int
+			}{
+				ID:       event.ID,
+				Page:     page,
+				ClubPage: nextPage,
+			}),
+		),
+		markup.Row(*h.layout.Button(c, "clubOwner:event:co_hosts:back", struct {
+			ID   string
+			Page string
+		}{
+			ID:   event.ID,
+			Page: page,
+		})),
+	)
+	markup.Inline(rows...)
+
+	return c.Edit(
+		banner.ClubOwner.Caption(h.layout.Text(c, "co_host_clubs_list", struct {
+			Name string
+		}{
+			Name: event.Name,
+		})),
+		markup,
+	)
+}
+
+func (h Handler) inviteCoHost(c tele.Context) error {
+	callbackData, err := h.callbacksStorage.Get(c.Callback().Data)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while getting callback from redis: %v", c.Sender().ID, err)
+		return c.Respond(&tele.CallbackResponse{
+			Text:      h.layout.Text(c, "co_host_callback_expired"),
+			ShowAlert: true,
+		})
+	}
+
+	data := strings.Split(callbackData, " ")
+	if len(data) != 3 {
+		return errorz.ErrInvalidCallbackData
+	}
+	eventID, page, clubID := data[0], data[1], data[2]
+	h.logger.Infof("(user: %d) invite co-host (event_id=%s, club_id=%s)", c.Sender().ID, eventID, clubID)
+
+	coHost, err := h.eventCoHostService.Invite(context.Background(), eventID, clubID)
+	switch {
+	case errors.Is(err, errorz.ErrCoHostAlreadyInvited):
+		return c.Respond(&tele.CallbackResponse{
+			Text:      h.layout.Text(c, "co_host_already_invited"),
+			ShowAlert: true,
+		})
+	case errors.Is(err, errorz.ErrEventCancelled):
+		return c.Respond(&tele.CallbackResponse{
+			Text:      h.layout.Text(c, "event_cancelled_alert"),
+			ShowAlert: true,
+		})
+	case err != nil:
+		h.logger.Errorf("(user: %d) error while invite co-host: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:event:co_hosts:back", struct {
+				ID   string
+				Page string
+			}{
+				ID:   eventID,
+				Page: page,
+			}),
+		)
+	}
+
+	if errSend := h.sendCoHostInvitation(c, coHost); errSend != nil {
+		h.logger.Errorf("(user: %d) error while send co-host invitation: %v", c.Sender().ID, errSend)
+	}
+
+	_ = c.Respond(&tele.CallbackResponse{
+		Text: h.layout.Text(c, "co_host_invited"),
+	})
+
+	caption, markup, err := h.eventCoHostsMenu(c, eventID, page)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event co-hosts: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:event:back", struct {
+				ID   string
+				Page string
+			}{
+				ID:   eventID,
+				Page: page,
+			}),
+		)
+	}
+	return c.Edit(caption, markup)
+}
+
+// sendCoHostInvitation sends the invitation with accept/decline buttons to the owners of the co-host club
+func (h Handler) sendCoHostInvitation(c tele.Context, coHost *entity.EventCoHost) error {
+	event, err := h.eventService.Get(context.Background(), coHost.EventID)
+	if err != nil {
+		return err
+	}
+
+	club, err := h.clubService.Get(context.Background(), event.ClubID)
+	if err != nil {
+		return err
+	}
+
+	owners, err := h.clubOwnerService.GetByClubID(context.Background(), coHost.ClubID)
+	if err != nil {
+		return err
+	}
+
+	callbackID, err := h.callbacksStorage.Set(fmt.Sprintf("%s %s", coHost.EventID, coHost.ClubID), coHostInvitationTTL)
+	if err != nil {
+		return err
+	}
+
+	text := h.layout.Text(c, "co_host_invitation", struct {
+		ClubName  string
+		EventName string
+		StartTime string
+	}{
+		ClubName:  club.Name,
+		EventName: event.Name,
+		StartTime: event.StartTime.In(location.Location()).Format("02.01.2006 15:04"),
+	})
+	markup := h.layout.Markup(c, "clubOwner:co_host:invitation", struct {
+		CallbackID string
+	}{
+		CallbackID: callbackID,
+	})
+
+	for _, owner := range owners {
+		chat, errGet := c.Bot().ChatByID(owner.UserID)
+		if errGet != nil {
+			h.logger.Errorf("(user: %d) error while get chat of club owner %d: %v", c.Sender().ID, owner.UserID, errGet)
+			continue
+		}
+		if _, errSend := c.Bot().Send(chat, banner.ClubOwner.Caption(text), markup); errSend != nil {
+			h.logger.Errorf("(user: %d) error while send co-host invitation to %d: %v", c.Sender().ID, owner.UserID, errSend)
+		}
+	}
+
+	return nil
+}
+
+func (h Handler) answerCoHostInvitation(c tele.Context) error {
+	accepted := c.Callback().Unique == "cOwner_coHost_accept"
+
+	callbackData, err := h.callbacksStorage.Get(c.Callback().Data)
+	if err != nil {
+		h.logger.Infof("(user: %d) co-host invitation expired: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "co_host_invitation_expired")),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+
+	data := strings.Split(callbackData, " ")
+	if len(data) != 2 {
+		return errorz.ErrInvalidCallbackData
+	}
+	eventID, clubID := data[0], data[1]
+	h.logger.Infof("(user: %d) answer co-host invitation (event_id=%s, club_id=%s, accepted=%t)", c.Sender().ID, eventID, clubID, accepted)
+
+	if _, err = h.clubOwnerService.Get(context.Background(), clubID, c.Sender().ID); err != nil {
+		h.logger.Errorf("(user: %d) error while get club owner: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "co_host_invitation_expired")),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+
+	coHost, err := h.eventCoHostService.Get(context.Background(), eventID, clubID)
+	if err != nil || coHost.Accepted {
+		h.callbacksStorage.Delete(c.Callback().Data)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "co_host_invitation_expired")),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+
+	if accepted {
+		_, err = h.eventCoHostService.Accept(context.Background(), eventID, clubID)
+	} else {
+		err = h.eventCoHostService.Remove(context.Background(), eventID, clubID)
+	}
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while answer co-host invitation: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+	h.callbacksStorage.Delete(c.Callback().Data)
+
+	event, err := h.eventService.Get(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+
+	// Let the primary club know about the answer
+	owners, err := h.clubOwnerService.GetByClubID(context.Background(), event.ClubID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get club owners: %v", c.Sender().ID, err)
+	}
+	for _, owner := range owners {
+		chat, errGet := c.Bot().ChatByID(owner.UserID)
+		if errGet != nil {
+			continue
+		}
+		_, _ = c.Bot().Send(
+			chat,
+			banner.ClubOwner.Caption(h.layout.Text(c, "co_host_answer", struct {
+				ClubName  string
+				EventName string
+				Accepted  bool
+			}{
+				ClubName:  coHost.Club.Name,
+				EventName: event.Name,
+				Accepted:  accepted,
+			})),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+
+	return c.Edit(
+		banner.ClubOwner.Caption(h.layout.Text(c, "co_host_invitation_answered", struct {
+			EventName string
+			Accepted  bool
+		}{
+			EventName: event.Name,
+			Accepted:  accepted,
+		})),
+		h.layout.Markup(c, "core:hide"),
+	)
+}
+
+func (h Handler) coHost(c tele.Context) error {
+	callbackData, err := h.callbacksStorage.Get(c.Callback().Data)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while getting callback from redis: %v", c.Sender().ID, err)
+		return c.Respond(&tele.CallbackResponse{
+			Text:      h.layout.Text(c, "co_host_callback_expired"),
+			ShowAlert: true,
+		})
+	}
+
+	data := strings.Split(callbackData, " ")
+	if len(data) != 3 {
+		return errorz.ErrInvalidCallbackData
+	}
+	eventID, page, clubID := data[0], data[1], data[2]
+	h.logger.Infof("(user: %d) event co-host (event_id=%s, club_id=%s)", c.Sender().ID, eventID, clubID)
+
+	backMarkup := h.layout.Markup(c, "clubOwner:event:co_hosts:back", struct {
+		ID   string
+		Page string
+	}{
+		ID:   eventID,
+		Page: page,
+	})
+
+	coHost, err := h.eventCoHostService.Get(context.Background(), eventID, clubID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get co-host: %v", c.Sender().ID, err)
+		return c.Edit(banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())), backMarkup)
+	}
+
+	markup := h.layout.Markup(c, "clubOwner:event:co_host", struct {
+		CallbackID string
+		ID         string
+		Page       string
+	}{
+		CallbackID: c.Callback().Data,
+		ID:         eventID,
+		Page:       page,
+	})
+
+	return c.Edit(
+		banner.ClubOwner.Caption(h.layout.Text(c, "co_host_text", struct {
+			Name     string
+			Accepted bool
+		}{
+			Name:     coHost.Club.Name,
+			Accepted: coHost.Accepted,
+		})),
+		markup,
+	)
+}
+
+func (h Handler) removeCoHost(c tele.Context) error {
+	callbackData, err := h.callbacksStorage.Get(c.Callback().Data)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while getting callback from redis: %v", c.Sender().ID, err)
+		return c.Respond(&tele.CallbackResponse{
+			Text:      h.layout.Text(c, "co_host_callback_expired"),
+			ShowAlert: true,
+		})
+	}
+	h.callbacksStorage.Delete(c.Callback().Data)
+
+	data := strings.Split(callbackData, " ")
+	if len(data) != 3 {
+		return errorz.ErrInvalidCallbackData
+	}
+	eventID, page, clubID := data[0], data[1], data[2]
+	h.logger.Infof("(user: %d) remove co-host (event_id=%s, club_id=%s)", c.Sender().ID, eventID, clubID)
+
+	if err = h.eventCoHostService.Remove(context.Background(), eventID, clubID); err != nil {
+		h.logger.Errorf("(user: %d) error while remove co-host: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:event:co_hosts:back", struct {
+				ID   string
+				Page string
+			}{
+				ID:   eventID,
+				Page: page,
+			}),
+		)
+	}
+
+	_ = c.Respond(&tele.CallbackResponse{
+		Text: h.layout.Text(c, "co_host_removed"),
+	})
+
+	caption, markup, err := h.eventCoHostsMenu(c, eventID, page)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event co-hosts: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:event:back", struct {
+				ID   string
+				Page string
+			}{
+				ID:   eventID,
+				Page: page,
+			}),
+		)
+	}
+	return c.Edit(caption, markup)
+}
@@ -0,0 +1,221 @@
+package clubowner
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nlypage/intele/collector"
+	tele "gopkg.in/telebot.v3"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/common/errorz"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/banner"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/location"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/validator"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/valueobject"
+)
+
+// eventRoleData parses "eventID page roleIndex" callback data of the per-role settings,
+// the role is passed by its index in valueobject.AllRoles() to fit the callback data limit
+func eventRoleData(data string) (string, string, valueobject.Role, error) {
+	parts := strings.Split(data, " ")
+	if len(parts) != 3 {
+		return "", "", "", errorz.ErrInvalidCallbackData
+	}
+
+	roles := valueobject.AllRoles()
+	index, err := strconv.Atoi(parts[2])
+	if err != nil || index < 0 || index >= len(roles) {
+		return "", "", "", errorz.ErrInvalidCallbackData
+	}
+
+	return parts[0], parts[1], roles[index], nil
+}
+
+func (h Handler) eventDeadlines(c tele.Context) error {
+	data := strings.Split(c.Callback().Data, " ")
+	if len(data) != 2 {
+		return errorz.ErrInvalidCallbackData
+	}
+
+	eventID := data[0]
+	page := data[1]
+	h.logger.Infof("(user: %d) event deadlines (event_id=%s)", c.Sender().ID, eventID)
+
+	event, err := h.eventService.Get(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+				ID   string
+				Page string
+			}{
+				ID:   eventID,
+				Page: page,
+			}),
+		)
+	}
+
+	caption, markup := h.eventDeadlinesMenu(c, event, page)
+	return c.Edit(caption, markup)
+}
+
+func (h Handler) eventDeadlinesMenu(c tele.Context, event *entity.Event, page string) (interface{}, *tele.ReplyMarkup) {
+	type roleDeadline struct {
+		Name       string
+		Deadline   string
+		Overridden bool
+	}
+
+	markup := c.Bot().NewMarkup()
+	var (
+		rows      []tele.Row
+		deadlines []roleDeadline
+	)
+	for i, role := range valueobject.AllRoles() {
+		if !event.IsRoleAllowed(role) {
+			continue
+		}
+
+		deadlines = append(deadlines, roleDeadline{
+			Name:       h.layout.Text(c, role.String()),
+			Deadline:   h.eventService.RegistrationDeadline(event, role).In(location.Location()).Format("02.01.2006 15:04"),
+			Overridden: event.HasRoleDeadline(role),
+		})
+
+		btnData := struct {
+			ID       string
+			Page     string
+			Index    int
+			RoleName string
+		}{
+			ID:       event.ID,
+			Page:     page,
+			Index:    i,
+			RoleName: h.layout.Text(c, role.String()),
+		}
+		row := markup.Row(*h.layout.Button(c, "clubOwner:event:deadline", btnData))
+		if event.HasRoleDeadline(role) {
+			row = append(row, *h.layout.Button(c, "clubOwner:event:deadline:reset", btnData))
+		}
+		rows = append(rows, row)
+	}
+	rows = append(rows, markup.Row(*h.layout.Button(c, "clubOwner:event:settings:back", struct {
+		ID   string
+		Page string
+	}{
+		ID:   event.ID,
+		Page: page,
+	})))
+	markup.Inline(rows...)
+
+	return banner.ClubOwner.Caption(h.layout.Text(c, "event_deadlines_text", struct {
+		Name      string
+		Deadlines []roleDeadline
+	}{
+		Name:      event.Name,
+		Deadlines: deadlines,
+	})), markup
+}
+
+func (h Handler) editEventDeadline(c tele.Context) error {
+	eventID, page, role, err := eventRoleData(c.Callback().Data)
+	if err != nil {
+		return err
+	}
+	h.logger.Infof("(user: %d) edit event deadline (event_id=%s, role=%s)", c.Sender().ID, eventID, role)
+
+	backMarkup := h.layout.Markup(c, "clubOwner:event:deadlines:back", struct {
+		ID   string
+		Page string
+	}{
+		ID:   eventID,
+		Page: page,
+	})
+
+	event, err := h.eventService.Get(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			backMarkup,
+		)
+	}
+
+	registrationEnd := event.RegistrationEnd.In(location.Location()).Format("02.01.2006 15:04")
+	inputCollector := collector.New()
+	value, ok := h.inputEventValue(c, inputCollector, eventID, page, "clubOwner:event:deadlines:back",
+		"input_event_role_deadline",
+		struct {
+			RoleName        string
+			RegistrationEnd string
+		}{
+			RoleName:        h.layout.Text(c, role.String()),
+			RegistrationEnd: registrationEnd,
+		},
+		"invalid_event_role_deadline",
+		validator.EventRoleDeadline,
+		map[string]interface{}{
+			"registrationEnd": registrationEnd,
+		},
+	)
+	if !ok {
+		return nil
+	}
+
+	at, _ := time.ParseInLocation("02.01.2006 15:04", value, location.Location())
+	event.SetRoleDeadline(role, &at)
+	event, err = h.eventService.Update(context.Background(), event)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while update event deadline: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			backMarkup,
+		)
+	}
+
+	caption, markup := h.eventDeadlinesMenu(c, event, page)
+	return c.Send(caption, markup)
+}
+
+// resetEventDeadline removes the deadline set for the role, so the deadline policy applies again
+func (h Handler) resetEventDeadline(c tele.Context) error {
+	eventID, page, role, err := eventRoleData(c.Callback().Data)
+	if err != nil {
+		return err
+	}
+	h.logger.Infof("(user: %d) reset event deadline (event_id=%s, role=%s)", c.Sender().ID, eventID, role)
+
+	backMarkup := h.layout.Markup(c, "clubOwner:event:deadlines:back", struct {
+		ID   string
+		Page string
+	}{
+		ID:   eventID,
+		Page: page,
+	})
+
+	event, err := h.eventService.Get(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			backMarkup,
+		)
+	}
+
+	event.SetRoleDeadline(role, nil)
+	event, err = h.eventService.Update(context.Background(), event)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while update event deadline: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			backMarkup,
+		)
+	}
+
+	caption, markup := h.eventDeadlinesMenu(c, event, page)
+	return c.Edit(caption, markup)
+}
@@ -0,0 +1,148 @@
+package clubowner
+
+import (
+	"context"
+	"strings"
+
+	"github.com/nlypage/intele/collector"
+	tele "gopkg.in/telebot.v3"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/common/errorz"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/banner"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/validator"
+)
+
+// toggleEventFormat switches the event to the next format: offline -> online -> hybrid.
+// Passes are synced because online events don't need them, the meeting link is dropped for offline events.
+func (h Handler) toggleEventFormat(c tele.Context) error {
+	data := strings.Split(c.Callback().Data, " ")
+	if len(data) != 2 {
+		return errorz.ErrInvalidCallbackData
+	}
+
+	eventID := data[0]
+	page := data[1]
+	h.logger.Infof("(user: %d) toggle event format (event_id=%s)", c.Sender().ID, eventID)
+
+	backMarkup := h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+		ID   string
+		Page string
+	}{
+		ID:   eventID,
+		Page: page,
+	})
+
+	event, err := h.eventService.Get(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			backMarkup,
+		)
+	}
+
+	passRequired := event.IsPassRequired()
+	event.Format = event.Format.Next()
+	if event.Format == entity.EventFormatOffline {
+		event.MeetingURL = ""
+	}
+
+	if _, err = h.eventService.Update(context.Background(), event); err != nil {
+		h.logger.Errorf("(user: %d) error while update event: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.eventSaveErrorText(c, err)),
+			backMarkup,
+		)
+	}
+
+	if passRequired != event.IsPassRequired() {
+		if err = h.eventParticipantService.SyncPasses(context.Background(), event.ID); err != nil {
+			h.logger.Errorf("(user: %d) error while sync event passes: %v", c.Sender().ID, err)
+		}
+	}
+
+	return h.eventSettings(c)
+}
+
+func (h Handler) editEventMeetingURL(c tele.Context) error {
+	data := strings.Split(c.Callback().Data, " ")
+	if len(data) != 2 {
+		return errorz.ErrInvalidCallbackData
+	}
+
+	eventID := data[0]
+	page := data[1]
+	h.logger.Infof("(user: %d) edit event meeting url", c.Sender().ID)
+
+	backMarkup := h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+		ID   string
+		Page string
+	}{
+		ID:   eventID,
+		Page: page,
+	})
+
+	event, err := h.eventService.Get(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			backMarkup,
+		)
+	}
+
+	if !event.HasOnlinePart() {
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "event_meeting_url_offline")),
+			backMarkup,
+		)
+	}
+
+	inputCollector := collector.New()
+	meetingURL, ok := h.inputEventValue(c, inputCollector, eventID, page, "clubOwner:event:settings:back",
+		"input_event_meeting_url",
+		struct{}{},
+		"invalid_event_meeting_url",
+		validator.EventMeetingURL,
+		nil,
+	)
+	if !ok {
+		return nil
+	}
+
+	events, err := h.eventsToEdit(c, inputCollector, event, page)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get events to edit: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			backMarkup,
+		)
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	for _, e := range events {
+		previous := e
+		e.MeetingURL = meetingURL
+
+		updatedEvent, err := h.eventService.Update(context.Background(), &e)
+		if err != nil {
+			h.logger.Errorf("(user: %d) error while update event meeting url: %v", c.Sender().ID, err)
+			return c.Send(
+				banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+				backMarkup,
+			)
+		}
+
+		if err = h.sendEventScheduleUpdate(c, *updatedEvent, previous, "event_field_meeting_url"); err != nil {
+			h.logger.Errorf("(user: %d) error while send event update notification: %v", c.Sender().ID, err)
+		}
+	}
+
+	return c.Send(
+		banner.ClubOwner.Caption(h.layout.Text(c, "event_meeting_url_changed")),
+		backMarkup,
+	)
+}
@@ -0,0 +1,339 @@
+package clubowner
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/nlypage/intele/collector"
+	tele "gopkg.in/telebot.v3"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/common/errorz"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/dto"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/banner"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/location"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/validator"
+)
+
+// eventPreview shows the draft exactly as users will see it in the events list
+func (h Handler) eventPreview(c tele.Context) error {
+	data := strings.Split(c.Callback().Data, " ")
+	if len(data) != 2 {
+		return errorz.ErrInvalidCallbackData
+	}
+
+	eventID := data[0]
+	page := data[1]
+	h.logger.Infof("(user: %d) preview event (event_id=%s)", c.Sender().ID, eventID)
+
+	backMarkup := h.layout.Markup(c, "clubOwner:event:back", struct {
+		ID   string
+		Page string
+	}{
+		ID:   eventID,
+		Page: page,
+	})
+
+	event, err := h.eventService.Get(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			backMarkup,
+		)
+	}
+
+	club, err := h.clubService.Get(context.Background(), event.ClubID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get club: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			backMarkup,
+		)
+	}
+
+	roleSeats, err := h.eventParticipantService.GetSeatsByRole(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get seats by role: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			backMarkup,
+		)
+	}
+
+	endTime := event.EndTime.In(location.Location()).Format("02.01.2006 15:04")
+	if event.EndTime.Year() == 1 {
+		endTime = ""
+	}
+
+	return c.Edit(
+		banner.Events.Caption(h.layout.Text(c, "event_text", struct {
+			Name                  string
+			ClubName              string
+			Description           string
+			Location              string
+			StartTime             string
+			EndTime               string
+			RegistrationEnd       string
+			MaxParticipants       int
+			ParticipantsCount     int
+			RoleSeats             []dto.RoleSeats
+			AfterRegistrationText string
+			IsRegistered          bool
+			IsPending             bool
+			MeetingURL            string
+			WaitlistPosition      int
+		}{
+			Name:                  event.Name,
+			ClubName:              club.Name,
+			Description:           event.Description,
+			Location:              event.Location,
+			StartTime:             event.StartTime.In(location.Location()).Format("02.01.2006 15:04"),
+			EndTime:               endTime,
+			RegistrationEnd:       event.RegistrationEnd.In(location.Location()).Format("02.01.2006 15:04"),
+			MaxParticipants:       event.MaxParticipants,
+			RoleSeats:             roleSeats,
+			AfterRegistrationText: event.AfterRegistrationText,
+		})),
+		backMarkup,
+	)
+}
+
+func (h Handler) eventPublish(c tele.Context) error {
+	data := strings.Split(c.Callback().Data, " ")
+	if len(data) != 2 {
+		return errorz.ErrInvalidCallbackData
+	}
+
+	eventID := data[0]
+	page := data[1]
+	h.logger.Infof("(user: %d) event publish menu (event_id=%s)", c.Sender().ID, eventID)
+
+	event, err := h.eventService.Get(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:event:back", struct {
+				ID   string
+				Page string
+			}{
+				ID:   eventID,
+				Page: page,
+			}),
+		)
+	}
+
+	caption, markup := h.eventPublishMenu(c, event, page)
+	return c.Edit(caption, markup)
+}
+
+func (h Handler) eventPublishMenu(c tele.Context, event *entity.Event, page string) (interface{}, *tele.ReplyMarkup) {
+	var publishAt string
+	if event.PublishAt != nil {
+		publishAt = event.PublishAt.In(location.Location()).Format("02.01.2006 15:04")
+	}
+
+	btnData := struct {
+		ID       string
+		Page     string
+		Announce bool
+	}{
+		ID:       event.ID,
+		Page:     page,
+		Announce: event.AnnounceOnPublish,
+	}
+
+	markup := c.Bot().NewMarkup()
+	rows := []tele.Row{
+		markup.Row(*h.layout.Button(c, "clubOwner:event:publish:now", btnData)),
+		markup.Row(*h.layout.Button(c, "clubOwner:event:publish:schedule", btnData)),
+	}
+	if event.PublishAt != nil {
+		rows = append(rows, markup.Row(*h.layout.Button(c, "clubOwner:event:publish:unschedule", btnData)))
+	}
+	rows = append(rows,
+		markup.Row(*h.layout.Button(c, "clubOwner:event:publish:announce", btnData)),
+		markup.Row(*h.layout.Button(c, "clubOwner:event:back", btnData)),
+	)
+	markup.Inline(rows...)
+
+	return banner.ClubOwner.Caption(h.layout.Text(c, "event_publish_text", struct {
+		Name        string
+		PublishAt   string
+		Announce    bool
+		IsRecurring bool
+	}{
+		Name:        event.Name,
+		PublishAt:   publishAt,
+		Announce:    event.AnnounceOnPublish,
+		IsRecurring: event.IsRecurring(),
+	})), markup
+}
+
+func (h Handler) eventPublishNow(c tele.Context) error {
+	data := strings.Split(c.Callback().Data, " ")
+	if len(data) != 2 {
+		return errorz.ErrInvalidCallbackData
+	}
+
+	eventID := data[0]
+	page := data[1]
+	h.logger.Infof("(user: %d) publish event (event_id=%s)", c.Sender().ID, eventID)
+
+	backMarkup := h.layout.Markup(c, "clubOwner:event:back", struct {
+		ID   string
+		Page string
+	}{
+		ID:   eventID,
+		Page: page,
+	})
+
+	event, err := h.eventPublishService.Publish(context.Background(), eventID)
+	if err != nil {
+		if errors.Is(err, errorz.ErrEventNotDraft) {
+			return c.Edit(
+				banner.ClubOwner.Caption(h.layout.Text(c, "event_already_published")),
+				backMarkup,
+			)
+		}
+		h.logger.Errorf("(user: %d) error while publish event: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			backMarkup,
+		)
+	}
+
+	return c.Edit(
+		banner.ClubOwner.Caption(h.layout.Text(c, "event_published", struct {
+			Name string
+		}{
+			Name: event.Name,
+		})),
+		backMarkup,
+	)
+}
+
+func (h Handler) eventPublishSchedule(c tele.Context) error {
+	data := strings.Split(c.Callback().Data, " ")
+	if len(data) != 2 {
+		return errorz.ErrInvalidCallbackData
+	}
+
+	eventID := data[0]
+	page := data[1]
+	h.logger.Infof("(user: %d) schedule event publication (event_id=%s)", c.Sender().ID, eventID)
+
+	backMarkup := h.layout.Markup(c, "clubOwner:event:publish:back", struct {
+		ID   string
+		Page string
+	}{
+		ID:   eventID,
+		Page: page,
+	})
+
+	event, err := h.eventService.Get(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			backMarkup,
+		)
+	}
+
+	registrationEnd := event.RegistrationEnd.In(location.Location()).Format("02.01.2006 15:04")
+	inputCollector := collector.New()
+	value, ok := h.inputEventValue(c, inputCollector, eventID, page, "clubOwner:event:publish:back",
+		"input_event_publish_time",
+		struct {
+			RegistrationEnd string
+		}{
+			RegistrationEnd: registrationEnd,
+		},
+		"invalid_event_publish_time",
+		validator.EventPublishTime,
+		map[string]interface{}{
+			"registrationEnd": registrationEnd,
+		},
+	)
+	if !ok {
+		return nil
+	}
+
+	publishAt, _ := time.ParseInLocation("02.01.2006 15:04", value, location.Location())
+	publishAt = publishAt.UTC()
+	event, err = h.eventPublishService.Schedule(context.Background(), eventID, &publishAt, event.AnnounceOnPublish)
+	if err != nil {
+		if errors.Is(err, errorz.ErrEventNotDraft) {
+			return c.Send(
+				banner.ClubOwner.Caption(h.layout.Text(c, "event_already_published")),
+				backMarkup,
+			)
+		}
+		h.logger.Errorf("(user: %d) error while schedule event publication: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			backMarkup,
+		)
+	}
+
+	caption, markup := h.eventPublishMenu(c, event, page)
+	return c.Send(caption, markup)
+}
+
+// eventPublishSettings handles the announce toggle and the cancellation of the scheduled publication
+func (h Handler) eventPublishSettings(c tele.Context) error {
+	data := strings.Split(c.Callback().Data, " ")
+	if len(data) != 2 {
+		return errorz.ErrInvalidCallbackData
+	}
+
+	eventID := data[0]
+	page := data[1]
+	h.logger.Infof("(user: %d) edit event publication (event_id=%s, action=%s)", c.Sender().ID, eventID, c.Callback().Unique)
+
+	backMarkup := h.layout.Markup(c, "clubOwner:event:back", struct {
+		ID   string
+		Page string
+	}{
+		ID:   eventID,
+		Page: page,
+	})
+
+	event, err := h.eventService.Get(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			backMarkup,
+		)
+	}
+
+	publishAt, announce := event.PublishAt, event.AnnounceOnPublish
+	switch c.Callback().Unique {
+	case "cOwner_pubAnnounce":
+		announce = !announce
+	case "cOwner_pubUnschedule":
+		publishAt = nil
+	}
+
+	event, err = h.eventPublishService.Schedule(context.Background(), eventID, publishAt, announce)
+	if err != nil {
+		if errors.Is(err, errorz.ErrEventNotDraft) {
+			return c.Edit(
+				banner.ClubOwner.Caption(h.layout.Text(c, "event_already_published")),
+				backMarkup,
+			)
+		}
+		h.logger.Errorf("(user: %d) error while edit event publication: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			backMarkup,
+		)
+	}
+
+	caption, markup := h.eventPublishMenu(c, event, page)
+	return c.Edit(caption, markup)
+}
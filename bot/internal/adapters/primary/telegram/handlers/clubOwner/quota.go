@@ -0,0 +1,181 @@
+package clubowner
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nlypage/intele/collector"
+	tele "gopkg.in/telebot.v3"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/common/errorz"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/dto"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/banner"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/validator"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/valueobject"
+)
+
+func (h Handler) eventQuotas(c tele.Context) error {
+	data := strings.Split(c.Callback().Data, " ")
+	if len(data) != 2 {
+		return errorz.ErrInvalidCallbackData
+	}
+
+	eventID := data[0]
+	page := data[1]
+	h.logger.Infof("(user: %d) event quotas (event_id=%s)", c.Sender().ID, eventID)
+
+	backMarkup := h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+		ID   string
+		Page string
+	}{
+		ID:   eventID,
+		Page: page,
+	})
+
+	event, err := h.eventService.Get(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			backMarkup,
+		)
+	}
+
+	caption, markup, err := h.eventQuotasMenu(c, event, page)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event seats by role: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			backMarkup,
+		)
+	}
+	return c.Edit(caption, markup)
+}
+
+func (h Handler) eventQuotasMenu(c tele.Context, event *entity.Event, page string) (interface{}, *tele.ReplyMarkup, error) {
+	seats, err := h.eventParticipantService.GetSeatsByRole(context.Background(), event.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	markup := c.Bot().NewMarkup()
+	var rows []tele.Row
+	for _, roleSeats := range seats {
+		rows = append(rows, markup.Row(*h.layout.Button(c, "clubOwner:event:quota", struct {
+			ID       string
+			Page     string
+			Index    int
+			RoleName string
+		}{
+			ID:       event.ID,
+			Page:     page,
+			Index:    slices.Index(valueobject.AllRoles(), roleSeats.Role),
+			RoleName: h.layout.Text(c, roleSeats.Role.String()),
+		})))
+	}
+	rows = append(rows, markup.Row(*h.layout.Button(c, "clubOwner:event:settings:back", struct {
+		ID   string
+		Page string
+	}{
+		ID:   event.ID,
+		Page: page,
+	})))
+	markup.Inline(rows...)
+
+	return banner.ClubOwner.Caption(h.layout.Text(c, "event_quotas_text", struct {
+		Name            string
+		MaxParticipants int
+		Seats           []dto.RoleSeats
+	}{
+		Name:            event.Name,
+		MaxParticipants: event.MaxParticipants,
+		Seats:           seats,
+	})), markup, nil
+}
+
+func (h Handler) editEventQuota(c tele.Context) error {
+	eventID, page, role, err := eventRoleData(c.Callback().Data)
+	if err != nil {
+		return err
+	}
+	h.logger.Infof("(user: %d) edit event quota (event_id=%s, role=%s)", c.Sender().ID, eventID, role)
+
+	backMarkup := h.layout.Markup(c, "clubOwner:event:quotas:back", struct {
+		ID   string
+		Page string
+	}{
+		ID:   eventID,
+		Page: page,
+	})
+
+	event, err := h.eventService.Get(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			backMarkup,
+		)
+	}
+
+	seats, err := h.eventParticipantService.GetSeatsByRole(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event seats by role: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			backMarkup,
+		)
+	}
+	var taken int
+	for _, roleSeats := range seats {
+		if roleSeats.Role == role {
+			taken = roleSeats.Taken + roleSeats.Offered
+		}
+	}
+
+	inputCollector := collector.New()
+	value, ok := h.inputEventValue(c, inputCollector, eventID, page, "clubOwner:event:quotas:back",
+		"input_event_role_quota",
+		struct {
+			RoleName        string
+			Taken           int
+			MaxParticipants int
+		}{
+			RoleName:        h.layout.Text(c, role.String()),
+			Taken:           taken,
+			MaxParticipants: event.MaxParticipants,
+		},
+		"invalid_event_role_quota",
+		validator.EventRoleQuota,
+		map[string]interface{}{
+			"taken":           taken,
+			"maxParticipants": event.MaxParticipants,
+		},
+	)
+	if !ok {
+		return nil
+	}
+
+	quota, _ := strconv.Atoi(value)
+	event.SetRoleQuota(role, quota)
+	event, err = h.eventService.Update(context.Background(), event)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while update event quota: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			backMarkup,
+		)
+	}
+
+	caption, markup, err := h.eventQuotasMenu(c, event, page)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event seats by role: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			backMarkup,
+		)
+	}
+	return c.Send(caption, markup)
+}
@@ -0,0 +1,272 @@
+package clubowner
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nlypage/intele/collector"
+	tele "gopkg.in/telebot.v3"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/common/errorz"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/banner"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/validator"
+)
+
+func (h Handler) eventForm(c tele.Context) error {
+	clubID := c.Callback().Data
+	if clubID == "" {
+		return errorz.ErrInvalidCallbackData
+	}
+
+	h.logger.Infof("(user: %d) edit event registration form (club_id=%s)", c.Sender().ID, clubID)
+
+	event, err := h.eventsStorage.Get(c.Sender().ID)
+	if err != nil {
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:club:back", struct {
+				ID string
+			}{
+				ID: clubID,
+			}),
+		)
+	}
+
+	caption, markup := h.eventFormMenu(c, clubID, event)
+	return c.Edit(caption, markup)
+}
+
+func (h Handler) eventFormAdd(c tele.Context) error {
+	data := strings.Split(c.Callback().Data, " ")
+	if len(data) != 2 {
+		return errorz.ErrInvalidCallbackData
+	}
+	clubID, questionType := data[0], entity.EventQuestionType(data[1])
+	if !questionType.IsValid() {
+		return errorz.ErrInvalidCallbackData
+	}
+
+	event, err := h.eventsStorage.Get(c.Sender().ID)
+	if err != nil {
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:club:back", struct {
+				ID string
+			}{
+				ID: clubID,
+			}),
+		)
+	}
+
+	if len(event.Questions) >= entity.MaxEventQuestions {
+		return c.Respond(&tele.CallbackResponse{
+			Text: h.layout.Text(c, "event_form_full", struct {
+				MaxQuestions int
+			}{
+				MaxQuestions: entity.MaxEventQuestions,
+			}),
+			ShowAlert: true,
+		})
+	}
+
+	h.logger.Infof("(user: %d) add event registration question (club_id=%s, type=%s)", c.Sender().ID, clubID, questionType)
+
+	backMarkup := h.layout.Markup(c, "clubOwner:create_event:form:back", struct {
+		ID string
+	}{
+		ID: clubID,
+	})
+
+	inputCollector := collector.New()
+	_ = c.Edit(banner.ClubOwner.Caption(h.layout.Text(c, "input_event_question_text")), backMarkup)
+	inputCollector.Collect(c.Message())
+
+	type step struct {
+		promptKey string
+		errorKey  string
+		validator func(string, map[string]interface{}) bool
+		result    string
+	}
+	steps := []step{
+		{
+			promptKey: "input_event_question_text",
+			errorKey:  "invalid_event_question_text",
+			validator: validator.EventQuestionText,
+		},
+	}
+	if questionType == entity.EventQuestionTypeChoice {
+		steps = append(steps, step{
+			promptKey: "input_event_question_options",
+			errorKey:  "invalid_event_question_options",
+			validator: validator.EventQuestionOptions,
+		})
+	}
+
+	for i := range steps {
+		if i > 0 {
+			_ = inputCollector.Send(c, banner.ClubOwner.Caption(h.layout.Text(c, steps[i].promptKey)), backMarkup)
+		}
+
+		done := false
+		for !done {
+			response, errGet := h.input.Get(context.Background(), c.Sender().ID, 0)
+			if response.Message != nil {
+				inputCollector.Collect(response.Message)
+			}
+			switch {
+			case response.Canceled:
+				_ = inputCollector.Clear(c, collector.ClearOptions{IgnoreErrors: true, ExcludeLast: true})
+				return nil
+			case errGet != nil:
+				h.logger.Errorf("(user: %d) error while input event question: %v", c.Sender().ID, errGet)
+				_ = inputCollector.Send(c,
+					banner.ClubOwner.Caption(h.layout.Text(c, "input_error", h.layout.Text(c, steps[i].promptKey))),
+					backMarkup,
+				)
+			case response.Message == nil || !steps[i].validator(response.Message.Text, nil):
+				_ = inputCollector.Send(c,
+					banner.ClubOwner.Caption(h.layout.Text(c, steps[i].errorKey)),
+					backMarkup,
+				)
+			default:
+				steps[i].result = strings.TrimSpace(response.Message.Text)
+				_ = inputCollector.Clear(c, collector.ClearOptions{IgnoreErrors: true})
+				done = true
+			}
+		}
+	}
+
+	question := entity.EventQuestion{
+		Position: len(event.Questions),
+		Text:     steps[0].result,
+		Type:     questionType,
+		Required: true,
+	}
+	if questionType == entity.EventQuestionTypeChoice {
+		for _, option := range strings.Split(steps[1].result, "\n") {
+			if option = strings.TrimSpace(option); option != "" && !slices.Contains(question.Options, option) {
+				question.Options = append(question.Options, option)
+			}
+		}
+	}
+	event.Questions = append(event.Questions, question)
+	h.eventsStorage.Set(c.Sender().ID, event, 0)
+
+	caption, markup := h.eventFormMenu(c, clubID, event)
+	return c.Send(caption, markup)
+}
+
+func (h Handler) eventFormQuestion(c tele.Context) error {
+	data := strings.Split(c.Callback().Data, " ")
+	if len(data) != 2 {
+		return errorz.ErrInvalidCallbackData
+	}
+	clubID := data[0]
+	index, err := strconv.Atoi(data[1])
+	if err != nil {
+		return errorz.ErrInvalidCallbackData
+	}
+
+	event, err := h.eventsStorage.Get(c.Sender().ID)
+	if err != nil {
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:club:back", struct {
+				ID string
+			}{
+				ID: clubID,
+			}),
+		)
+	}
+	if index < 0 || index >= len(event.Questions) {
+		return errorz.ErrInvalidCallbackData
+	}
+
+	switch c.Callback().Unique {
+	case "cOwner_evFormDel":
+		h.logger.Infof("(user: %d) delete event registration question (club_id=%s, index=%d)", c.Sender().ID, clubID, index)
+		event.Questions = slices.Delete(event.Questions, index, index+1)
+		for i := range event.Questions {
+			event.Questions[i].Position = i
+		}
+	default:
+		event.Questions[index].Required = !event.Questions[index].Required
+	}
+	h.eventsStorage.Set(c.Sender().ID, event, 0)
+
+	caption, markup := h.eventFormMenu(c, clubID, event)
+	return c.Edit(caption, markup)
+}
+
+// eventFormMenu returns the caption and the markup of the registration form of the created event
+func (h Handler) eventFormMenu(c tele.Context, clubID string, event entity.Event) (interface{}, *tele.ReplyMarkup) {
+	markup := h.layout.Markup(c, "clubOwner:create_event:form", struct {
+		ID string
+	}{
+		ID: clubID,
+	})
+
+	type questionView struct {
+		Number   int
+		Text     string
+		TypeName string
+		Options  string
+		Required bool
+	}
+	questions := make([]questionView, 0, len(event.Questions))
+	var rows [][]tele.InlineButton
+	for i, question := range event.Questions {
+		view := questionView{
+			Number:   i + 1,
+			Text:     question.Text,
+			TypeName: h.layout.Text(c, "question_type_"+question.Type.String()),
+			Options:  strings.Join(question.Options, ", "),
+			Required: question.Required,
+		}
+		questions = append(questions, view)
+
+		payload := struct {
+			ID       string
+			Index    int
+			Number   int
+			Required bool
+		}{
+			ID:       clubID,
+			Index:    i,
+			Number:   view.Number,
+			Required: question.Required,
+		}
+		rows = append(rows, []tele.InlineButton{
+			*h.layout.Button(c, "clubOwner:create_event:form:required", payload).Inline(),
+			*h.layout.Button(c, "clubOwner:create_event:form:delete", payload).Inline(),
+		})
+	}
+
+	if len(event.Questions) < entity.MaxEventQuestions {
+		var addRow []tele.InlineButton
+		for _, questionType := range entity.EventQuestionTypes {
+			addRow = append(addRow, *h.layout.Button(c, "clubOwner:create_event:form:add", struct {
+				ID       string
+				Type     entity.EventQuestionType
+				TypeName string
+			}{
+				ID:       clubID,
+				Type:     questionType,
+				TypeName: h.layout.Text(c, "question_type_"+questionType.String()),
+			}).Inline())
+		}
+		rows = append(rows, addRow)
+	}
+	markup.InlineKeyboard = append(rows, markup.InlineKeyboard...)
+
+	return banner.ClubOwner.Caption(h.layout.Text(c, "event_form_text", struct {
+		Questions    []questionView
+		MaxQuestions int
+	}{
+		Questions:    questions,
+		MaxQuestions: entity.MaxEventQuestions,
+	})), markup
+}
@@ -0,0 +1,134 @@
+package clubowner
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+
+	tele "gopkg.in/telebot.v3"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/common/errorz"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/banner"
+)
+
+func (h Handler) eventReminders(c tele.Context) error {
+	data := strings.Split(c.Callback().Data, " ")
+	if len(data) != 2 {
+		return errorz.ErrInvalidCallbackData
+	}
+
+	eventID := data[0]
+	page := data[1]
+	h.logger.Infof("(user: %d) event reminders (event_id=%s)", c.Sender().ID, eventID)
+
+	event, err := h.eventService.Get(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+				ID   string
+				Page string
+			}{
+				ID:   eventID,
+				Page: page,
+			}),
+		)
+	}
+
+	caption, markup := h.eventRemindersMenu(c, event, page)
+	return c.Edit(caption, markup)
+}
+
+func (h Handler) eventRemindersMenu(c tele.Context, event *entity.Event, page string) (interface{}, *tele.ReplyMarkup) {
+	markup := c.Bot().NewMarkup()
+	var rows []tele.Row
+	for i, offset := range entity.ReminderOffsetOptions {
+		rows = append(rows, markup.Row(*h.layout.Button(c, "clubOwner:event:reminder", struct {
+			ID      string
+			Page    string
+			Index   int
+			Enabled bool
+			When    string
+		}{
+			ID:      event.ID,
+			Page:    page,
+			Index:   i,
+			Enabled: slices.Contains(event.Reminders(), offset),
+			When:    h.layout.Text(c, "reminder_offset", offset),
+		})))
+	}
+	rows = append(rows, markup.Row(*h.layout.Button(c, "clubOwner:event:settings:back", struct {
+		ID   string
+		Page string
+	}{
+		ID:   event.ID,
+		Page: page,
+	})))
+	markup.Inline(rows...)
+
+	return banner.ClubOwner.Caption(h.layout.Text(c, "event_reminders_text", struct {
+		Name    string
+		Default bool
+	}{
+		Name:    event.Name,
+		Default: len(event.ReminderOffsets) == 0,
+	})), markup
+}
+
+// toggleEventReminder turns the reminder on or off, the event keeps at least one reminder
+func (h Handler) toggleEventReminder(c tele.Context) error {
+	data := strings.Split(c.Callback().Data, " ")
+	if len(data) != 3 {
+		return errorz.ErrInvalidCallbackData
+	}
+	index, err := strconv.Atoi(data[2])
+	if err != nil || index < 0 || index >= len(entity.ReminderOffsetOptions) {
+		return errorz.ErrInvalidCallbackData
+	}
+
+	eventID := data[0]
+	page := data[1]
+	offset := entity.ReminderOffsetOptions[index]
+	h.logger.Infof("(user: %d) toggle event reminder (event_id=%s, offset=%dm)", c.Sender().ID, eventID, offset)
+
+	backMarkup := h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+		ID   string
+		Page string
+	}{
+		ID:   eventID,
+		Page: page,
+	})
+
+	event, err := h.eventService.Get(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			backMarkup,
+		)
+	}
+
+	reminders := event.Reminders().Toggle(offset)
+	if len(reminders) == 0 {
+		return c.Respond(&tele.CallbackResponse{
+			Text:      h.layout.Text(c, "reminder_required"),
+			ShowAlert: true,
+		})
+	}
+
+	event.ReminderOffsets = reminders
+	event, err = h.eventService.Update(context.Background(), event)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while update event reminders: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			backMarkup,
+		)
+	}
+
+	caption, markup := h.eventRemindersMenu(c, event, page)
+	return c.Edit(caption, markup)
+}
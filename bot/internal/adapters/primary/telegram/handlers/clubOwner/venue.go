@@ -0,0 +1,180 @@
+package clubowner
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/nlypage/intele/collector"
+	tele "gopkg.in/telebot.v3"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/common/errorz"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/banner"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/location"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/validator"
+)
+
+// findVenue returns the venue with the given id, nil if the location was entered as text
+func findVenue(venues []entity.Venue, id string) *entity.Venue {
+	for i := range venues {
+		if venues[i].ID == id {
+			return &venues[i]
+		}
+	}
+	return nil
+}
+
+// venueButtons returns a row for every venue, all buttons share the same unique,
+// so the input manager accepts any of them
+func (h Handler) venueButtons(c tele.Context, venues []entity.Venue) [][]tele.InlineButton {
+	if len(venues) == 0 {
+		return nil
+	}
+
+	rows := make([][]tele.InlineButton, 0, len(venues))
+	for _, venue := range venues {
+		rows = append(rows, []tele.InlineButton{*h.layout.Button(c, "clubOwner:event:venue", venue).Inline()})
+	}
+	return rows
+}
+
+// venueBookedText returns the text about events that have already booked the venue at the given time,
+// an empty string if the venue is free or the location is not a venue
+func (h Handler) venueBookedText(c tele.Context, venue *entity.Venue, startTimeStr, endTimeStr string) string {
+	const timeLayout = "02.01.2006 15:04"
+
+	if venue == nil {
+		return ""
+	}
+
+	event := entity.Event{VenueID: &venue.ID}
+	startTime, _ := time.ParseInLocation(timeLayout, startTimeStr, location.Location())
+	event.StartTime = startTime.UTC()
+	if endTime, err := time.ParseInLocation(timeLayout, endTimeStr, location.Location()); err == nil {
+		event.EndTime = endTime.UTC()
+	}
+
+	bookings, err := h.eventService.GetVenueBookings(context.Background(), event)
+	if err != nil {
+		// Площадка всё равно проверяется при сохранении мероприятия
+		h.logger.Errorf("(user: %d) error while get venue bookings: %v", c.Sender().ID, err)
+		return ""
+	}
+	if len(bookings) == 0 {
+		return ""
+	}
+
+	type booking struct {
+		Name      string
+		StartTime string
+		EndTime   string
+	}
+	events := make([]booking, 0, len(bookings))
+	for _, e := range bookings {
+		events = append(events, booking{
+			Name:      e.Name,
+			StartTime: e.StartTime.In(location.Location()).Format(timeLayout),
+			EndTime:   e.End().In(location.Location()).Format("15:04"),
+		})
+	}
+
+	return h.layout.Text(c, "venue_booked", struct {
+		Venue  string
+		Events []booking
+	}{
+		Venue:  venue.Name,
+		Events: events,
+	})
+}
+
+// eventSaveErrorText explains venue errors to the club owner, other errors are shown as technical issues
+func (h Handler) eventSaveErrorText(c tele.Context, err error) string {
+	switch {
+	case errors.Is(err, errorz.ErrVenueBooked):
+		return h.layout.Text(c, "venue_booked_error")
+	case errors.Is(err, errorz.ErrVenueCapacityExceeded):
+		return h.layout.Text(c, "venue_capacity_exceeded")
+	default:
+		return h.layout.Text(c, "technical_issues", err.Error())
+	}
+}
+
+// inputEventLocation asks the club owner to pick a venue or to enter the location as text.
+// Returns the venue if it was picked, false if the input was canceled
+func (h Handler) inputEventLocation(
+	c tele.Context,
+	inputCollector *collector.MessageCollector,
+	eventID, page string,
+) (string, *entity.Venue, bool) {
+	venues, err := h.venueService.GetAll(context.Background())
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get venues: %v", c.Sender().ID, err)
+	}
+
+	markup := h.layout.Markup(c, "clubOwner:event:settings:back", struct {
+		ID   string
+		Page string
+	}{
+		ID:   eventID,
+		Page: page,
+	})
+	var venueBtn *tele.Btn
+	if len(venues) > 0 {
+		venueBtn = h.layout.Button(c, "clubOwner:event:venue", venues[0])
+		markup.InlineKeyboard = append(h.venueButtons(c, venues), markup.InlineKeyboard...)
+	}
+	prompt := struct {
+		HasVenues bool
+	}{
+		HasVenues: len(venues) > 0,
+	}
+
+	_ = c.Edit(
+		banner.ClubOwner.Caption(h.layout.Text(c, "input_event_location", prompt)),
+		markup,
+	)
+	inputCollector.Collect(c.Message())
+
+	for {
+		response, errGet := h.input.Get(context.Background(), c.Sender().ID, 0, venueBtn)
+		if response.Message != nil {
+			inputCollector.Collect(response.Message)
+		}
+		switch {
+		case response.Canceled:
+			_ = inputCollector.Clear(c, collector.ClearOptions{IgnoreErrors: true, ExcludeLast: true})
+			return "", nil, false
+		case errGet != nil:
+			h.logger.Errorf("(user: %d) error while input event location: %v", c.Sender().ID, errGet)
+			_ = inputCollector.Send(c,
+				banner.ClubOwner.Caption(h.layout.Text(c, "input_error", h.layout.Text(c, "input_event_location", prompt))),
+				markup,
+			)
+		case response.Callback != nil:
+			venue := findVenue(venues, response.Callback.Data)
+			if venue == nil {
+				_ = inputCollector.Send(c,
+					banner.ClubOwner.Caption(h.layout.Text(c, "input_error", h.layout.Text(c, "input_event_location", prompt))),
+					markup,
+				)
+				continue
+			}
+			_ = inputCollector.Clear(c, collector.ClearOptions{IgnoreErrors: true})
+			return venue.Location(), venue, true
+		case response.Message == nil:
+			_ = inputCollector.Send(c,
+				banner.ClubOwner.Caption(h.layout.Text(c, "input_error", h.layout.Text(c, "input_event_location", prompt))),
+				markup,
+			)
+		case !validator.EventLocation(response.Message.Text, nil):
+			_ = inputCollector.Send(c,
+				banner.ClubOwner.Caption(h.layout.Text(c, "invalid_event_location", prompt)),
+				markup,
+			)
+		default:
+			_ = inputCollector.Clear(c, collector.ClearOptions{IgnoreErrors: true})
+			return response.Message.Text, nil, true
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package registrationForm
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/nlypage/intele"
+	"github.com/nlypage/intele/collector"
+	tele "gopkg.in/telebot.v3"
+	"gopkg.in/telebot.v3/layout"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/banner"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/validator"
+	"github.com/Badsnus/cu-clubs-bot/bot/pkg/logger/types"
+)
+
+// Form asks the user the questions of the event registration form through the input manager
+type Form struct {
+	layout *layout.Layout
+	input  *intele.InputManager
+	logger *types.Logger
+}
+
+func New(lt *layout.Layout, input *intele.InputManager, logger *types.Logger) *Form {
+	return &Form{
+		layout: lt,
+		input:  input,
+		logger: logger,
+	}
+}
+
+// Ask asks the questions one by one in the message of the callback and returns the answers.
+//
+// The second result is false if the user left the form with the back button of backMarkup,
+// the back button must re-render the message itself.
+func (f *Form) Ask(c tele.Context, questions []entity.EventQuestion, backMarkup *tele.ReplyMarkup) ([]entity.EventAnswer, bool) {
+	answers := make([]entity.EventAnswer, 0, len(questions))
+	inputCollector := collector.New()
+
+	for i, question := range questions {
+		prompt := f.layout.Text(c, "registration_question", struct {
+			Number   int
+			Count    int
+			Text     string
+			Type     string
+			Required bool
+		}{
+			Number:   i + 1,
+			Count:    len(questions),
+			Text:     question.Text,
+			Type:     question.Type.String(),
+			Required: question.Required,
+		})
+		markup, endpoints := f.questionMarkup(c, question, backMarkup)
+		_ = c.Edit(banner.Events.Caption(prompt), markup)
+
+		var (
+			answer string
+			done   bool
+		)
+		for !done {
+			response, errGet := f.input.Get(context.Background(), c.Sender().ID, 0, endpoints...)
+			if response.Message != nil {
+				inputCollector.Collect(response.Message)
+			}
+			switch {
+			case response.Canceled:
+				_ = inputCollector.Clear(c, collector.ClearOptions{IgnoreErrors: true})
+				return nil, false
+			case errGet != nil:
+				f.logger.Errorf("(user: %d) error while input registration answer: %v", c.Sender().ID, errGet)
+				_ = inputCollector.Send(c,
+					f.layout.Text(c, "input_error", prompt),
+					f.layout.Markup(c, "core:hide"),
+				)
+			case response.Callback != nil && response.Callback.Unique == "reg_skip":
+				done = true
+			case response.Callback != nil:
+				choices := question.Choices()
+				index, err := strconv.Atoi(response.Callback.Data)
+				if err != nil || index < 0 || index >= len(choices) {
+					continue
+				}
+				answer = choices[index]
+				done = true
+			case question.Type != entity.EventQuestionTypeText:
+				_ = inputCollector.Send(c,
+					f.layout.Text(c, "input_should_be_callback"),
+					f.layout.Markup(c, "core:hide"),
+				)
+			case !validator.EventAnswer(response.Message.Text, nil):
+				_ = inputCollector.Send(c,
+					f.layout.Text(c, "invalid_registration_answer"),
+					f.layout.Markup(c, "core:hide"),
+				)
+			default:
+				answer = strings.TrimSpace(response.Message.Text)
+				done = true
+			}
+		}
+		_ = inputCollector.Clear(c, collector.ClearOptions{IgnoreErrors: true})
+
+		answers = append(answers, entity.EventAnswer{
+			QuestionID: question.ID,
+			Answer:     answer,
+		})
+	}
+
+	return answers, true
+}
+
+// questionMarkup returns the markup of the question and the buttons the input manager should wait for
+func (f *Form) questionMarkup(c tele.Context, question entity.EventQuestion, backMarkup *tele.ReplyMarkup) (*tele.ReplyMarkup, []tele.CallbackEndpoint) {
+	markup := &tele.ReplyMarkup{}
+	var endpoints []tele.CallbackEndpoint
+
+	for i, choice := range question.Choices() {
+		text := choice
+		if question.Type == entity.EventQuestionTypeYesNo {
+			text = f.layout.Text(c, "answer_"+choice)
+		}
+		btn := f.layout.Button(c, "registration_form:answer", struct {
+			Index int
+			Text  string
+		}{
+			Index: i,
+			Text:  text,
+		})
+		markup.InlineKeyboard = append(markup.InlineKeyboard, []tele.InlineButton{*btn.Inline()})
+		if i == 0 {
+			endpoints = append(endpoints, btn)
+		}
+	}
+
+	if !question.Required {
+		btn := f.layout.Button(c, "registration_form:skip")
+		markup.InlineKeyboard = append(markup.InlineKeyboard, []tele.InlineButton{*btn.Inline()})
+		endpoints = append(endpoints, btn)
+	}
+
+	markup.InlineKeyboard = append(markup.InlineKeyboard, backMarkup.InlineKeyboard...)
+	return markup, endpoints
+}
@@ -3,17 +3,16 @@ package start
 import (
 	"context"
 	"errors"
-	"time"
 
 	"gorm.io/gorm"
 
-	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/banner"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/location"
 
 	"github.com/redis/go-redis/v9"
 	tele "gopkg.in/telebot.v3"
 
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/dto"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/valueobject"
 )
@@ -104,6 +103,12 @@ func (h Handler) auth(c tele.Context, authCode string) error {
 			h.layout.Markup(c, "mainMenu:back"),
 		)
 	}
+	if event.IsDraft() {
+		return c.Send(
+			banner.Events.Caption(h.layout.Text(c, "event_not_published")),
+			h.layout.Markup(c, "mainMenu:back"),
+		)
+	}
 
 	club, err := h.clubService.Get(context.Background(), event.ClubID)
 	if err != nil {
@@ -123,8 +128,17 @@ func (h Handler) auth(c tele.Context, authCode string) error {
 		)
 	}
 
-	var registered bool
-	_, errGetParticipant := h.eventParticipantService.Get(context.Background(), eventID, c.Sender().ID)
+	roleSeats, err := h.eventParticipantService.GetSeatsByRole(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get seats by role: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "mainMenu:back"),
+		)
+	}
+
+	var registered, pending bool
+	participant, errGetParticipant := h.eventParticipantService.Get(context.Background(), eventID, c.Sender().ID)
 	if errGetParticipant != nil {
 		if !errors.Is(errGetParticipant, gorm.ErrRecordNotFound) {
 			h.logger.Errorf("(user: %d) error while get participant: %v", c.Sender().ID, errGetParticipant)
@@ -135,6 +149,7 @@ func (h Handler) auth(c tele.Context, authCode string) error {
 		}
 	} else {
 		registered = true
+		pending = participant.IsPending()
 	}
 
 	endTime := event.EndTime.In(location.Location()).Format("02.01.2006 15:04")
@@ -142,16 +157,7 @@ func (h Handler) auth(c tele.Context, authCode string) error {
 		endTime = ""
 	}
 
-	var maxRegistrationEnd time.Time
-	if user.Role == valueobject.Student {
-		maxRegistrationEnd = event.RegistrationEnd
-	} else {
-		if event.RegistrationEnd.Before(utils.GetMaxRegisteredEndTime(event.StartTime)) {
-			maxRegistrationEnd = event.RegistrationEnd
-		} else {
-			maxRegistrationEnd = utils.GetMaxRegisteredEndTime(event.StartTime)
-		}
-	}
+	maxRegistrationEnd := h.eventService.RegistrationDeadline(event, user.Role)
 
 	_ = c.Send(
 		banner.Events.Caption(h.layout.Text(c, "event_text", struct {
@@ -164,8 +170,12 @@ func (h Handler) auth(c tele.Context, authCode string) error {
 			RegistrationEnd       string
 			MaxParticipants       int
 			ParticipantsCount     int
+			RoleSeats             []dto.RoleSeats
 			AfterRegistrationText string
 			IsRegistered          bool
+			IsPending             bool
+			MeetingURL            string
+			WaitlistPosition      int
 		}{
 			Name:                  event.Name,
 			ClubName:              club.Name,
@@ -176,8 +186,11 @@ func (h Handler) auth(c tele.Context, authCode string) error {
 			RegistrationEnd:       maxRegistrationEnd.In(location.Location()).Format("02.01.2006 15:04"),
 			MaxParticipants:       event.MaxParticipants,
 			ParticipantsCount:     participantsCount,
+			RoleSeats:             roleSeats,
 			AfterRegistrationText: event.AfterRegistrationText,
+			MeetingURL:            event.MeetingURL,
 			IsRegistered:          registered,
+			IsPending:             pending,
 		})),
 		h.layout.Markup(c, "user:url:event", struct {
 			ID           string
@@ -0,0 +1,107 @@
+package start
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tele "gopkg.in/telebot.v3"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/common/errorz"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/dto"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/banner"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/location"
+)
+
+// conflictCallbackTTL - lifetime of the "cancel the other registration" buttons
+const conflictCallbackTTL = 30 * time.Minute
+
+// urlEventConflicts warns the user that the event overlaps their other registrations
+func (h Handler) urlEventConflicts(c tele.Context, event *entity.Event, conflicts []dto.UserEvent) error {
+	h.logger.Infof("(user: %d) registration by url conflicts with %d events (event_id=%s)", c.Sender().ID, len(conflicts), event.ID)
+
+	type conflict struct {
+		Name      string
+		StartTime string
+		EndTime   string
+	}
+
+	btnData := struct {
+		ID string
+	}{
+		ID: event.ID,
+	}
+
+	markup := c.Bot().NewMarkup()
+	rows := []tele.Row{markup.Row(*h.layout.Button(c, "user:url:event:register_anyway", btnData))}
+	items := make([]conflict, 0, len(conflicts))
+	for _, other := range conflicts {
+		items = append(items, conflict{
+			Name:      other.Name,
+			StartTime: other.StartTime.In(location.Location()).Format("02.01.2006 15:04"),
+			EndTime:   other.End().In(location.Location()).Format("15:04"),
+		})
+
+		callbackID, err := h.callbacksStorage.Set(fmt.Sprintf("%s %s", event.ID, other.ID), conflictCallbackTTL)
+		if err != nil {
+			h.logger.Errorf("(user: %d) error while saving callback to redis: %v", c.Sender().ID, err)
+			return c.Edit(
+				banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+				h.layout.Markup(c, "user:url:event:form", btnData),
+			)
+		}
+		rows = append(rows, markup.Row(*h.layout.Button(c, "user:url:event:cancel_conflict", struct {
+			CallbackID string
+			Name       string
+		}{
+			CallbackID: callbackID,
+			Name:       other.Name,
+		})))
+	}
+	rows = append(rows, markup.Row(*h.layout.Button(c, "user:url:event:form:back", btnData)))
+	markup.Inline(rows...)
+
+	return c.Edit(
+		banner.Events.Caption(h.layout.Text(c, "event_conflicts_text", struct {
+			Name      string
+			Conflicts []conflict
+		}{
+			Name:      event.Name,
+			Conflicts: items,
+		})),
+		markup,
+	)
+}
+
+// urlEventCancelConflict cancels the overlapping registration and continues the registration for the event
+func (h Handler) urlEventCancelConflict(c tele.Context) error {
+	callbackData, err := h.callbacksStorage.Get(c.Callback().Data)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while getting callback from redis: %v", c.Sender().ID, err)
+		return c.Respond(&tele.CallbackResponse{
+			Text:      h.layout.Text(c, "event_conflict_expired"),
+			ShowAlert: true,
+		})
+	}
+
+	data := strings.Split(callbackData, " ")
+	if len(data) != 2 {
+		return errorz.ErrInvalidCallbackData
+	}
+	eventID, otherEventID := data[0], data[1]
+	h.logger.Infof("(user: %d) cancel conflicting registration (event_id=%s, other_event_id=%s)", c.Sender().ID, eventID, otherEventID)
+
+	err = h.eventParticipantService.Delete(context.Background(), otherEventID, c.Sender().ID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while delete event participant: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "mainMenu:back"),
+		)
+	}
+	h.callbacksStorage.Delete(c.Callback().Data)
+
+	return h.showURLEvent(c, eventID, true, true)
+}
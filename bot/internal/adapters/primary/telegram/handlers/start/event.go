@@ -8,11 +8,12 @@ import (
 	tele "gopkg.in/telebot.v3"
 	"gorm.io/gorm"
 
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/adapters/primary/telegram/handlers/registrationForm"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/common/errorz"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/dto"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
-	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/banner"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/location"
-	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/valueobject"
 )
 
 func (h Handler) eventMenu(c tele.Context, eventID string) error {
@@ -43,6 +44,12 @@ func (h Handler) eventMenu(c tele.Context, eventID string) error {
 			h.layout.Markup(c, "mainMenu:back"),
 		)
 	}
+	if event.IsDraft() {
+		return c.Send(
+			banner.Events.Caption(h.layout.Text(c, "event_not_published")),
+			h.layout.Markup(c, "mainMenu:back"),
+		)
+	}
 
 	club, err := h.clubService.Get(context.Background(), event.ClubID)
 	if err != nil {
@@ -62,8 +69,17 @@ func (h Handler) eventMenu(c tele.Context, eventID string) error {
 		)
 	}
 
-	var registered bool
-	_, errGetParticipant := h.eventParticipantService.Get(context.Background(), eventID, c.Sender().ID)
+	roleSeats, err := h.eventParticipantService.GetSeatsByRole(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get seats by role: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "mainMenu:back"),
+		)
+	}
+
+	var registered, pending bool
+	participant, errGetParticipant := h.eventParticipantService.Get(context.Background(), eventID, c.Sender().ID)
 	if errGetParticipant != nil {
 		if !errors.Is(errGetParticipant, gorm.ErrRecordNotFound) {
 			h.logger.Errorf("(user: %d) error while get participant: %v", c.Sender().ID, errGetParticipant)
@@ -74,6 +90,16 @@ func (h Handler) eventMenu(c tele.Context, eventID string) error {
 		}
 	} else {
 		registered = true
+		pending = participant.IsPending()
+	}
+
+	waitlistPosition, err := h.eventParticipantService.GetWaitlistPosition(context.Background(), eventID, c.Sender().ID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get waitlist position: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "mainMenu:back"),
+		)
 	}
 
 	endTime := event.EndTime.In(location.Location()).Format("02.01.2006 15:04")
@@ -81,25 +107,22 @@ func (h Handler) eventMenu(c tele.Context, eventID string) error {
 		endTime = ""
 	}
 
-	var maxRegistrationEnd time.Time
-	if user.Role == valueobject.Student {
-		maxRegistrationEnd = event.RegistrationEnd
-	} else {
-		if event.RegistrationEnd.Before(utils.GetMaxRegisteredEndTime(event.StartTime)) {
-			maxRegistrationEnd = event.RegistrationEnd
-		} else {
-			maxRegistrationEnd = utils.GetMaxRegisteredEndTime(event.StartTime)
-		}
-	}
+	maxRegistrationEnd := h.eventService.RegistrationDeadline(event, user.Role)
 
-	var registrationActive bool
-	if user.Role == valueobject.Student {
-		registrationActive = event.RegistrationEnd.After(time.Now().In(location.Location()))
-	} else {
-		registrationActive = utils.GetMaxRegisteredEndTime(event.StartTime).After(time.Now().In(location.Location())) && event.RegistrationEnd.After(time.Now().In(location.Location()))
+	if event.IsCancelled() {
+		return c.Send(
+			banner.Events.Caption(h.layout.Text(c, "event_cancelled_info", struct {
+				Name   string
+				Reason string
+			}{
+				Name:   event.Name,
+				Reason: event.CancelReason,
+			})),
+			h.layout.Markup(c, "mainMenu:back"),
+		)
 	}
 
-	if !registrationActive && !registered {
+	if !h.eventService.IsRegistrationOpen(event, user.Role, time.Now()) && !registered {
 		return c.Send(
 			banner.Events.Caption(h.layout.Text(c, "registration_ended")),
 			h.layout.Markup(c, "mainMenu:back"),
@@ -117,8 +140,12 @@ func (h Handler) eventMenu(c tele.Context, eventID string) error {
 			RegistrationEnd       string
 			MaxParticipants       int
 			ParticipantsCount     int
+			RoleSeats             []dto.RoleSeats
 			AfterRegistrationText string
 			IsRegistered          bool
+			IsPending             bool
+			MeetingURL            string
+			WaitlistPosition      int
 		}{
 			Name:                  event.Name,
 			ClubName:              club.Name,
@@ -129,8 +156,12 @@ func (h Handler) eventMenu(c tele.Context, eventID string) error {
 			RegistrationEnd:       maxRegistrationEnd.In(location.Location()).Format("02.01.2006 15:04"),
 			MaxParticipants:       event.MaxParticipants,
 			ParticipantsCount:     participantsCount,
+			RoleSeats:             roleSeats,
 			AfterRegistrationText: event.AfterRegistrationText,
+			MeetingURL:            event.MeetingURL,
 			IsRegistered:          registered,
+			IsPending:             pending,
+			WaitlistPosition:      waitlistPosition,
 		})),
 		h.layout.Markup(c, "user:url:event", struct {
 			ID           string
@@ -145,7 +176,14 @@ func (h Handler) eventMenu(c tele.Context, eventID string) error {
 }
 
 func (h Handler) eventRegister(c tele.Context) error {
-	eventID := c.Callback().Data
+	unique := c.Callback().Unique
+	register := unique == "user_url_event_reg" || unique == "user_url_event_reg_any"
+	return h.showURLEvent(c, c.Callback().Data, register, unique == "user_url_event_reg")
+}
+
+// showURLEvent shows the event opened by url, if register is true the user is registered first.
+// With checkConflicts the user is warned about registrations that overlap the event instead of registering
+func (h Handler) showURLEvent(c tele.Context, eventID string, register, checkConflicts bool) error {
 	h.logger.Infof("(user: %d) register to event by url (event_id=%s)", c.Sender().ID, eventID)
 
 	event, err := h.eventService.Get(context.Background(), eventID)
@@ -156,6 +194,12 @@ func (h Handler) eventRegister(c tele.Context) error {
 			h.layout.Markup(c, "mainMenu:back"),
 		)
 	}
+	if event.IsDraft() {
+		return c.Edit(
+			banner.Events.Caption(h.layout.Text(c, "event_not_published")),
+			h.layout.Markup(c, "mainMenu:back"),
+		)
+	}
 
 	club, err := h.clubService.Get(context.Background(), event.ClubID)
 	if err != nil {
@@ -166,8 +210,17 @@ func (h Handler) eventRegister(c tele.Context) error {
 		)
 	}
 
-	var registered bool
-	_, errGetParticipant := h.eventParticipantService.Get(context.Background(), eventID, c.Sender().ID)
+	user, err := h.userService.Get(context.Background(), c.Sender().ID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get user: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "mainMenu:back"),
+		)
+	}
+
+	var registered, pending bool
+	participant, errGetParticipant := h.eventParticipantService.Get(context.Background(), eventID, c.Sender().ID)
 	if errGetParticipant != nil {
 		if !errors.Is(errGetParticipant, gorm.ErrRecordNotFound) {
 			h.logger.Errorf("(user: %d) error while get participant: %v", c.Sender().ID, errGetParticipant)
@@ -178,6 +231,101 @@ func (h Handler) eventRegister(c tele.Context) error {
 		}
 	} else {
 		registered = true
+		pending = participant.IsPending()
+	}
+
+	var justRegistered bool
+	if register && !registered {
+		if checkConflicts {
+			conflicts, errConflicts := h.eventParticipantService.GetConflicts(context.Background(), eventID, c.Sender().ID)
+			if errConflicts != nil {
+				h.logger.Errorf("(user: %d) error while get conflicting events: %v", c.Sender().ID, errConflicts)
+				return c.Edit(
+					banner.Events.Caption(h.layout.Text(c, "technical_issues", errConflicts.Error())),
+					h.layout.Markup(c, "mainMenu:back"),
+				)
+			}
+			if len(conflicts) > 0 {
+				return h.urlEventConflicts(c, event, conflicts)
+			}
+		}
+
+		var questions []entity.EventQuestion
+		questions, err = h.eventParticipantService.GetQuestions(context.Background(), eventID)
+		if err != nil {
+			h.logger.Errorf("(user: %d) error while get event registration form: %v", c.Sender().ID, err)
+			return c.Edit(
+				banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+				h.layout.Markup(c, "mainMenu:back"),
+			)
+		}
+
+		var answers []entity.EventAnswer
+		if len(questions) > 0 {
+			var answered bool
+			answers, answered = registrationForm.New(h.layout, h.input, h.logger).Ask(c, questions, h.layout.Markup(c, "user:url:event:form", struct {
+				ID string
+			}{
+				ID: eventID,
+			}))
+			if !answered {
+				return nil
+			}
+		}
+
+		participant, err = h.eventParticipantService.Register(context.Background(), eventID, c.Sender().ID, answers)
+		switch {
+		case errors.Is(err, errorz.ErrInvalidAnswer):
+			return c.Respond(&tele.CallbackResponse{
+				Text:      h.layout.Text(c, "invalid_registration_form"),
+				ShowAlert: true,
+			})
+		case errors.Is(err, errorz.ErrEventCancelled):
+			return c.Respond(&tele.CallbackResponse{
+				Text:      h.layout.Text(c, "event_cancelled_alert"),
+				ShowAlert: true,
+			})
+		case errors.Is(err, errorz.ErrEventDraft):
+			return c.Respond(&tele.CallbackResponse{
+				Text:      h.layout.Text(c, "event_draft_alert"),
+				ShowAlert: true,
+			})
+		case errors.Is(err, errorz.ErrRegistrationClosed):
+			return c.Respond(&tele.CallbackResponse{
+				Text:      h.layout.Text(c, "registration_ended"),
+				ShowAlert: true,
+			})
+		case errors.Is(err, errorz.ErrRegistrationBlocked):
+			return c.Respond(&tele.CallbackResponse{
+				Text:      h.layout.Text(c, "registration_blocked"),
+				ShowAlert: true,
+			})
+		case errors.Is(err, errorz.ErrRoleQuotaFull):
+			return c.Respond(&tele.CallbackResponse{
+				Text:      h.layout.Text(c, "role_quota_reached"),
+				ShowAlert: true,
+			})
+		case errors.Is(err, errorz.ErrEventFull):
+			return c.Respond(&tele.CallbackResponse{
+				Text:      h.layout.Text(c, "max_participants_reached"),
+				ShowAlert: true,
+			})
+		case errors.Is(err, errorz.ErrRoleNotAllowed):
+			return c.Respond(&tele.CallbackResponse{
+				Text:      h.layout.Text(c, "not_allowed_role"),
+				ShowAlert: true,
+			})
+		case err != nil:
+			h.logger.Errorf("(user: %d) error while register to event: %v", c.Sender().ID, err)
+			return c.Edit(
+				banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+				h.layout.Markup(c, "mainMenu:back"),
+			)
+		}
+
+		registered = true
+		pending = participant.IsPending()
+		justRegistered = true
 	}
 
 	participantsCount, err := h.eventParticipantService.CountByEventID(context.Background(), eventID)
@@ -189,93 +337,45 @@ func (h Handler) eventRegister(c tele.Context) error {
 		)
 	}
 
-	if c.Callback().Unique == "user_url_event_reg" {
-		if !registered {
-			var user *entity.User
-			user, err = h.userService.Get(context.Background(), c.Sender().ID)
-			if err != nil {
-				h.logger.Errorf("(user: %d) error while get user: %v", c.Sender().ID, err)
-				return c.Edit(
-					banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
-					h.layout.Markup(c, "mainMenu:back"),
-				)
-			}
-
-			var roleAllowed bool
-			var registrationActive bool
-			for _, role := range event.AllowedRoles {
-				if role == string(user.Role) {
-					roleAllowed = true
-				}
-			}
+	roleSeats, err := h.eventParticipantService.GetSeatsByRole(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get seats by role: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "mainMenu:back"),
+		)
+	}
 
-			if user.Role == valueobject.Student {
-				registrationActive = event.RegistrationEnd.After(time.Now().In(location.Location()))
-			} else {
-				registrationActive = utils.GetMaxRegisteredEndTime(event.StartTime).After(time.Now().In(location.Location())) && event.RegistrationEnd.After(time.Now().In(location.Location()))
+	if justRegistered {
+		if participantsCount == event.ExpectedParticipants {
+			errSendWarning := h.notificationService.SendClubWarning(event.ClubID,
+				h.layout.Text(c, "expected_participants_reached_warning", struct {
+					Name              string
+					ParticipantsCount int
+				}{
+					Name:              event.Name,
+					ParticipantsCount: participantsCount,
+				}),
+				h.layout.Markup(c, "core:hide"),
+			)
+			if errSendWarning != nil {
+				h.logger.Errorf("(user: %d) error while send expected participants reached warning: %v", c.Sender().ID, errSendWarning)
 			}
+		}
 
-			if (event.MaxParticipants == 0 || participantsCount < event.MaxParticipants) && registrationActive && roleAllowed {
-				_, err = h.eventParticipantService.Register(context.Background(), eventID, c.Sender().ID)
-				if err != nil {
-					h.logger.Errorf("(user: %d) error while register to event: %v", c.Sender().ID, err)
-					return c.Edit(
-						banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
-						h.layout.Markup(c, "mainMenu:back"),
-					)
-				}
-
-				if participantsCount+1 == event.ExpectedParticipants {
-					errSendWarning := h.notificationService.SendClubWarning(event.ClubID,
-						h.layout.Text(c, "expected_participants_reached_warning", struct {
-							Name              string
-							ParticipantsCount int
-						}{
-							Name:              event.Name,
-							ParticipantsCount: participantsCount + 1,
-						}),
-						h.layout.Markup(c, "core:hide"),
-					)
-					if errSendWarning != nil {
-						h.logger.Errorf("(user: %d) error while send expected participants reached warning: %v", c.Sender().ID, errSendWarning)
-					}
-				}
-
-				if participantsCount+1 == event.MaxParticipants {
-					errSendWarning := h.notificationService.SendClubWarning(event.ClubID,
-						h.layout.Text(c, "max_participants_reached_warning", struct {
-							Name              string
-							ParticipantsCount int
-						}{
-							Name:              event.Name,
-							ParticipantsCount: participantsCount + 1,
-						}),
-						h.layout.Markup(c, "core:hide"),
-					)
-					if errSendWarning != nil {
-						h.logger.Errorf("(user: %d) error while send expected participants reached warning: %v", c.Sender().ID, errSendWarning)
-					}
-				}
-
-				registered = true
-			} else {
-				switch {
-				case event.RegistrationEnd.Before(time.Now().In(location.Location())):
-					return c.Respond(&tele.CallbackResponse{
-						Text:      h.layout.Text(c, "registration_ended"),
-						ShowAlert: true,
-					})
-				case event.MaxParticipants > 0 && participantsCount >= event.MaxParticipants:
-					return c.Respond(&tele.CallbackResponse{
-						Text:      h.layout.Text(c, "max_participants_reached"),
-						ShowAlert: true,
-					})
-				case !roleAllowed:
-					return c.Respond(&tele.CallbackResponse{
-						Text:      h.layout.Text(c, "not_allowed_role"),
-						ShowAlert: true,
-					})
-				}
+		if participantsCount == event.MaxParticipants {
+			errSendWarning := h.notificationService.SendClubWarning(event.ClubID,
+				h.layout.Text(c, "max_participants_reached_warning", struct {
+					Name              string
+					ParticipantsCount int
+				}{
+					Name:              event.Name,
+					ParticipantsCount: participantsCount,
+				}),
+				h.layout.Markup(c, "core:hide"),
+			)
+			if errSendWarning != nil {
+				h.logger.Errorf("(user: %d) error while send expected participants reached warning: %v", c.Sender().ID, errSendWarning)
 			}
 		}
 	}
@@ -295,8 +395,13 @@ func (h Handler) eventRegister(c tele.Context) error {
 			EndTime               string
 			RegistrationEnd       string
 			MaxParticipants       int
+			ParticipantsCount     int
+			RoleSeats             []dto.RoleSeats
 			AfterRegistrationText string
 			IsRegistered          bool
+			IsPending             bool
+			MeetingURL            string
+			WaitlistPosition      int
 		}{
 			Name:                  event.Name,
 			ClubName:              club.Name,
@@ -304,10 +409,14 @@ func (h Handler) eventRegister(c tele.Context) error {
 			Location:              event.Location,
 			StartTime:             event.StartTime.In(location.Location()).Format("02.01.2006 15:04"),
 			EndTime:               endTime,
-			RegistrationEnd:       event.RegistrationEnd.In(location.Location()).Format("02.01.2006 15:04"),
+			RegistrationEnd:       h.eventService.RegistrationDeadline(event, user.Role).In(location.Location()).Format("02.01.2006 15:04"),
 			MaxParticipants:       event.MaxParticipants,
+			ParticipantsCount:     participantsCount,
+			RoleSeats:             roleSeats,
 			AfterRegistrationText: event.AfterRegistrationText,
+			MeetingURL:            event.MeetingURL,
 			IsRegistered:          registered,
+			IsPending:             pending,
 		})),
 		h.layout.Markup(c, "user:url:event", struct {
 			ID           string
@@ -323,4 +432,7 @@ func (h Handler) eventRegister(c tele.Context) error {
 
 func (h Handler) SetupURLEvent(group *tele.Group) {
 	group.Handle(h.layout.Callback("user:url:event:register"), h.eventRegister)
+	group.Handle(h.layout.Callback("user:url:event:form:back"), h.eventRegister)
+	group.Handle(h.layout.Callback("user:url:event:register_anyway"), h.eventRegister)
+	group.Handle(h.layout.Callback("user:url:event:cancel_conflict"), h.urlEventCancelConflict)
 }
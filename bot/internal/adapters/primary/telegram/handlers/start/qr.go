@@ -10,6 +10,7 @@ import (
 	tele "gopkg.in/telebot.v3"
 	"gorm.io/gorm"
 
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/common/errorz"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/banner"
 )
 
@@ -205,13 +206,25 @@ func (h Handler) qrEventsList(c tele.Context) error {
 			h.logger.Errorf("(user: %d) error while setting callback: %v", c.Sender().ID, errSet)
 			continue
 		}
-		rows = append(rows, markup.Row(*h.layout.Button(c, "clubOwner:activateQR:event", struct {
-			CallbackID string
-			Name       string
-		}{
-			CallbackID: callbackID,
-			Name:       event.Name,
-		})))
+		exitCallbackID, errSet := h.callbacksStorage.Set(fmt.Sprintf("%s %s", event.ID, qrCodeID), time.Minute*5)
+		if errSet != nil {
+			h.logger.Errorf("(user: %d) error while setting callback: %v", c.Sender().ID, errSet)
+			continue
+		}
+		rows = append(rows, markup.Row(
+			*h.layout.Button(c, "clubOwner:activateQR:event", struct {
+				CallbackID string
+				Name       string
+			}{
+				CallbackID: callbackID,
+				Name:       event.Name,
+			}),
+			*h.layout.Button(c, "clubOwner:activateQR:event:exit", struct {
+				CallbackID string
+			}{
+				CallbackID: exitCallbackID,
+			}),
+		))
 	}
 
 	rows = append(
@@ -280,8 +293,33 @@ func (h Handler) activateUserQR(c tele.Context) error {
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			h.logger.Infof("(user: %d) participant not found (event_id=%s)", c.Sender().ID, eventID)
-			eventParticipant, err = h.eventParticipantService.Register(context.Background(), eventID, user.ID)
-			if err != nil {
+			eventParticipant, err = h.eventParticipantService.RegisterOnSite(context.Background(), eventID, user.ID)
+			switch {
+			case errors.Is(err, errorz.ErrEventCancelled):
+				h.logger.Infof("(user: %d) event is cancelled (event_id=%s)", c.Sender().ID, eventID)
+				return c.Edit(
+					banner.ClubOwner.Caption(h.layout.Text(c, "event_cancelled_alert")),
+					h.layout.Markup(c, "core:hide"),
+				)
+			case errors.Is(err, errorz.ErrEventDraft):
+				h.logger.Infof("(user: %d) event is not published (event_id=%s)", c.Sender().ID, eventID)
+				return c.Edit(
+					banner.ClubOwner.Caption(h.layout.Text(c, "event_not_published")),
+					h.layout.Markup(c, "core:hide"),
+				)
+			case errors.Is(err, errorz.ErrEventFull):
+				h.logger.Infof("(user: %d) event is full (event_id=%s)", c.Sender().ID, eventID)
+				return c.Edit(
+					banner.ClubOwner.Caption(h.layout.Text(c, "qr_event_full")),
+					h.layout.Markup(c, "core:hide"),
+				)
+			case errors.Is(err, errorz.ErrRoleNotAllowed):
+				h.logger.Infof("(user: %d) user role is not allowed (event_id=%s, user_id=%d)", c.Sender().ID, eventID, user.ID)
+				return c.Edit(
+					banner.ClubOwner.Caption(h.layout.Text(c, "qr_role_not_allowed")),
+					h.layout.Markup(c, "core:hide"),
+				)
+			case err != nil:
 				h.logger.Errorf("(user: %d) error while registering participant: %v", c.Sender().ID, err)
 				return c.Edit(
 					banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
@@ -307,7 +345,21 @@ func (h Handler) activateUserQR(c tele.Context) error {
 		)
 	}
 
+	// Владелец клуба сам впускает участника, поэтому неподтвержденная регистрация подтверждается
+	if eventParticipant.IsPending() {
+		eventParticipant, err = h.eventParticipantService.Approve(context.Background(), eventID, user.ID)
+		if err != nil {
+			h.logger.Errorf("(user: %d) error while approving event participant: %v", c.Sender().ID, err)
+			return c.Edit(
+				banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+				h.layout.Markup(c, "core:hide"),
+			)
+		}
+		h.logger.Infof("(user: %d) participant approved (event_id=%s, user_id=%d)", c.Sender().ID, eventID, user.ID)
+	}
+
 	eventParticipant.IsUserQr = true
+	eventParticipant.CheckIn(time.Now())
 	_, err = h.eventParticipantService.Update(context.Background(), eventParticipant)
 	if err != nil {
 		h.logger.Errorf("(user: %d) error while updating event participant: %v", c.Sender().ID, err)
@@ -331,10 +383,87 @@ func (h Handler) activateUserQR(c tele.Context) error {
 	)
 }
 
+// checkOutUserQR records the exit of the participant by the user QR code scanned by the club owner
+func (h Handler) checkOutUserQR(c tele.Context) error {
+	callbackData, err := h.callbacksStorage.Get(c.Callback().Data)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while getting callback from redis: %v", c.Sender().ID, err)
+		return c.Send(
+			h.layout.Text(c, "technical_issues", err.Error()),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+	h.callbacksStorage.Delete(c.Callback().Data)
+	eventID, qrCodeID := strings.Split(callbackData, " ")[0], strings.Split(callbackData, " ")[1]
+
+	h.logger.Infof("(user: %d) check out by user qr (event_id=%s, qr_id=%s)", c.Sender().ID, eventID, qrCodeID)
+	user, err := h.userService.GetByQRCodeID(context.Background(), qrCodeID)
+	if err != nil {
+		h.logger.Infof("(user: %d) qr expired: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "qr_expired")),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+
+	eventParticipant, err := h.eventParticipantService.Get(context.Background(), eventID, user.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		h.logger.Errorf("(user: %d) error while getting event participant from db: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+	if err != nil || !eventParticipant.CheckOut(time.Now()) {
+		h.logger.Infof("(user: %d) participant has not checked in (event_id=%s, user_id=%d)", c.Sender().ID, eventID, user.ID)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "qr_not_checked_in")),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+
+	err = h.qrService.RevokeUserQR(context.Background(), user.ID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while revoking user QR code: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+
+	_, err = h.eventParticipantService.Update(context.Background(), eventParticipant)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while updating event participant: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.ClubOwner.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+
+	h.logger.Infof("(user: %d) user checked out (event_id=%s, user_id=%d)", c.Sender().ID, eventID, user.ID)
+
+	duration := eventParticipant.AttendanceDuration()
+	return c.Edit(
+		banner.ClubOwner.Caption(h.layout.Text(c, "qr_checked_out", struct {
+			FIO      string
+			Username string
+			Hours    int
+			Minutes  int
+		}{
+			FIO:      user.FIO.String(),
+			Username: user.Username,
+			Hours:    int(duration.Hours()),
+			Minutes:  int(duration.Minutes()) % 60,
+		})),
+		h.layout.Markup(c, "core:hide"),
+	)
+}
+
 func (h Handler) SetupUserQR(group *tele.Group) {
 	group.Handle(h.layout.Callback("clubOwner:activateQR:clubs:back"), h.backToClubsList)
 	group.Handle(h.layout.Callback("clubOwner:activateQR:club"), h.qrEventsList)
 	group.Handle(h.layout.Callback("clubOwner:activateQR:event"), h.activateUserQR)
+	group.Handle(h.layout.Callback("clubOwner:activateQR:event:exit"), h.checkOutUserQR)
 }
 
 func (h Handler) eventQR(c tele.Context, qrCodeID string) error {
@@ -368,8 +497,33 @@ func (h Handler) eventQR(c tele.Context, qrCodeID string) error {
 			)
 		}
 		h.logger.Infof("(user: %d) participant not found (event_id=%s)", c.Sender().ID, event.ID)
-		eventParticipant, err = h.eventParticipantService.Register(context.Background(), event.ID, c.Sender().ID)
-		if err != nil {
+		eventParticipant, err = h.eventParticipantService.RegisterOnSite(context.Background(), event.ID, c.Sender().ID)
+		switch {
+		case errors.Is(err, errorz.ErrEventCancelled):
+			h.logger.Infof("(user: %d) event is cancelled (event_id=%s)", c.Sender().ID, event.ID)
+			return c.Send(
+				banner.Events.Caption(h.layout.Text(c, "event_cancelled_alert")),
+				h.layout.Markup(c, "core:hide"),
+			)
+		case errors.Is(err, errorz.ErrEventDraft):
+			h.logger.Infof("(user: %d) event is not published (event_id=%s)", c.Sender().ID, event.ID)
+			return c.Send(
+				banner.Events.Caption(h.layout.Text(c, "event_not_published")),
+				h.layout.Markup(c, "core:hide"),
+			)
+		case errors.Is(err, errorz.ErrEventFull):
+			h.logger.Infof("(user: %d) event is full (event_id=%s)", c.Sender().ID, event.ID)
+			return c.Send(
+				banner.Events.Caption(h.layout.Text(c, "qr_event_full")),
+				h.layout.Markup(c, "core:hide"),
+			)
+		case errors.Is(err, errorz.ErrRoleNotAllowed):
+			h.logger.Infof("(user: %d) role is not allowed (event_id=%s)", c.Sender().ID, event.ID)
+			return c.Send(
+				banner.Events.Caption(h.layout.Text(c, "not_allowed_role")),
+				h.layout.Markup(c, "core:hide"),
+			)
+		case err != nil:
 			h.logger.Errorf("(user: %d) error while registering participant: %v", c.Sender().ID, err)
 			return c.Edit(
 				banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
@@ -379,7 +533,16 @@ func (h Handler) eventQR(c tele.Context, qrCodeID string) error {
 		h.logger.Infof("(user: %d) participant registered (event_id=%s, user_id=%d)", c.Sender().ID, event.ID, c.Sender().ID)
 	}
 
+	if eventParticipant.IsPending() {
+		h.logger.Infof("(user: %d) registration is pending (event_id=%s)", c.Sender().ID, event.ID)
+		return c.Send(
+			banner.Events.Caption(h.layout.Text(c, "qr_registration_pending")),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+
 	eventParticipant.IsEventQr = true
+	eventParticipant.CheckIn(time.Now())
 	_, err = h.eventParticipantService.Update(context.Background(), eventParticipant)
 	if err != nil {
 		h.logger.Errorf("(user: %d) error while updating event participant: %v", c.Sender().ID, err)
@@ -399,3 +562,58 @@ func (h Handler) eventQR(c tele.Context, qrCodeID string) error {
 		h.layout.Markup(c, "core:hide"),
 	)
 }
+
+// eventExitQR records the exit of the participant who scanned the exit QR code of the event
+func (h Handler) eventExitQR(c tele.Context, qrCodeID string) error {
+	_ = c.Delete()
+	h.logger.Infof("(user: %d) scan event exit QR code", c.Sender().ID)
+
+	event, err := h.eventService.GetByExitQRCodeID(context.Background(), qrCodeID)
+	if err != nil {
+		h.logger.Infof("(user: %d) event exit qr expired: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.Events.Caption(h.layout.Text(c, "qr_expired")),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+
+	eventParticipant, err := h.eventParticipantService.Get(context.Background(), event.ID, c.Sender().ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		h.logger.Errorf("(user: %d) error while getting event participant from db: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+	if err != nil || !eventParticipant.CheckOut(time.Now()) {
+		h.logger.Infof("(user: %d) participant has not checked in (event_id=%s)", c.Sender().ID, event.ID)
+		return c.Send(
+			banner.Events.Caption(h.layout.Text(c, "event_not_checked_in")),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+
+	_, err = h.eventParticipantService.Update(context.Background(), eventParticipant)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while updating event participant: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+	h.logger.Infof("(user: %d) checked out by event qr (event_id=%s)", c.Sender().ID, event.ID)
+
+	duration := eventParticipant.AttendanceDuration()
+	return c.Send(
+		banner.Events.Caption(h.layout.Text(c, "event_qr_checked_out", struct {
+			Name    string
+			Hours   int
+			Minutes int
+		}{
+			Name:    event.Name,
+			Hours:   int(duration.Hours()),
+			Minutes: int(duration.Minutes()) % 60,
+		})),
+		h.layout.Markup(c, "core:hide"),
+	)
+}
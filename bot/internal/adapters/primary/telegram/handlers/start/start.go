@@ -146,6 +146,9 @@ func (h Handler) Start(c tele.Context) error {
 	case "eventQR":
 		return h.eventQR(c, data)
 
+	case "eventExitQR":
+		return h.eventExitQR(c, data)
+
 	case "event":
 		return h.eventMenu(c, data)
 
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"math"
-	"time"
 
 	"github.com/nlypage/intele/collector"
 	"github.com/redis/go-redis/v9"
@@ -13,8 +12,8 @@ import (
 
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/adapters/secondary/redis/codes"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/adapters/secondary/redis/emails"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/dto"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
-	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/banner"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/location"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/validator"
@@ -131,6 +130,12 @@ func (h Handler) externalUserAuth(c tele.Context) error {
 			h.layout.Markup(c, "mainMenu:back"),
 		)
 	}
+	if event.IsDraft() {
+		return c.Send(
+			banner.Events.Caption(h.layout.Text(c, "event_not_published")),
+			h.layout.Markup(c, "mainMenu:back"),
+		)
+	}
 
 	club, err := h.clubService.Get(context.Background(), event.ClubID)
 	if err != nil {
@@ -150,8 +155,17 @@ func (h Handler) externalUserAuth(c tele.Context) error {
 		)
 	}
 
-	var registered bool
-	_, errGetParticipant := h.eventParticipantService.Get(context.Background(), eventID, c.Sender().ID)
+	roleSeats, err := h.eventParticipantService.GetSeatsByRole(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get seats by role: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "mainMenu:back"),
+		)
+	}
+
+	var registered, pending bool
+	participant, errGetParticipant := h.eventParticipantService.Get(context.Background(), eventID, c.Sender().ID)
 	if errGetParticipant != nil {
 		if !errors.Is(errGetParticipant, gorm.ErrRecordNotFound) {
 			h.logger.Errorf("(user: %d) error while get participant: %v", c.Sender().ID, errGetParticipant)
@@ -162,6 +176,7 @@ func (h Handler) externalUserAuth(c tele.Context) error {
 		}
 	} else {
 		registered = true
+		pending = participant.IsPending()
 	}
 
 	endTime := event.EndTime.In(location.Location()).Format("02.01.2006 15:04")
@@ -169,16 +184,7 @@ func (h Handler) externalUserAuth(c tele.Context) error {
 		endTime = ""
 	}
 
-	var maxRegistrationEnd time.Time
-	if user.Role == valueobject.Student {
-		maxRegistrationEnd = event.RegistrationEnd
-	} else {
-		if event.RegistrationEnd.Before(utils.GetMaxRegisteredEndTime(event.StartTime)) {
-			maxRegistrationEnd = event.RegistrationEnd
-		} else {
-			maxRegistrationEnd = utils.GetMaxRegisteredEndTime(event.StartTime)
-		}
-	}
+	maxRegistrationEnd := h.eventService.RegistrationDeadline(event, user.Role)
 
 	_ = c.Send(
 		banner.Events.Caption(h.layout.Text(c, "event_text", struct {
@@ -191,8 +197,12 @@ func (h Handler) externalUserAuth(c tele.Context) error {
 			RegistrationEnd       string
 			MaxParticipants       int
 			ParticipantsCount     int
+			RoleSeats             []dto.RoleSeats
 			AfterRegistrationText string
 			IsRegistered          bool
+			IsPending             bool
+			MeetingURL            string
+			WaitlistPosition      int
 		}{
 			Name:                  event.Name,
 			ClubName:              club.Name,
@@ -203,8 +213,11 @@ func (h Handler) externalUserAuth(c tele.Context) error {
 			RegistrationEnd:       maxRegistrationEnd.In(location.Location()).Format("02.01.2006 15:04"),
 			MaxParticipants:       event.MaxParticipants,
 			ParticipantsCount:     participantsCount,
+			RoleSeats:             roleSeats,
 			AfterRegistrationText: event.AfterRegistrationText,
+			MeetingURL:            event.MeetingURL,
 			IsRegistered:          registered,
+			IsPending:             pending,
 		})),
 		h.layout.Markup(c, "user:url:event", struct {
 			ID           string
@@ -330,6 +343,12 @@ func (h Handler) grantUserAuth(c tele.Context) error {
 			h.layout.Markup(c, "mainMenu:back"),
 		)
 	}
+	if event.IsDraft() {
+		return c.Send(
+			banner.Events.Caption(h.layout.Text(c, "event_not_published")),
+			h.layout.Markup(c, "mainMenu:back"),
+		)
+	}
 
 	club, err := h.clubService.Get(context.Background(), event.ClubID)
 	if err != nil {
@@ -349,8 +368,17 @@ func (h Handler) grantUserAuth(c tele.Context) error {
 		)
 	}
 
-	var registered bool
-	_, errGetParticipant := h.eventParticipantService.Get(context.Background(), eventID, c.Sender().ID)
+	roleSeats, err := h.eventParticipantService.GetSeatsByRole(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get seats by role: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "mainMenu:back"),
+		)
+	}
+
+	var registered, pending bool
+	participant, errGetParticipant := h.eventParticipantService.Get(context.Background(), eventID, c.Sender().ID)
 	if errGetParticipant != nil {
 		if !errors.Is(errGetParticipant, gorm.ErrRecordNotFound) {
 			h.logger.Errorf("(user: %d) error while get participant: %v", c.Sender().ID, errGetParticipant)
@@ -361,6 +389,7 @@ func (h Handler) grantUserAuth(c tele.Context) error {
 		}
 	} else {
 		registered = true
+		pending = participant.IsPending()
 	}
 
 	endTime := event.EndTime.In(location.Location()).Format("02.01.2006 15:04")
@@ -368,16 +397,7 @@ func (h Handler) grantUserAuth(c tele.Context) error {
 		endTime = ""
 	}
 
-	var maxRegistrationEnd time.Time
-	if user.Role == valueobject.Student {
-		maxRegistrationEnd = event.RegistrationEnd
-	} else {
-		if event.RegistrationEnd.Before(utils.GetMaxRegisteredEndTime(event.StartTime)) {
-			maxRegistrationEnd = event.RegistrationEnd
-		} else {
-			maxRegistrationEnd = utils.GetMaxRegisteredEndTime(event.StartTime)
-		}
-	}
+	maxRegistrationEnd := h.eventService.RegistrationDeadline(event, user.Role)
 
 	_ = c.Send(
 		banner.Events.Caption(h.layout.Text(c, "event_text", struct {
@@ -390,8 +410,12 @@ func (h Handler) grantUserAuth(c tele.Context) error {
 			RegistrationEnd       string
 			MaxParticipants       int
 			ParticipantsCount     int
+			RoleSeats             []dto.RoleSeats
 			AfterRegistrationText string
 			IsRegistered          bool
+			IsPending             bool
+			MeetingURL            string
+			WaitlistPosition      int
 		}{
 			Name:                  event.Name,
 			ClubName:              club.Name,
@@ -402,8 +426,11 @@ func (h Handler) grantUserAuth(c tele.Context) error {
 			RegistrationEnd:       maxRegistrationEnd.In(location.Location()).Format("02.01.2006 15:04"),
 			MaxParticipants:       event.MaxParticipants,
 			ParticipantsCount:     participantsCount,
+			RoleSeats:             roleSeats,
 			AfterRegistrationText: event.AfterRegistrationText,
+			MeetingURL:            event.MeetingURL,
 			IsRegistered:          registered,
+			IsPending:             pending,
 		})),
 		h.layout.Markup(c, "user:url:event", struct {
 			ID           string
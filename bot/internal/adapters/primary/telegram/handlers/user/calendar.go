@@ -0,0 +1,69 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	tele "gopkg.in/telebot.v3"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/banner"
+)
+
+func (h Handler) calendarFeed(c tele.Context) error {
+	h.logger.Infof("(user: %d) get calendar feed", c.Sender().ID)
+
+	if h.httpPublicURL == "" {
+		return c.Edit(
+			banner.PersonalAccount.Caption(h.layout.Text(c, "calendar_feed_unavailable")),
+			h.layout.Markup(c, "personalAccount:back"),
+		)
+	}
+
+	token, err := h.userService.GetCalendarToken(context.Background(), c.Sender().ID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while getting calendar token: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.PersonalAccount.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "personalAccount:back"),
+		)
+	}
+
+	return c.Edit(
+		banner.PersonalAccount.Caption(h.layout.Text(c, "calendar_feed_text", struct {
+			URL     string
+			Rotated bool
+		}{
+			URL: h.calendarFeedURL(token),
+		})),
+		h.layout.Markup(c, "personalAccount:calendar"),
+	)
+}
+
+// rotateCalendarFeed replaces the feed URL, e.g. if the user has shared it by mistake
+func (h Handler) rotateCalendarFeed(c tele.Context) error {
+	h.logger.Infof("(user: %d) rotate calendar feed", c.Sender().ID)
+
+	token, err := h.userService.RotateCalendarToken(context.Background(), c.Sender().ID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while rotating calendar token: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.PersonalAccount.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "personalAccount:back"),
+		)
+	}
+
+	return c.Edit(
+		banner.PersonalAccount.Caption(h.layout.Text(c, "calendar_feed_text", struct {
+			URL     string
+			Rotated bool
+		}{
+			URL:     h.calendarFeedURL(token),
+			Rotated: true,
+		})),
+		h.layout.Markup(c, "personalAccount:calendar"),
+	)
+}
+
+func (h Handler) calendarFeedURL(token string) string {
+	return fmt.Sprintf("%s/calendar/%s.ics", h.httpPublicURL, token)
+}
@@ -0,0 +1,115 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tele "gopkg.in/telebot.v3"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/common/errorz"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/dto"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/banner"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/location"
+)
+
+// conflictCallbackTTL - lifetime of the "cancel the other registration" buttons
+const conflictCallbackTTL = 30 * time.Minute
+
+// eventConflicts warns the user that the event overlaps their other registrations,
+// the user can register anyway or cancel one of the other registrations
+func (h Handler) eventConflicts(c tele.Context, event *entity.Event, page string, conflicts []dto.UserEvent) error {
+	h.logger.Infof("(user: %d) registration conflicts with %d events (event_id=%s)", c.Sender().ID, len(conflicts), event.ID)
+
+	type conflict struct {
+		Name      string
+		StartTime string
+		EndTime   string
+	}
+
+	btnData := struct {
+		ID   string
+		Page string
+	}{
+		ID:   event.ID,
+		Page: page,
+	}
+
+	markup := c.Bot().NewMarkup()
+	rows := []tele.Row{markup.Row(*h.layout.Button(c, "user:events:event:register_anyway", btnData))}
+	items := make([]conflict, 0, len(conflicts))
+	for _, other := range conflicts {
+		items = append(items, conflict{
+			Name:      other.Name,
+			StartTime: other.StartTime.In(location.Location()).Format("02.01.2006 15:04"),
+			EndTime:   other.End().In(location.Location()).Format("15:04"),
+		})
+
+		callbackID, err := h.callbacksStorage.Set(fmt.Sprintf("%s %s %s", event.ID, page, other.ID), conflictCallbackTTL)
+		if err != nil {
+			h.logger.Errorf("(user: %d) error while saving callback to redis: %v", c.Sender().ID, err)
+			return c.Edit(
+				banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+				h.layout.Markup(c, "user:events:event:form", btnData),
+			)
+		}
+		rows = append(rows, markup.Row(*h.layout.Button(c, "user:events:event:cancel_conflict", struct {
+			CallbackID string
+			Name       string
+		}{
+			CallbackID: callbackID,
+			Name:       other.Name,
+		})))
+	}
+	rows = append(rows, markup.Row(*h.layout.Button(c, "user:events:event:form:back", btnData)))
+	markup.Inline(rows...)
+
+	return c.Edit(
+		banner.Events.Caption(h.layout.Text(c, "event_conflicts_text", struct {
+			Name      string
+			Conflicts []conflict
+		}{
+			Name:      event.Name,
+			Conflicts: items,
+		})),
+		markup,
+	)
+}
+
+// eventCancelConflict cancels the overlapping registration and continues the registration for the event
+func (h Handler) eventCancelConflict(c tele.Context) error {
+	callbackData, err := h.callbacksStorage.Get(c.Callback().Data)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while getting callback from redis: %v", c.Sender().ID, err)
+		return c.Respond(&tele.CallbackResponse{
+			Text:      h.layout.Text(c, "event_conflict_expired"),
+			ShowAlert: true,
+		})
+	}
+
+	data := strings.Split(callbackData, " ")
+	if len(data) != 3 {
+		return errorz.ErrInvalidCallbackData
+	}
+	eventID, page, otherEventID := data[0], data[1], data[2]
+	h.logger.Infof("(user: %d) cancel conflicting registration (event_id=%s, other_event_id=%s)", c.Sender().ID, eventID, otherEventID)
+
+	err = h.eventParticipantService.Delete(context.Background(), otherEventID, c.Sender().ID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while delete event participant: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "user:events:back", struct {
+				Page string
+			}{
+				Page: page,
+			}),
+		)
+	}
+	h.callbacksStorage.Delete(c.Callback().Data)
+
+	// Other registrations may still overlap the event, so they are checked again
+	return h.showEvent(c, eventID, page, true, true)
+}
@@ -0,0 +1,152 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/nlypage/intele/collector"
+	tele "gopkg.in/telebot.v3"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/common/errorz"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/validator"
+)
+
+func (h Handler) feedbackRate(c tele.Context) error {
+	data := strings.Split(c.Callback().Data, " ")
+	if len(data) != 2 {
+		return errorz.ErrInvalidCallbackData
+	}
+	eventID := data[0]
+	rating, err := strconv.Atoi(data[1])
+	if err != nil {
+		return errorz.ErrInvalidCallbackData
+	}
+	h.logger.Infof("(user: %d) rate event (event_id=%s, rating=%d)", c.Sender().ID, eventID, rating)
+
+	_, err = h.feedbackService.Rate(context.Background(), eventID, c.Sender().ID, rating)
+	if err != nil {
+		if errors.Is(err, errorz.ErrFeedbackNotAllowed) {
+			return c.Edit(
+				h.layout.Text(c, "feedback_not_allowed"),
+				h.layout.Markup(c, "core:hide"),
+			)
+		}
+		h.logger.Errorf("(user: %d) error while rate event: %v", c.Sender().ID, err)
+		return c.Edit(
+			h.layout.Text(c, "technical_issues", err.Error()),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+
+	return h.feedbackThanks(c, eventID, rating)
+}
+
+func (h Handler) feedbackComment(c tele.Context) error {
+	eventID := c.Callback().Data
+	h.logger.Infof("(user: %d) comment event feedback (event_id=%s)", c.Sender().ID, eventID)
+
+	inputCollector := collector.New()
+	_ = c.Edit(
+		h.layout.Text(c, "feedback_input_comment"),
+		h.layout.Markup(c, "feedback:comment:back", struct {
+			ID string
+		}{
+			ID: eventID,
+		}),
+	)
+	inputCollector.Collect(c.Message())
+
+	var comment string
+	for comment == "" {
+		response, errGet := h.input.Get(context.Background(), c.Sender().ID, 0)
+		if response.Message != nil {
+			inputCollector.Collect(response.Message)
+		}
+		switch {
+		case response.Canceled:
+			_ = inputCollector.Clear(c, collector.ClearOptions{IgnoreErrors: true, ExcludeLast: true})
+			return nil
+		case errGet != nil:
+			h.logger.Errorf("(user: %d) error while input feedback comment: %v", c.Sender().ID, errGet)
+			_ = inputCollector.Send(c,
+				h.layout.Text(c, "input_error", h.layout.Text(c, "feedback_input_comment")),
+				h.layout.Markup(c, "feedback:comment:back", struct {
+					ID string
+				}{
+					ID: eventID,
+				}),
+			)
+		case response.Message == nil:
+			_ = inputCollector.Send(c,
+				h.layout.Text(c, "input_error", h.layout.Text(c, "feedback_input_comment")),
+				h.layout.Markup(c, "feedback:comment:back", struct {
+					ID string
+				}{
+					ID: eventID,
+				}),
+			)
+		case !validator.FeedbackComment(response.Message.Text, nil):
+			_ = inputCollector.Send(c,
+				h.layout.Text(c, "invalid_feedback_comment"),
+				h.layout.Markup(c, "feedback:comment:back", struct {
+					ID string
+				}{
+					ID: eventID,
+				}),
+			)
+		default:
+			comment = response.Message.Text
+		}
+	}
+	_ = inputCollector.Clear(c, collector.ClearOptions{IgnoreErrors: true})
+
+	_, err := h.feedbackService.Comment(context.Background(), eventID, c.Sender().ID, comment)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while save feedback comment: %v", c.Sender().ID, err)
+		return c.Send(
+			h.layout.Text(c, "technical_issues", err.Error()),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+
+	return c.Send(
+		h.layout.Text(c, "feedback_comment_saved"),
+		h.layout.Markup(c, "core:hide"),
+	)
+}
+
+func (h Handler) feedbackCommentBack(c tele.Context) error {
+	eventID := c.Callback().Data
+	h.logger.Infof("(user: %d) skip feedback comment (event_id=%s)", c.Sender().ID, eventID)
+
+	return h.feedbackThanks(c, eventID, 0)
+}
+
+// feedbackThanks edits the feedback request into the thanks message with the button to leave a comment
+func (h Handler) feedbackThanks(c tele.Context, eventID string, rating int) error {
+	event, err := h.eventService.Get(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event: %v", c.Sender().ID, err)
+		return c.Edit(
+			h.layout.Text(c, "technical_issues", err.Error()),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+
+	return c.Edit(
+		h.layout.Text(c, "feedback_thanks", struct {
+			Name   string
+			Rating int
+		}{
+			Name:   event.Name,
+			Rating: rating,
+		}),
+		h.layout.Markup(c, "feedback:rated", struct {
+			ID string
+		}{
+			ID: eventID,
+		}),
+	)
+}
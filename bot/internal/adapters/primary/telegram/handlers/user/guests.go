@@ -0,0 +1,332 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/nlypage/intele/collector"
+	tele "gopkg.in/telebot.v3"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/common/errorz"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/banner"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/validator"
+)
+
+func (h Handler) eventGuests(c tele.Context) error {
+	data := strings.Split(c.Callback().Data, " ")
+	if len(data) != 2 {
+		return errorz.ErrInvalidCallbackData
+	}
+	eventID, page := data[0], data[1]
+	h.logger.Infof("(user: %d) event guests (event_id=%s)", c.Sender().ID, eventID)
+
+	caption, markup, err := h.eventGuestsMenu(c, eventID, page)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event guests: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "user:events:back", struct {
+				Page string
+			}{
+				Page: page,
+			}),
+		)
+	}
+
+	return c.Edit(caption, markup)
+}
+
+func (h Handler) eventGuestAdd(c tele.Context) error {
+	data := strings.Split(c.Callback().Data, " ")
+	if len(data) != 2 {
+		return errorz.ErrInvalidCallbackData
+	}
+	eventID, page := data[0], data[1]
+
+	event, err := h.eventService.Get(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "user:events:back", struct {
+				Page string
+			}{
+				Page: page,
+			}),
+		)
+	}
+
+	guests, err := h.eventParticipantService.GetGuests(context.Background(), eventID, c.Sender().ID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event guests: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "user:events:back", struct {
+				Page string
+			}{
+				Page: page,
+			}),
+		)
+	}
+	if len(guests) >= event.MaxGuests {
+		return c.Respond(&tele.CallbackResponse{
+			Text: h.layout.Text(c, "too_many_guests", struct {
+				MaxGuests int
+			}{
+				MaxGuests: event.MaxGuests,
+			}),
+			ShowAlert: true,
+		})
+	}
+
+	h.logger.Infof("(user: %d) add event guest (event_id=%s)", c.Sender().ID, eventID)
+
+	backMarkup := h.layout.Markup(c, "user:events:event:guests:back", struct {
+		ID   string
+		Page string
+	}{
+		ID:   eventID,
+		Page: page,
+	})
+	skipBtn := h.layout.Button(c, "user:events:event:guests:skip_email")
+
+	steps := []struct {
+		promptKey string
+		errorKey  string
+		validator func(string, map[string]interface{}) bool
+		skipBtn   *tele.Btn
+		result    string
+	}{
+		{
+			promptKey: "input_guest_name",
+			errorKey:  "invalid_guest_name",
+			validator: validator.GuestName,
+		},
+		{
+			promptKey: "input_guest_email",
+			errorKey:  "invalid_guest_email",
+			validator: validator.GuestEmail,
+			skipBtn:   skipBtn,
+		},
+	}
+
+	inputCollector := collector.New()
+	inputCollector.Collect(c.Message())
+	for i := range steps {
+		markup := backMarkup
+		var endpoints []tele.CallbackEndpoint
+		if steps[i].skipBtn != nil {
+			markup = &tele.ReplyMarkup{InlineKeyboard: append(
+				[][]tele.InlineButton{{*steps[i].skipBtn.Inline()}},
+				backMarkup.InlineKeyboard...,
+			)}
+			endpoints = append(endpoints, steps[i].skipBtn)
+		}
+
+		if i == 0 {
+			_ = c.Edit(banner.Events.Caption(h.layout.Text(c, steps[i].promptKey)), markup)
+		} else {
+			_ = inputCollector.Send(c, banner.Events.Caption(h.layout.Text(c, steps[i].promptKey)), markup)
+		}
+
+		done := false
+		for !done {
+			response, errGet := h.input.Get(context.Background(), c.Sender().ID, 0, endpoints...)
+			if response.Message != nil {
+				inputCollector.Collect(response.Message)
+			}
+			switch {
+			case response.Canceled:
+				_ = inputCollector.Clear(c, collector.ClearOptions{IgnoreErrors: true, ExcludeLast: true})
+				return nil
+			case errGet != nil:
+				h.logger.Errorf("(user: %d) error while input event guest: %v", c.Sender().ID, errGet)
+				_ = inputCollector.Send(c,
+					banner.Events.Caption(h.layout.Text(c, "input_error", h.layout.Text(c, steps[i].promptKey))),
+					backMarkup,
+				)
+			case response.Callback != nil:
+				_ = inputCollector.Clear(c, collector.ClearOptions{IgnoreErrors: true})
+				done = true
+			case !steps[i].validator(response.Message.Text, nil):
+				_ = inputCollector.Send(c,
+					banner.Events.Caption(h.layout.Text(c, steps[i].errorKey)),
+					backMarkup,
+				)
+			default:
+				steps[i].result = strings.TrimSpace(response.Message.Text)
+				_ = inputCollector.Clear(c, collector.ClearOptions{IgnoreErrors: true})
+				done = true
+			}
+		}
+	}
+
+	_, err = h.eventParticipantService.AddGuest(context.Background(), eventID, c.Sender().ID, steps[0].result, steps[1].result)
+	var errorKey string
+	switch {
+	case errors.Is(err, errorz.ErrRoleQuotaFull):
+		errorKey = "guest_role_quota_reached"
+	case errors.Is(err, errorz.ErrEventFull):
+		errorKey = "max_participants_reached"
+	case errors.Is(err, errorz.ErrTooManyGuests), errors.Is(err, errorz.ErrGuestsNotAllowed):
+		errorKey = "too_many_guests"
+	case errors.Is(err, errorz.ErrRegistrationClosed):
+		errorKey = "registration_ended"
+	case errors.Is(err, errorz.ErrEventCancelled):
+		errorKey = "event_cancelled_alert"
+	case errors.Is(err, errorz.ErrNotRegistered):
+		errorKey = "guest_without_registration"
+	case err != nil:
+		h.logger.Errorf("(user: %d) error while add event guest: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			backMarkup,
+		)
+	}
+	if errorKey != "" {
+		return c.Send(
+			banner.Events.Caption(h.layout.Text(c, errorKey, struct {
+				MaxGuests int
+			}{
+				MaxGuests: event.MaxGuests,
+			})),
+			backMarkup,
+		)
+	}
+
+	caption, markup, err := h.eventGuestsMenu(c, eventID, page)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event guests: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			backMarkup,
+		)
+	}
+
+	return c.Send(caption, markup)
+}
+
+func (h Handler) eventGuestRemove(c tele.Context) error {
+	data := strings.Split(c.Callback().Data, " ")
+	if len(data) != 3 {
+		return errorz.ErrInvalidCallbackData
+	}
+	eventID, page := data[0], data[1]
+	index, err := strconv.Atoi(data[2])
+	if err != nil {
+		return errorz.ErrInvalidCallbackData
+	}
+
+	guests, err := h.eventParticipantService.GetGuests(context.Background(), eventID, c.Sender().ID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event guests: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "user:events:back", struct {
+				Page string
+			}{
+				Page: page,
+			}),
+		)
+	}
+	if index < 0 || index >= len(guests) {
+		return errorz.ErrInvalidCallbackData
+	}
+
+	h.logger.Infof("(user: %d) remove event guest (event_id=%s, guest_id=%s)", c.Sender().ID, eventID, guests[index].ID)
+
+	err = h.eventParticipantService.RemoveGuest(context.Background(), eventID, c.Sender().ID, guests[index].ID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while remove event guest: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "user:events:back", struct {
+				Page string
+			}{
+				Page: page,
+			}),
+		)
+	}
+
+	caption, markup, err := h.eventGuestsMenu(c, eventID, page)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event guests: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "user:events:back", struct {
+				Page string
+			}{
+				Page: page,
+			}),
+		)
+	}
+
+	return c.Edit(caption, markup)
+}
+
+// eventGuestsMenu returns the caption and the markup of the participant's guests of the event
+func (h Handler) eventGuestsMenu(c tele.Context, eventID, page string) (interface{}, *tele.ReplyMarkup, error) {
+	event, err := h.eventService.Get(context.Background(), eventID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	guests, err := h.eventParticipantService.GetGuests(context.Background(), eventID, c.Sender().ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	markup := h.layout.Markup(c, "user:events:event:guests", struct {
+		ID   string
+		Page string
+	}{
+		ID:   eventID,
+		Page: page,
+	})
+
+	var rows [][]tele.InlineButton
+	for i, guest := range guests {
+		rows = append(rows, []tele.InlineButton{*h.layout.Button(c, "user:events:event:guests:remove", struct {
+			ID    string
+			Page  string
+			Index int
+			Name  string
+		}{
+			ID:    eventID,
+			Page:  page,
+			Index: i,
+			Name:  guest.Name,
+		}).Inline()})
+	}
+	if len(guests) < event.MaxGuests {
+		rows = append(rows, []tele.InlineButton{*h.layout.Button(c, "user:events:event:guests:add", struct {
+			ID   string
+			Page string
+		}{
+			ID:   eventID,
+			Page: page,
+		}).Inline()})
+	}
+	markup.InlineKeyboard = append(rows, markup.InlineKeyboard...)
+
+	type guestView struct {
+		Name  string
+		Email string
+	}
+	views := make([]guestView, 0, len(guests))
+	for _, guest := range guests {
+		views = append(views, guestView{Name: guest.Name, Email: guest.Email})
+	}
+
+	return banner.Events.Caption(h.layout.Text(c, "event_guests_text", struct {
+		Name      string
+		Guests    []guestView
+		MaxGuests int
+	}{
+		Name:      event.Name,
+		Guests:    views,
+		MaxGuests: event.MaxGuests,
+	})), markup, nil
+}
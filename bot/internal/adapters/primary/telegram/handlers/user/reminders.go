@@ -0,0 +1,116 @@
+package user
+
+import (
+	"context"
+	"slices"
+	"strconv"
+
+	tele "gopkg.in/telebot.v3"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/common/errorz"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/banner"
+)
+
+func (h Handler) reminders(c tele.Context) error {
+	h.logger.Infof("(user: %d) get reminders settings", c.Sender().ID)
+
+	user, err := h.userService.Get(context.Background(), c.Sender().ID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while getting user from db: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.PersonalAccount.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "personalAccount:back"),
+		)
+	}
+
+	caption, markup := h.remindersMenu(c, user)
+	return c.Edit(caption, markup)
+}
+
+func (h Handler) remindersMenu(c tele.Context, user *entity.User) (interface{}, *tele.ReplyMarkup) {
+	markup := c.Bot().NewMarkup()
+	rows := []tele.Row{markup.Row(*h.layout.Button(c, "personalAccount:reminders:mute", user))}
+	// Offsets can't be chosen while reminders are muted
+	if !user.RemindersMuted {
+		for i, offset := range entity.ReminderOffsetOptions {
+			rows = append(rows, markup.Row(*h.layout.Button(c, "personalAccount:reminder", struct {
+				Index   int
+				Enabled bool
+				When    string
+			}{
+				Index:   i,
+				Enabled: slices.Contains(user.ReminderOffsets, offset),
+				When:    h.layout.Text(c, "reminder_offset", offset),
+			})))
+		}
+		if len(user.ReminderOffsets) > 0 {
+			rows = append(rows, markup.Row(*h.layout.Button(c, "personalAccount:reminders:reset")))
+		}
+	}
+	rows = append(rows, markup.Row(*h.layout.Button(c, "personalAccount:back")))
+	markup.Inline(rows...)
+
+	return banner.PersonalAccount.Caption(h.layout.Text(c, "reminders_text", struct {
+		Muted  bool
+		Custom bool
+	}{
+		Muted:  user.RemindersMuted,
+		Custom: len(user.ReminderOffsets) > 0,
+	})), markup
+}
+
+// toggleReminder turns the reminder on or off, when the last one is turned off the user gets the reminders of events again
+func (h Handler) toggleReminder(c tele.Context) error {
+	index, err := strconv.Atoi(c.Callback().Data)
+	if err != nil || index < 0 || index >= len(entity.ReminderOffsetOptions) {
+		return errorz.ErrInvalidCallbackData
+	}
+	offset := entity.ReminderOffsetOptions[index]
+	h.logger.Infof("(user: %d) toggle reminder (offset=%dm)", c.Sender().ID, offset)
+
+	return h.updateReminders(c, func(user *entity.User) (bool, entity.ReminderOffsets) {
+		return user.RemindersMuted, user.ReminderOffsets.Toggle(offset)
+	})
+}
+
+func (h Handler) toggleRemindersMute(c tele.Context) error {
+	h.logger.Infof("(user: %d) toggle reminders mute", c.Sender().ID)
+
+	return h.updateReminders(c, func(user *entity.User) (bool, entity.ReminderOffsets) {
+		return !user.RemindersMuted, user.ReminderOffsets
+	})
+}
+
+func (h Handler) resetReminders(c tele.Context) error {
+	h.logger.Infof("(user: %d) reset reminders", c.Sender().ID)
+
+	return h.updateReminders(c, func(user *entity.User) (bool, entity.ReminderOffsets) {
+		return user.RemindersMuted, nil
+	})
+}
+
+// updateReminders saves the reminder settings returned by change and shows the updated menu
+func (h Handler) updateReminders(c tele.Context, change func(user *entity.User) (bool, entity.ReminderOffsets)) error {
+	user, err := h.userService.Get(context.Background(), c.Sender().ID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while getting user from db: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.PersonalAccount.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "personalAccount:back"),
+		)
+	}
+
+	muted, offsets := change(user)
+	user, err = h.userService.UpdateReminders(context.Background(), user.ID, muted, offsets)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while update reminders: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.PersonalAccount.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "personalAccount:back"),
+		)
+	}
+
+	caption, markup := h.remindersMenu(c, user)
+	return c.Edit(caption, markup)
+}
@@ -0,0 +1,232 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nlypage/intele/collector"
+	"github.com/redis/go-redis/v9"
+	tele "gopkg.in/telebot.v3"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/common/errorz"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/dto"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/banner"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/location"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/validator"
+)
+
+// searchQueryTTL - how long the search query is kept for the results pagination
+const searchQueryTTL = time.Hour
+
+func (h Handler) search(c tele.Context) error {
+	h.logger.Infof("(user: %d) search request", c.Sender().ID)
+
+	inputCollector := collector.New()
+	_ = c.Edit(
+		banner.Menu.Caption(h.layout.Text(c, "search_request")),
+		h.layout.Markup(c, "mainMenu:back"),
+	)
+	inputCollector.Collect(c.Message())
+
+	var query string
+	for query == "" {
+		response, errGet := h.input.Get(context.Background(), c.Sender().ID, 0)
+		if response.Message != nil {
+			inputCollector.Collect(response.Message)
+		}
+		switch {
+		case response.Canceled:
+			_ = inputCollector.Clear(c, collector.ClearOptions{IgnoreErrors: true, ExcludeLast: true})
+			return nil
+		case errGet != nil:
+			h.logger.Errorf("(user: %d) error while input search query: %v", c.Sender().ID, errGet)
+			_ = inputCollector.Send(c,
+				banner.Menu.Caption(h.layout.Text(c, "input_error", h.layout.Text(c, "search_request"))),
+				h.layout.Markup(c, "mainMenu:back"),
+			)
+		case response.Message == nil:
+			_ = inputCollector.Send(c,
+				banner.Menu.Caption(h.layout.Text(c, "input_error", h.layout.Text(c, "search_request"))),
+				h.layout.Markup(c, "mainMenu:back"),
+			)
+		case !validator.SearchQuery(response.Message.Text, nil):
+			_ = inputCollector.Send(c,
+				banner.Menu.Caption(h.layout.Text(c, "invalid_search_query")),
+				h.layout.Markup(c, "mainMenu:back"),
+			)
+		default:
+			query = strings.TrimSpace(response.Message.Text)
+		}
+	}
+	_ = inputCollector.Clear(c, collector.ClearOptions{IgnoreErrors: true})
+
+	// Запрос может не поместиться в callback data, поэтому храним его в redis
+	queryID, err := h.callbacksStorage.Set(query, searchQueryTTL)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while save search query: %v", c.Sender().ID, err)
+		return c.Send(
+			banner.Menu.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "mainMenu:back"),
+		)
+	}
+
+	caption, markup, err := h.searchResults(c, queryID, query, 0)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while search (query=%q): %v", c.Sender().ID, query, err)
+		return c.Send(
+			banner.Menu.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "mainMenu:back"),
+		)
+	}
+	return c.Send(caption, markup)
+}
+
+func (h Handler) searchPage(c tele.Context) error {
+	callbackData := strings.Split(c.Callback().Data, " ")
+	if len(callbackData) != 2 {
+		return errorz.ErrInvalidCallbackData
+	}
+	queryID := callbackData[0]
+	page, err := strconv.Atoi(callbackData[1])
+	if err != nil {
+		return errorz.ErrInvalidCallbackData
+	}
+
+	query, err := h.callbacksStorage.Get(queryID)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return c.Edit(
+				banner.Menu.Caption(h.layout.Text(c, "search_expired")),
+				h.layout.Markup(c, "search:again"),
+			)
+		}
+		h.logger.Errorf("(user: %d) error while get search query: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.Menu.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "mainMenu:back"),
+		)
+	}
+
+	caption, markup, err := h.searchResults(c, queryID, query, page)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while search (query=%q): %v", c.Sender().ID, query, err)
+		return c.Edit(
+			banner.Menu.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "mainMenu:back"),
+		)
+	}
+	return c.Edit(caption, markup)
+}
+
+// searchResults returns the caption and the markup of the search results page
+func (h Handler) searchResults(c tele.Context, queryID, query string, p int) (interface{}, *tele.ReplyMarkup, error) {
+	const resultsOnPage = 5
+
+	user, err := h.userService.Get(context.Background(), c.Sender().ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resultsCount, err := h.searchService.Count(context.Background(), query, user.Role)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	results, err := h.searchService.Search(context.Background(), query, user.Role, resultsOnPage, p*resultsOnPage)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	h.logger.Infof(
+		"(user: %d) search results (query=%q, page=%d, results_count=%d)",
+		c.Sender().ID,
+		query,
+		p,
+		resultsCount,
+	)
+
+	markup := c.Bot().NewMarkup()
+	var rows []tele.Row
+	for _, result := range results {
+		switch result.Type {
+		case dto.SearchResultEvent:
+			var startTime string
+			if result.StartTime != nil {
+				startTime = result.StartTime.In(location.Location()).Format("02.01")
+			}
+			rows = append(rows, markup.Row(*h.layout.Button(c, "search:event", struct {
+				ID        string
+				Page      int
+				Name      string
+				StartTime string
+			}{
+				ID:        result.ID,
+				Page:      0,
+				Name:      result.Name,
+				StartTime: startTime,
+			})))
+		case dto.SearchResultClub:
+			rows = append(rows, markup.Row(*h.layout.Button(c, "search:club", struct {
+				ID   string
+				Page int
+				Name string
+			}{
+				ID:   result.ID,
+				Page: 0,
+				Name: result.Name,
+			})))
+		}
+	}
+
+	pagesCount := (int(resultsCount) - 1) / resultsOnPage
+	if pagesCount > 0 {
+		prevPage, nextPage := p-1, p+1
+		if p == 0 {
+			prevPage = pagesCount
+		}
+		if p >= pagesCount {
+			nextPage = 0
+		}
+
+		rows = append(rows, markup.Row(
+			*h.layout.Button(c, "search:prev_page", struct {
+				QueryID string
+				Page    int
+			}{
+				QueryID: queryID,
+				Page:    prevPage,
+			}),
+			*h.layout.Button(c, "core:page_counter", struct {
+				Page       int
+				PagesCount int
+			}{
+				Page:       p + 1,
+				PagesCount: pagesCount + 1,
+			}),
+			*h.layout.Button(c, "search:next_page", struct {
+				QueryID string
+				Page    int
+			}{
+				QueryID: queryID,
+				Page:    nextPage,
+			}),
+		))
+	}
+
+	rows = append(rows,
+		markup.Row(*h.layout.Button(c, "search:again")),
+		markup.Row(*h.layout.Button(c, "mainMenu:back")),
+	)
+	markup.Inline(rows...)
+
+	return banner.Menu.Caption(h.layout.Text(c, "search_results", struct {
+		Query string
+		Count int64
+	}{
+		Query: query,
+		Count: resultsCount,
+	})), markup, nil
+}
@@ -18,6 +18,7 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/adapters/primary/telegram/handlers/menu"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/adapters/primary/telegram/handlers/registrationForm"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/adapters/secondary/redis/callbacks"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/adapters/secondary/redis/codes"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/adapters/secondary/redis/emails"
@@ -42,6 +43,8 @@ type Handler struct {
 	eventParticipantService primary.EventParticipantService
 	qrService               primary.QrService
 	notificationService     primary.NotifyService
+	searchService           primary.SearchService
+	feedbackService         primary.FeedbackService
 
 	menuHandler *menu.Handler
 
@@ -59,6 +62,7 @@ type Handler struct {
 	emailTTL          time.Duration
 	authTTL           time.Duration
 	resendTTL         time.Duration
+	httpPublicURL     string
 }
 
 func New(
@@ -68,6 +72,8 @@ func New(
 	eventParticipantSvc primary.EventParticipantService,
 	qrSvc primary.QrService,
 	notifySvc primary.NotifyService,
+	searchSvc primary.SearchService,
+	feedbackSvc primary.FeedbackService,
 	menuHandler *menu.Handler,
 	codesStorage *codes.Storage,
 	emailsStorage *emails.Storage,
@@ -82,6 +88,7 @@ func New(
 	emailTTL time.Duration,
 	authTTL time.Duration,
 	resendTTL time.Duration,
+	httpPublicURL string,
 ) *Handler {
 	return &Handler{
 		userService:             userSvc,
@@ -90,6 +97,8 @@ func New(
 		clubService:             clubSvc,
 		qrService:               qrSvc,
 		notificationService:     notifySvc,
+		searchService:           searchSvc,
+		feedbackService:         feedbackSvc,
 		menuHandler:             menuHandler,
 		codesStorage:            codesStorage,
 		emailsStorage:           emailsStorage,
@@ -104,6 +113,7 @@ func New(
 		emailTTL:                emailTTL,
 		authTTL:                 authTTL,
 		resendTTL:               resendTTL,
+		httpPublicURL:           httpPublicURL,
 	}
 }
 
@@ -449,6 +459,15 @@ func (h Handler) personalAccount(c tele.Context) error {
 
 	fio := user.GetFIO()
 
+	noShows, err := h.eventParticipantService.GetNoShowStanding(context.Background(), user.ID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get no-show standing: %v", c.Sender().ID, err)
+	}
+	var blockedUntil string
+	if noShows.IsBlocked(time.Now()) {
+		blockedUntil = noShows.BlockedUntil.In(location.Location()).Format("02.01.2006 15:04")
+	}
+
 	// TODO: refactor
 	markup := h.layout.Markup(c, "personalAccount:menu")
 	if user.Role != valueobject.Student {
@@ -462,11 +481,15 @@ func (h Handler) personalAccount(c tele.Context) error {
 	}
 	return c.Edit(
 		banner.PersonalAccount.Caption(h.layout.Text(c, "personal_account_text", struct {
-			Name string
-			Role string
+			Name         string
+			Role         string
+			NoShows      dto.NoShowStanding
+			BlockedUntil string
 		}{
-			Name: fio.Name,
-			Role: user.Role.String(),
+			Name:         fio.Name,
+			Role:         user.Role.String(),
+			NoShows:      noShows,
+			BlockedUntil: blockedUntil,
 		})),
 		markup,
 	)
@@ -498,11 +521,23 @@ func (h Handler) qrCode(c tele.Context) error {
 }
 
 func (h Handler) eventsList(c tele.Context) error {
+	var p int
+	if c.Callback().Unique != "mainMenu_events" {
+		var err error
+		p, err = strconv.Atoi(c.Callback().Data)
+		if err != nil {
+			return errorz.ErrInvalidCallbackData
+		}
+	}
+
+	return h.showEventsList(c, p)
+}
+
+func (h Handler) showEventsList(c tele.Context, p int) error {
 	const eventsOnPage = 5
 	h.logger.Infof("(user: %d) edit events list", c.Sender().ID)
 
 	var (
-		p           int
 		prevPage    int
 		nextPage    int
 		err         error
@@ -511,12 +546,6 @@ func (h Handler) eventsList(c tele.Context) error {
 		rows        []tele.Row
 		menuRow     tele.Row
 	)
-	if c.Callback().Unique != "mainMenu_events" {
-		p, err = strconv.Atoi(c.Callback().Data)
-		if err != nil {
-			return errorz.ErrInvalidCallbackData
-		}
-	}
 
 	user, err := h.userService.Get(context.Background(), c.Sender().ID)
 	if err != nil {
@@ -527,7 +556,16 @@ func (h Handler) eventsList(c tele.Context) error {
 		)
 	}
 
-	eventsCount, err = h.eventService.Count(context.Background(), user.Role)
+	filter, err := h.eventsStorage.GetFilter(c.Sender().ID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get events filter: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "mainMenu:back"),
+		)
+	}
+
+	eventsCount, err = h.eventService.Count(context.Background(), user.Role, filter)
 	if err != nil {
 		h.logger.Errorf("(user: %d) error while get events count: %v", c.Sender().ID, err)
 		return c.Edit(
@@ -544,6 +582,7 @@ func (h Handler) eventsList(c tele.Context) error {
 		"start_time ASC",
 		user.Role,
 		user.ID,
+		filter,
 	)
 	if err != nil {
 		h.logger.Errorf(
@@ -570,11 +609,13 @@ func (h Handler) eventsList(c tele.Context) error {
 			ID           string
 			Name         string
 			Page         int
+			IsOnline     bool
 			IsRegistered bool
 		}{
 			ID:           event.ID,
 			Name:         event.Name,
 			Page:         p,
+			IsOnline:     event.IsOnline,
 			IsRegistered: event.IsRegistered,
 		})))
 	}
@@ -613,11 +654,29 @@ func (h Handler) eventsList(c tele.Context) error {
 		}),
 	)
 
-	rows = append(
-		rows,
-		menuRow,
-		markup.Row(*h.layout.Button(c, "mainMenu:back")),
-	)
+	filterRow := tele.Row{
+		*h.layout.Button(c, "user:events:filter:category", struct {
+			Active bool
+		}{
+			Active: filter.Category != "",
+		}),
+		*h.layout.Button(c, "user:events:filter:club", struct {
+			Active bool
+		}{
+			Active: filter.ClubID != "",
+		}),
+		*h.layout.Button(c, "user:events:filter:period", struct {
+			Active bool
+		}{
+			Active: filter.Period != "",
+		}),
+	}
+
+	rows = append(rows, menuRow, filterRow)
+	if !filter.IsEmpty() {
+		rows = append(rows, markup.Row(*h.layout.Button(c, "user:events:filter:reset")))
+	}
+	rows = append(rows, markup.Row(*h.layout.Button(c, "mainMenu:back")))
 
 	markup.Inline(rows...)
 
@@ -631,20 +690,223 @@ func (h Handler) eventsList(c tele.Context) error {
 		prevPage,
 	)
 
+	if filter.IsEmpty() {
+		_ = c.Edit(
+			banner.Events.Caption(h.layout.Text(c, "events_list")),
+			markup,
+		)
+		return nil
+	}
+
+	var clubName string
+	if filter.ClubID != "" {
+		club, err := h.clubService.Get(context.Background(), filter.ClubID)
+		if err != nil {
+			h.logger.Errorf("(user: %d) error while get club: %v", c.Sender().ID, err)
+			return c.Edit(
+				banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+				h.layout.Markup(c, "mainMenu:back"),
+			)
+		}
+		clubName = club.Name
+	}
+
+	var category, period string
+	if filter.Category != "" {
+		category = h.layout.Text(c, "category_"+filter.Category.String())
+	}
+	if filter.Period != "" {
+		period = h.layout.Text(c, "period_"+string(filter.Period))
+	}
+
 	_ = c.Edit(
-		banner.Events.Caption(h.layout.Text(c, "events_list")),
+		banner.Events.Caption(h.layout.Text(c, "events_list_filtered", struct {
+			Category string
+			Club     string
+			Period   string
+			Count    int64
+		}{
+			Category: category,
+			Club:     clubName,
+			Period:   period,
+			Count:    eventsCount,
+		})),
 		markup,
 	)
 	return nil
 }
 
+// eventsFilter shows the values of the chosen events list filter with the number of events for each value
+func (h Handler) eventsFilter(c tele.Context) error {
+	h.logger.Infof("(user: %d) edit events filter (%s)", c.Sender().ID, c.Callback().Unique)
+
+	user, err := h.userService.Get(context.Background(), c.Sender().ID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while getting user from db: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "mainMenu:back"),
+		)
+	}
+
+	filter, err := h.eventsStorage.GetFilter(c.Sender().ID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get events filter: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "mainMenu:back"),
+		)
+	}
+
+	counts, err := h.eventService.CountByFilters(context.Background(), user.Role, filter)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while count events by filters: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "mainMenu:back"),
+		)
+	}
+
+	var (
+		textKey   string
+		buttonKey string
+		rows      []tele.Row
+	)
+	markup := c.Bot().NewMarkup()
+
+	switch c.Callback().Unique {
+	case "user_evFilterCat":
+		textKey, buttonKey = "events_filter_category", "user:events:filter:set_category"
+		for _, category := range entity.EventCategories {
+			rows = append(rows, markup.Row(*h.layout.Button(c, buttonKey, struct {
+				Value    string
+				Name     string
+				Count    int64
+				Selected bool
+			}{
+				Value:    category.String(),
+				Name:     h.layout.Text(c, "category_"+category.String()),
+				Count:    counts.Categories[category],
+				Selected: filter.Category == category,
+			})))
+		}
+	case "user_evFilterClub":
+		textKey, buttonKey = "events_filter_club", "user:events:filter:set_club"
+		for _, club := range counts.Clubs {
+			rows = append(rows, markup.Row(*h.layout.Button(c, buttonKey, struct {
+				Value    string
+				Name     string
+				Count    int64
+				Selected bool
+			}{
+				Value:    club.ClubID,
+				Name:     club.ClubName,
+				Count:    club.Count,
+				Selected: filter.ClubID == club.ClubID,
+			})))
+		}
+	case "user_evFilterPeriod":
+		textKey, buttonKey = "events_filter_period", "user:events:filter:set_period"
+		for _, period := range dto.EventPeriods {
+			rows = append(rows, markup.Row(*h.layout.Button(c, buttonKey, struct {
+				Value    string
+				Name     string
+				Count    int64
+				Selected bool
+			}{
+				Value:    string(period),
+				Name:     h.layout.Text(c, "period_"+string(period)),
+				Count:    counts.Periods[period],
+				Selected: filter.Period == period,
+			})))
+		}
+	default:
+		return errorz.ErrInvalidCallbackData
+	}
+
+	rows = append(rows,
+		markup.Row(*h.layout.Button(c, buttonKey+":any")),
+		markup.Row(*h.layout.Button(c, "user:events:back", struct {
+			Page int
+		}{
+			Page: 0,
+		})),
+	)
+	markup.Inline(rows...)
+
+	return c.Edit(
+		banner.Events.Caption(h.layout.Text(c, textKey)),
+		markup,
+	)
+}
+
+// eventsSetFilter applies the chosen filter value and shows the first page of the filtered events list
+func (h Handler) eventsSetFilter(c tele.Context) error {
+	value := c.Callback().Data
+	h.logger.Infof("(user: %d) set events filter (%s=%s)", c.Sender().ID, c.Callback().Unique, value)
+
+	filter, err := h.eventsStorage.GetFilter(c.Sender().ID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get events filter: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "mainMenu:back"),
+		)
+	}
+
+	switch c.Callback().Unique {
+	case "user_evSetCat":
+		category := entity.EventCategory(value)
+		if !category.IsValid() {
+			return errorz.ErrInvalidCallbackData
+		}
+		filter.Category = category
+	case "user_evSetCatAny":
+		filter.Category = ""
+	case "user_evSetClub":
+		if value == "" {
+			return errorz.ErrInvalidCallbackData
+		}
+		filter.ClubID = value
+	case "user_evSetClubAny":
+		filter.ClubID = ""
+	case "user_evSetPeriod":
+		period := dto.EventPeriod(value)
+		if !period.IsValid() {
+			return errorz.ErrInvalidCallbackData
+		}
+		filter.Period = period
+	case "user_evSetPeriodAny":
+		filter.Period = ""
+	case "user_evFilterReset":
+		filter = dto.EventFilter{}
+	default:
+		return errorz.ErrInvalidCallbackData
+	}
+
+	if filter.IsEmpty() {
+		h.eventsStorage.ClearFilter(c.Sender().ID)
+	} else {
+		h.eventsStorage.SetFilter(c.Sender().ID, filter, 0)
+	}
+
+	return h.showEventsList(c, 0)
+}
+
 func (h Handler) event(c tele.Context) error {
 	callbackData := strings.Split(c.Callback().Data, " ")
 	if len(callbackData) != 2 {
 		return errorz.ErrInvalidCallbackData
 	}
-	eventID := callbackData[0]
-	page := callbackData[1]
+
+	unique := c.Callback().Unique
+	register := unique == "event_register" || unique == "event_register_anyway"
+	return h.showEvent(c, callbackData[0], callbackData[1], register, unique == "event_register")
+}
+
+// showEvent shows the event card, if register is true the user is registered first.
+// With checkConflicts the user is warned about registrations that overlap the event instead of registering
+func (h Handler) showEvent(c tele.Context, eventID, page string, register, checkConflicts bool) error {
 	h.logger.Infof("(user: %d) edit event (event_id=%s)", c.Sender().ID, eventID)
 
 	event, err := h.eventService.Get(context.Background(), eventID)
@@ -660,6 +922,13 @@ func (h Handler) event(c tele.Context) error {
 		)
 	}
 
+	if event.IsCancelled() {
+		return c.Respond(&tele.CallbackResponse{
+			Text:      h.layout.Text(c, "event_cancelled_alert"),
+			ShowAlert: true,
+		})
+	}
+
 	club, err := h.clubService.Get(context.Background(), event.ClubID)
 	if err != nil {
 		h.logger.Errorf("(user: %d) error while get club: %v", c.Sender().ID, err)
@@ -686,8 +955,8 @@ func (h Handler) event(c tele.Context) error {
 		)
 	}
 
-	var registered bool
-	_, errGetParticipant := h.eventParticipantService.Get(context.Background(), eventID, c.Sender().ID)
+	var registered, pending bool
+	participant, errGetParticipant := h.eventParticipantService.Get(context.Background(), eventID, c.Sender().ID)
 	if errGetParticipant != nil {
 		if !errors.Is(errGetParticipant, gorm.ErrRecordNotFound) {
 			h.logger.Errorf("(user: %d) error while get participant: %v", c.Sender().ID, errGetParticipant)
@@ -702,6 +971,104 @@ func (h Handler) event(c tele.Context) error {
 		}
 	} else {
 		registered = true
+		pending = participant.IsPending()
+	}
+
+	var justRegistered bool
+	if register && !registered {
+		if checkConflicts {
+			conflicts, errConflicts := h.eventParticipantService.GetConflicts(context.Background(), eventID, c.Sender().ID)
+			if errConflicts != nil {
+				h.logger.Errorf("(user: %d) error while get conflicting events: %v", c.Sender().ID, errConflicts)
+				return c.Edit(
+					banner.Events.Caption(h.layout.Text(c, "technical_issues", errConflicts.Error())),
+					h.layout.Markup(c, "user:events:back", struct {
+						Page string
+					}{
+						Page: page,
+					}),
+				)
+			}
+			if len(conflicts) > 0 {
+				return h.eventConflicts(c, event, page, conflicts)
+			}
+		}
+
+		answers, answered, errForm := h.askRegistrationForm(c, eventID, page)
+		if errForm != nil {
+			h.logger.Errorf("(user: %d) error while get event registration form: %v", c.Sender().ID, errForm)
+			return c.Edit(
+				banner.Events.Caption(h.layout.Text(c, "technical_issues", errForm.Error())),
+				h.layout.Markup(c, "user:events:back", struct {
+					Page string
+				}{
+					Page: page,
+				}),
+			)
+		}
+		if !answered {
+			return nil
+		}
+
+		participant, err = h.eventParticipantService.Register(context.Background(), eventID, c.Sender().ID, answers)
+		switch {
+		case err == nil:
+			registered = true
+			pending = participant.IsPending()
+			justRegistered = true
+		case errors.Is(err, errorz.ErrEventCancelled):
+			return c.Respond(&tele.CallbackResponse{
+				Text:      h.layout.Text(c, "event_cancelled_alert"),
+				ShowAlert: true,
+			})
+		case errors.Is(err, errorz.ErrEventDraft):
+			return c.Respond(&tele.CallbackResponse{
+				Text:      h.layout.Text(c, "event_draft_alert"),
+				ShowAlert: true,
+			})
+		case errors.Is(err, errorz.ErrRegistrationClosed):
+			return c.Respond(&tele.CallbackResponse{
+				Text:      h.layout.Text(c, "registration_ended"),
+				ShowAlert: true,
+			})
+		case errors.Is(err, errorz.ErrRegistrationBlocked):
+			return c.Respond(&tele.CallbackResponse{
+				Text:      h.layout.Text(c, "registration_blocked"),
+				ShowAlert: true,
+			})
+		case errors.Is(err, errorz.ErrRoleNotAllowed):
+			return c.Respond(&tele.CallbackResponse{
+				Text:      h.layout.Text(c, "not_allowed_role"),
+				ShowAlert: true,
+			})
+		case errors.Is(err, errorz.ErrInvalidAnswer):
+			return c.Respond(&tele.CallbackResponse{
+				Text:      h.layout.Text(c, "invalid_registration_form"),
+				ShowAlert: true,
+			})
+		case errors.Is(err, errorz.ErrRoleQuotaFull):
+			// Don't return here: the card is re-rendered with the waitlist button
+			_ = c.Respond(&tele.CallbackResponse{
+				Text:      h.layout.Text(c, "role_quota_reached"),
+				ShowAlert: true,
+			})
+		case errors.Is(err, errorz.ErrEventFull):
+			// Don't return here: the card is re-rendered with the waitlist button
+			_ = c.Respond(&tele.CallbackResponse{
+				Text:      h.layout.Text(c, "max_participants_reached"),
+				ShowAlert: true,
+			})
+		default:
+			h.logger.Errorf("(user: %d) error while register to event: %v", c.Sender().ID, err)
+			return c.Edit(
+				banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+				h.layout.Markup(c, "user:events:back", struct {
+					Page string
+				}{
+					Page: page,
+				}),
+			)
+		}
 	}
 
 	participantsCount, err := h.eventParticipantService.CountByEventID(context.Background(), eventID)
@@ -717,90 +1084,90 @@ func (h Handler) event(c tele.Context) error {
 		)
 	}
 
-	if c.Callback().Unique == "event_register" {
-		if !registered {
-			var roleAllowed bool
-			var registrationActive bool
-			for _, role := range event.AllowedRoles {
-				if role == string(user.Role) {
-					roleAllowed = true
-				}
-			}
+	roleSeats, err := h.eventParticipantService.GetSeatsByRole(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get seats by role: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "user:events:back", struct {
+				Page string
+			}{
+				Page: page,
+			}),
+		)
+	}
 
-			if user.Role == valueobject.Student {
-				registrationActive = event.RegistrationEnd.After(time.Now().In(location.Location()))
-			} else {
-				registrationActive = utils.GetMaxRegisteredEndTime(event.StartTime).After(time.Now().In(location.Location())) && event.RegistrationEnd.After(time.Now().In(location.Location()))
+	offeredSeats, err := h.eventParticipantService.CountOfferedSeats(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get offered seats count: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "user:events:back", struct {
+				Page string
+			}{
+				Page: page,
+			}),
+		)
+	}
+	isFull := event.MaxParticipants > 0 && participantsCount+offeredSeats >= event.MaxParticipants
+	for _, seats := range roleSeats {
+		if seats.Role == user.Role && seats.IsFull() {
+			isFull = true
+		}
+	}
+	roleAllowed := event.IsRoleAllowed(user.Role)
+	registrationActive := h.eventService.IsRegistrationOpen(event, user.Role, time.Now())
+
+	if justRegistered {
+		if participantsCount == event.ExpectedParticipants {
+			errSendWarning := h.notificationService.SendClubWarning(event.ClubID,
+				h.layout.Text(c, "expected_participants_reached_warning", struct {
+					Name              string
+					ParticipantsCount int
+				}{
+					Name:              event.Name,
+					ParticipantsCount: participantsCount,
+				}),
+				h.layout.Markup(c, "core:hide"),
+			)
+			if errSendWarning != nil {
+				h.logger.Errorf("(user: %d) error while send expected participants reached warning: %v", c.Sender().ID, errSendWarning)
 			}
+		}
 
-			if (event.MaxParticipants == 0 || participantsCount < event.MaxParticipants) && registrationActive && roleAllowed {
-				_, err = h.eventParticipantService.Register(context.Background(), eventID, c.Sender().ID)
-				if err != nil {
-					h.logger.Errorf("(user: %d) error while register to event: %v", c.Sender().ID, err)
-					return c.Edit(
-						banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
-						h.layout.Markup(c, "user:events:back", struct {
-							Page string
-						}{
-							Page: page,
-						}),
-					)
-				}
-
-				if participantsCount+1 == event.ExpectedParticipants {
-					errSendWarning := h.notificationService.SendClubWarning(event.ClubID,
-						h.layout.Text(c, "expected_participants_reached_warning", struct {
-							Name              string
-							ParticipantsCount int
-						}{
-							Name:              event.Name,
-							ParticipantsCount: participantsCount + 1,
-						}),
-						h.layout.Markup(c, "core:hide"),
-					)
-					if errSendWarning != nil {
-						h.logger.Errorf("(user: %d) error while send expected participants reached warning: %v", c.Sender().ID, errSendWarning)
-					}
-				}
-
-				if participantsCount+1 == event.MaxParticipants {
-					errSendWarning := h.notificationService.SendClubWarning(event.ClubID,
-						h.layout.Text(c, "max_participants_reached_warning", struct {
-							Name              string
-							ParticipantsCount int
-						}{
-							Name:              event.Name,
-							ParticipantsCount: participantsCount + 1,
-						}),
-						h.layout.Markup(c, "core:hide"),
-					)
-					if errSendWarning != nil {
-						h.logger.Errorf("(user: %d) error while send expected participants reached warning: %v", c.Sender().ID, errSendWarning)
-					}
-				}
-				registered = true
-			} else {
-				switch {
-				case event.RegistrationEnd.Before(time.Now().In(location.Location())):
-					return c.Respond(&tele.CallbackResponse{
-						Text:      h.layout.Text(c, "registration_ended"),
-						ShowAlert: true,
-					})
-				case event.MaxParticipants > 0 && participantsCount >= event.MaxParticipants:
-					return c.Respond(&tele.CallbackResponse{
-						Text:      h.layout.Text(c, "max_participants_reached"),
-						ShowAlert: true,
-					})
-				case !roleAllowed:
-					return c.Respond(&tele.CallbackResponse{
-						Text:      h.layout.Text(c, "not_allowed_role"),
-						ShowAlert: true,
-					})
-				}
+		if participantsCount == event.MaxParticipants {
+			errSendWarning := h.notificationService.SendClubWarning(event.ClubID,
+				h.layout.Text(c, "max_participants_reached_warning", struct {
+					Name              string
+					ParticipantsCount int
+				}{
+					Name:              event.Name,
+					ParticipantsCount: participantsCount,
+				}),
+				h.layout.Markup(c, "core:hide"),
+			)
+			if errSendWarning != nil {
+				h.logger.Errorf("(user: %d) error while send expected participants reached warning: %v", c.Sender().ID, errSendWarning)
 			}
 		}
 	}
 
+	var waitlistPosition int
+	if !registered {
+		waitlistPosition, err = h.eventParticipantService.GetWaitlistPosition(context.Background(), eventID, c.Sender().ID)
+		if err != nil {
+			h.logger.Errorf("(user: %d) error while get waitlist position: %v", c.Sender().ID, err)
+			return c.Edit(
+				banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+				h.layout.Markup(c, "user:events:back", struct {
+					Page string
+				}{
+					Page: page,
+				}),
+			)
+		}
+	}
+
 	endTime := event.EndTime.In(location.Location()).Format("02.01.2006 15:04")
 	if event.EndTime.Year() == 1 {
 		endTime = ""
@@ -813,40 +1180,42 @@ func (h Handler) event(c tele.Context) error {
 		ID:   eventID,
 		Page: page,
 	})
-	if registered {
-		markup.InlineKeyboard = append(
-			[][]tele.InlineButton{{*h.layout.Button(c, "user:events:event:cancel_registration", struct {
-				ID   string
-				Page string
-			}{
-				ID:   eventID,
-				Page: page,
-			}).Inline()}},
-			markup.InlineKeyboard...,
-		)
-	} else {
+	var registrationButton string
+	switch {
+	case registered:
+		registrationButton = "user:events:event:cancel_registration"
+	case waitlistPosition > 0:
+		registrationButton = "user:events:event:leave_waitlist"
+	case isFull && registrationActive && roleAllowed:
+		registrationButton = "user:events:event:join_waitlist"
+	default:
+		registrationButton = "user:events:event:register"
+	}
+	markup.InlineKeyboard = append(
+		[][]tele.InlineButton{{*h.layout.Button(c, registrationButton, struct {
+			ID   string
+			Page string
+		}{
+			ID:   eventID,
+			Page: page,
+		}).Inline()}},
+		markup.InlineKeyboard...,
+	)
+
+	if registered && !pending && event.MaxGuests > 0 {
 		markup.InlineKeyboard = append(
-			[][]tele.InlineButton{{*h.layout.Button(c, "user:events:event:register", struct {
+			[][]tele.InlineButton{markup.InlineKeyboard[0], {*h.layout.Button(c, "user:events:event:guests:open", struct {
 				ID   string
 				Page string
 			}{
 				ID:   eventID,
 				Page: page,
 			}).Inline()}},
-			markup.InlineKeyboard...,
+			markup.InlineKeyboard[1:]...,
 		)
 	}
 
-	var maxRegistrationEnd time.Time
-	if user.Role == valueobject.Student {
-		maxRegistrationEnd = event.RegistrationEnd
-	} else {
-		if event.RegistrationEnd.Before(utils.GetMaxRegisteredEndTime(event.StartTime)) {
-			maxRegistrationEnd = event.RegistrationEnd
-		} else {
-			maxRegistrationEnd = utils.GetMaxRegisteredEndTime(event.StartTime)
-		}
-	}
+	maxRegistrationEnd := h.eventService.RegistrationDeadline(event, user.Role)
 
 	_ = c.Edit(
 		banner.Events.Caption(h.layout.Text(c, "event_text", struct {
@@ -859,8 +1228,12 @@ func (h Handler) event(c tele.Context) error {
 			RegistrationEnd       string
 			MaxParticipants       int
 			ParticipantsCount     int
+			RoleSeats             []dto.RoleSeats
 			AfterRegistrationText string
 			IsRegistered          bool
+			IsPending             bool
+			MeetingURL            string
+			WaitlistPosition      int
 		}{
 			Name:                  event.Name,
 			ClubName:              club.Name,
@@ -871,8 +1244,12 @@ func (h Handler) event(c tele.Context) error {
 			RegistrationEnd:       maxRegistrationEnd.In(location.Location()).Format("02.01.2006 15:04"),
 			MaxParticipants:       event.MaxParticipants,
 			ParticipantsCount:     participantsCount,
+			RoleSeats:             roleSeats,
 			AfterRegistrationText: event.AfterRegistrationText,
+			MeetingURL:            event.MeetingURL,
 			IsRegistered:          registered,
+			IsPending:             pending,
+			WaitlistPosition:      waitlistPosition,
 		})),
 		markup,
 	)
@@ -885,7 +1262,6 @@ func (h Handler) eventCancelRegistration(c tele.Context) error {
 		return errorz.ErrInvalidCallbackData
 	}
 	eventID := callbackData[0]
-	page := callbackData[1]
 
 	err := h.eventParticipantService.Delete(context.Background(), eventID, c.Sender().ID)
 	if err != nil {
@@ -896,236 +1272,186 @@ func (h Handler) eventCancelRegistration(c tele.Context) error {
 		)
 	}
 
-	event, err := h.eventService.Get(context.Background(), eventID)
-	if err != nil {
-		h.logger.Errorf("(user: %d) error while get event: %v", c.Sender().ID, err)
-		return c.Edit(
-			banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
-			h.layout.Markup(c, "user:events:back", struct {
-				Page string
-			}{
-				Page: page,
-			}),
-		)
-	}
+	return h.event(c)
+}
 
-	club, err := h.clubService.Get(context.Background(), event.ClubID)
-	if err != nil {
-		h.logger.Errorf("(user: %d) error while get club: %v", c.Sender().ID, err)
-		return c.Edit(
-			banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
-			h.layout.Markup(c, "user:events:back", struct {
-				Page string
-			}{
-				Page: page,
-			}),
-		)
+func (h Handler) eventJoinWaitlist(c tele.Context) error {
+	callbackData := strings.Split(c.Callback().Data, " ")
+	if len(callbackData) != 2 {
+		return errorz.ErrInvalidCallbackData
 	}
+	eventID := callbackData[0]
+	h.logger.Infof("(user: %d) join event waitlist (event_id=%s)", c.Sender().ID, eventID)
 
-	user, err := h.userService.Get(context.Background(), c.Sender().ID)
+	answers, answered, err := h.askRegistrationForm(c, eventID, callbackData[1])
 	if err != nil {
-		h.logger.Errorf("(user: %d) error while get user: %v", c.Sender().ID, err)
+		h.logger.Errorf("(user: %d) error while get event registration form: %v", c.Sender().ID, err)
 		return c.Edit(
 			banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
-			h.layout.Markup(c, "user:events:back", struct {
-				Page string
-			}{
-				Page: page,
-			}),
+			h.layout.Markup(c, "core:hide"),
 		)
 	}
-
-	var registered bool
-	_, errGetParticipant := h.eventParticipantService.Get(context.Background(), eventID, c.Sender().ID)
-	if errGetParticipant != nil {
-		if !errors.Is(errGetParticipant, gorm.ErrRecordNotFound) {
-			h.logger.Errorf("(user: %d) error while get participant: %v", c.Sender().ID, errGetParticipant)
-			return c.Edit(
-				banner.Events.Caption(h.layout.Text(c, "technical_issues", errGetParticipant.Error())),
-				h.layout.Markup(c, "user:events:back", struct {
-					Page string
-				}{
-					Page: page,
-				}),
-			)
-		}
-	} else {
-		registered = true
+	if !answered {
+		return nil
 	}
 
-	participantsCount, err := h.eventParticipantService.CountByEventID(context.Background(), eventID)
-	if err != nil {
-		h.logger.Errorf("(user: %d) error while get participants count: %v", c.Sender().ID, err)
+	_, err = h.eventParticipantService.JoinWaitlist(context.Background(), eventID, c.Sender().ID, answers)
+	switch {
+	case errors.Is(err, errorz.ErrInvalidAnswer):
+		return c.Respond(&tele.CallbackResponse{
+			Text:      h.layout.Text(c, "invalid_registration_form"),
+			ShowAlert: true,
+		})
+	case errors.Is(err, errorz.ErrRegistrationClosed):
+		return c.Respond(&tele.CallbackResponse{
+			Text:      h.layout.Text(c, "registration_ended"),
+			ShowAlert: true,
+		})
+	case errors.Is(err, errorz.ErrRegistrationBlocked):
+		return c.Respond(&tele.CallbackResponse{
+			Text:      h.layout.Text(c, "registration_blocked"),
+			ShowAlert: true,
+		})
+	case errors.Is(err, errorz.ErrRoleNotAllowed):
+		return c.Respond(&tele.CallbackResponse{
+			Text:      h.layout.Text(c, "not_allowed_role"),
+			ShowAlert: true,
+		})
+	case err != nil && !errors.Is(err, gorm.ErrDuplicatedKey):
+		h.logger.Errorf("(user: %d) error while join event waitlist: %v", c.Sender().ID, err)
 		return c.Edit(
 			banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
-			h.layout.Markup(c, "user:events:back", struct {
-				Page string
-			}{
-				Page: page,
-			}),
+			h.layout.Markup(c, "core:hide"),
 		)
 	}
 
-	if c.Callback().Unique == "event_register" {
-		if !registered {
-			var roleAllowed bool
-			var registrationActive bool
-			for _, role := range event.AllowedRoles {
-				if role == string(user.Role) {
-					roleAllowed = true
-				}
-			}
-
-			if user.Role == valueobject.Student {
-				registrationActive = event.RegistrationEnd.After(time.Now().In(location.Location()))
-			} else {
-				registrationActive = utils.GetMaxRegisteredEndTime(event.StartTime).After(time.Now().In(location.Location())) && event.RegistrationEnd.After(time.Now().In(location.Location()))
-			}
+	return h.event(c)
+}
 
-			if (event.MaxParticipants == 0 || participantsCount < event.MaxParticipants) && registrationActive && roleAllowed {
-				_, err = h.eventParticipantService.Register(context.Background(), eventID, c.Sender().ID)
-				if err != nil {
-					h.logger.Errorf("(user: %d) error while register to event: %v", c.Sender().ID, err)
-					return c.Edit(
-						banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
-						h.layout.Markup(c, "user:events:back", struct {
-							Page string
-						}{
-							Page: page,
-						}),
-					)
-				}
-
-				if participantsCount+1 == event.ExpectedParticipants {
-					errSendWarning := h.notificationService.SendClubWarning(event.ClubID,
-						h.layout.Text(c, "expected_participants_reached_warning", struct {
-							Name              string
-							ParticipantsCount int
-						}{
-							Name:              event.Name,
-							ParticipantsCount: participantsCount + 1,
-						}),
-						h.layout.Markup(c, "core:hide"),
-					)
-					if errSendWarning != nil {
-						h.logger.Errorf("(user: %d) error while send expected participants reached warning: %v", c.Sender().ID, errSendWarning)
-					}
-				}
-
-				if participantsCount+1 == event.MaxParticipants {
-					errSendWarning := h.notificationService.SendClubWarning(event.ClubID,
-						h.layout.Text(c, "max_participants_reached_warning", struct {
-							Name              string
-							ParticipantsCount int
-						}{
-							Name:              event.Name,
-							ParticipantsCount: participantsCount + 1,
-						}),
-						h.layout.Markup(c, "core:hide"),
-					)
-					if errSendWarning != nil {
-						h.logger.Errorf("(user: %d) error while send expected participants reached warning: %v", c.Sender().ID, errSendWarning)
-					}
-				}
-				registered = true
-			} else {
-				switch {
-				case event.RegistrationEnd.Before(time.Now().In(location.Location())):
-					return c.Respond(&tele.CallbackResponse{
-						Text:      h.layout.Text(c, "registration_ended"),
-						ShowAlert: true,
-					})
-				case event.MaxParticipants > 0 && participantsCount >= event.MaxParticipants:
-					return c.Respond(&tele.CallbackResponse{
-						Text:      h.layout.Text(c, "max_participants_reached"),
-						ShowAlert: true,
-					})
-				case !roleAllowed:
-					return c.Respond(&tele.CallbackResponse{
-						Text:      h.layout.Text(c, "not_allowed_role"),
-						ShowAlert: true,
-					})
-				}
-			}
-		}
+// askRegistrationForm asks the user the questions of the event registration form, if the event has them.
+// The second result is false if the user went back to the event from the form
+func (h Handler) askRegistrationForm(c tele.Context, eventID, page string) ([]entity.EventAnswer, bool, error) {
+	questions, err := h.eventParticipantService.GetQuestions(context.Background(), eventID)
+	if err != nil || len(questions) == 0 {
+		return nil, true, err
 	}
 
-	endTime := event.EndTime.In(location.Location()).Format("02.01.2006 15:04")
-	if event.EndTime.Year() == 1 {
-		endTime = ""
-	}
-
-	markup := h.layout.Markup(c, "user:events:event", struct {
+	answers, answered := registrationForm.New(h.layout, h.input, h.logger).Ask(c, questions, h.layout.Markup(c, "user:events:event:form", struct {
 		ID   string
 		Page string
 	}{
 		ID:   eventID,
 		Page: page,
-	})
-	if registered {
-		markup.InlineKeyboard = append(
-			[][]tele.InlineButton{{*h.layout.Button(c, "user:events:event:cancel_registration", struct {
-				ID   string
-				Page string
-			}{
-				ID:   eventID,
-				Page: page,
-			}).Inline()}},
-			markup.InlineKeyboard...,
-		)
-	} else {
-		markup.InlineKeyboard = append(
-			[][]tele.InlineButton{{*h.layout.Button(c, "user:events:event:register", struct {
-				ID   string
-				Page string
-			}{
-				ID:   eventID,
-				Page: page,
-			}).Inline()}},
-			markup.InlineKeyboard...,
+	}))
+	return answers, answered, nil
+}
+
+func (h Handler) eventLeaveWaitlist(c tele.Context) error {
+	callbackData := strings.Split(c.Callback().Data, " ")
+	if len(callbackData) != 2 {
+		return errorz.ErrInvalidCallbackData
+	}
+	eventID := callbackData[0]
+	h.logger.Infof("(user: %d) leave event waitlist (event_id=%s)", c.Sender().ID, eventID)
+
+	err := h.eventParticipantService.LeaveWaitlist(context.Background(), eventID, c.Sender().ID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while leave event waitlist: %v", c.Sender().ID, err)
+		return c.Edit(
+			banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+			h.layout.Markup(c, "core:hide"),
 		)
 	}
 
-	var maxRegistrationEnd time.Time
-	if user.Role == valueobject.Student {
-		maxRegistrationEnd = event.RegistrationEnd
-	} else {
-		if event.RegistrationEnd.Before(utils.GetMaxRegisteredEndTime(event.StartTime)) {
-			maxRegistrationEnd = event.RegistrationEnd
-		} else {
-			maxRegistrationEnd = utils.GetMaxRegisteredEndTime(event.StartTime)
+	return h.event(c)
+}
+
+func (h Handler) waitlistConfirm(c tele.Context) error {
+	eventID := c.Callback().Data
+	h.logger.Infof("(user: %d) confirm waitlist offer (event_id=%s)", c.Sender().ID, eventID)
+
+	participant, err := h.eventParticipantService.ConfirmWaitlistOffer(context.Background(), eventID, c.Sender().ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, errorz.ErrWaitlistOfferExpired), errors.Is(err, errorz.ErrEventFull):
+			return c.Edit(
+				h.layout.Text(c, "waitlist_offer_expired"),
+				h.layout.Markup(c, "core:hide"),
+			)
+		case errors.Is(err, errorz.ErrEventCancelled):
+			return c.Edit(
+				h.layout.Text(c, "event_cancelled_alert"),
+				h.layout.Markup(c, "core:hide"),
+			)
+		case errors.Is(err, errorz.ErrRegistrationClosed):
+			return c.Edit(
+				h.layout.Text(c, "registration_ended"),
+				h.layout.Markup(c, "core:hide"),
+			)
+		case errors.Is(err, errorz.ErrRegistrationBlocked):
+			return c.Edit(
+				h.layout.Text(c, "registration_blocked"),
+				h.layout.Markup(c, "core:hide"),
+			)
+		case errors.Is(err, errorz.ErrRoleNotAllowed):
+			return c.Edit(
+				h.layout.Text(c, "not_allowed_role"),
+				h.layout.Markup(c, "core:hide"),
+			)
 		}
+		h.logger.Errorf("(user: %d) error while confirm waitlist offer: %v", c.Sender().ID, err)
+		return c.Edit(
+			h.layout.Text(c, "technical_issues", err.Error()),
+			h.layout.Markup(c, "core:hide"),
+		)
 	}
 
-	_ = c.Edit(
-		banner.Events.Caption(h.layout.Text(c, "event_text", struct {
+	event, err := h.eventService.Get(context.Background(), eventID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while get event: %v", c.Sender().ID, err)
+		return c.Edit(
+			h.layout.Text(c, "technical_issues", err.Error()),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+
+	return c.Edit(
+		h.layout.Text(c, "waitlist_offer_confirmed", struct {
 			Name                  string
-			ClubName              string
-			Description           string
-			Location              string
-			StartTime             string
-			EndTime               string
-			RegistrationEnd       string
-			MaxParticipants       int
-			ParticipantsCount     int
 			AfterRegistrationText string
-			IsRegistered          bool
+			IsPending             bool
+			MeetingURL            string
 		}{
 			Name:                  event.Name,
-			ClubName:              club.Name,
-			Description:           event.Description,
-			Location:              event.Location,
-			StartTime:             event.StartTime.In(location.Location()).Format("02.01.2006 15:04"),
-			EndTime:               endTime,
-			RegistrationEnd:       maxRegistrationEnd.In(location.Location()).Format("02.01.2006 15:04"),
-			MaxParticipants:       event.MaxParticipants,
-			ParticipantsCount:     participantsCount,
 			AfterRegistrationText: event.AfterRegistrationText,
-			IsRegistered:          registered,
-		})),
-		markup,
+			MeetingURL:            event.MeetingURL,
+			IsPending:             participant.IsPending(),
+		}),
+		h.layout.Markup(c, "waitlist:confirmed", struct {
+			ID string
+		}{
+			ID: eventID,
+		}),
+	)
+}
+
+func (h Handler) waitlistDecline(c tele.Context) error {
+	eventID := c.Callback().Data
+	h.logger.Infof("(user: %d) decline waitlist offer (event_id=%s)", c.Sender().ID, eventID)
+
+	err := h.eventParticipantService.LeaveWaitlist(context.Background(), eventID, c.Sender().ID)
+	if err != nil {
+		h.logger.Errorf("(user: %d) error while decline waitlist offer: %v", c.Sender().ID, err)
+		return c.Edit(
+			h.layout.Text(c, "technical_issues", err.Error()),
+			h.layout.Markup(c, "core:hide"),
+		)
+	}
+
+	return c.Edit(
+		h.layout.Text(c, "waitlist_offer_declined"),
+		h.layout.Markup(c, "core:hide"),
 	)
-	return nil
 }
 
 func (h Handler) eventExportToICS(c tele.Context) error {
@@ -1141,7 +1467,25 @@ func (h Handler) eventExportToICS(c tele.Context) error {
 		)
 	}
 
-	ics, err := calendar.ExportEventToICS(*event)
+	var series *entity.EventSeries
+	if event.IsRecurring() {
+		series, err = h.eventService.GetSeries(context.Background(), *event.SeriesID)
+		if err != nil {
+			h.logger.Errorf("(user: %d) error while get event series: %v", c.Sender().ID, err)
+			return c.Edit(
+				banner.Events.Caption(h.layout.Text(c, "technical_issues", err.Error())),
+				h.layout.Markup(c, "core:hide"),
+			)
+		}
+	}
+
+	// Ссылка на встречу попадает в календарь только у подтверждённых участников
+	participant, err := h.eventParticipantService.Get(context.Background(), eventID, c.Sender().ID)
+	if err != nil || participant.IsPending() {
+		event.MeetingURL = ""
+	}
+
+	ics, err := calendar.ExportEventToICS(*event, series)
 	if err != nil {
 		h.logger.Errorf("(user: %d) error while export event to ics: %v", c.Sender().ID, err)
 		return c.Edit(
@@ -1224,17 +1568,21 @@ func (h Handler) myEvents(c tele.Context) error {
 	markup := c.Bot().NewMarkup()
 	for _, event := range events {
 		rows = append(rows, markup.Row(*h.layout.Button(c, "user:myEvents:event", struct {
-			ID        string
-			Name      string
-			Page      int
-			IsOver    bool
-			IsVisited bool
+			ID          string
+			Name        string
+			Page        int
+			IsOver      bool
+			IsVisited   bool
+			IsCancelled bool
+			HasConflict bool
 		}{
-			ID:        event.ID,
-			Name:      event.Name,
-			Page:      p,
-			IsOver:    event.IsOver(0),
-			IsVisited: event.IsVisited,
+			ID:          event.ID,
+			Name:        event.Name,
+			Page:        p,
+			IsOver:      event.IsOver(0),
+			IsVisited:   event.IsVisited,
+			IsCancelled: event.IsCancelled,
+			HasConflict: event.HasConflict,
 		})))
 	}
 
@@ -1375,15 +1723,16 @@ func (h Handler) myEvent(c tele.Context) error {
 		endTime = ""
 	}
 
-	var maxRegistrationEnd time.Time
-	if user.Role == valueobject.Student {
-		maxRegistrationEnd = event.RegistrationEnd
-	} else {
-		if event.RegistrationEnd.Before(utils.GetMaxRegisteredEndTime(event.StartTime)) {
-			maxRegistrationEnd = event.RegistrationEnd
-		} else {
-			maxRegistrationEnd = utils.GetMaxRegisteredEndTime(event.StartTime)
-		}
+	maxRegistrationEnd := h.eventService.RegistrationDeadline(event, user.Role)
+
+	markupKey := "user:myEvents:event"
+	if event.IsCancelled() {
+		markupKey = "user:myEvents:event:cancelled"
+	}
+
+	var meetingURL string
+	if !eventParticipant.IsPending() {
+		meetingURL = event.MeetingURL
 	}
 
 	_ = c.Edit(
@@ -1398,8 +1747,11 @@ func (h Handler) myEvent(c tele.Context) error {
 			MaxParticipants       int
 			ParticipantsCount     int
 			AfterRegistrationText string
+			MeetingURL            string
 			IsOver                bool
 			IsVisited             bool
+			IsCancelled           bool
+			CancelReason          string
 		}{
 			Name:                  event.Name,
 			ClubName:              club.Name,
@@ -1411,10 +1763,13 @@ func (h Handler) myEvent(c tele.Context) error {
 			MaxParticipants:       event.MaxParticipants,
 			ParticipantsCount:     participantsCount,
 			AfterRegistrationText: event.AfterRegistrationText,
+			MeetingURL:            meetingURL,
 			IsOver:                event.IsOver(0),
 			IsVisited:             eventParticipant.IsEventQr || eventParticipant.IsUserQr,
+			IsCancelled:           event.IsCancelled(),
+			CancelReason:          event.CancelReason,
 		})),
-		h.layout.Markup(c, "user:myEvents:event", struct {
+		h.layout.Markup(c, markupKey, struct {
 			ID   string
 			Page string
 		}{
@@ -1912,11 +2267,42 @@ func (h Handler) UserSetup(group *tele.Group) {
 	group.Handle(h.layout.Callback("user:events:prev_page"), h.eventsList)
 	group.Handle(h.layout.Callback("user:events:next_page"), h.eventsList)
 	group.Handle(h.layout.Callback("user:events:back"), h.eventsList)
+	group.Handle(h.layout.Callback("mainMenu:search"), h.search)
+	group.Handle(h.layout.Callback("search:again"), h.search)
+	group.Handle(h.layout.Callback("search:prev_page"), h.searchPage)
+	group.Handle(h.layout.Callback("search:next_page"), h.searchPage)
+	group.Handle(h.layout.Callback("search:event"), h.event)
+	group.Handle(h.layout.Callback("search:club"), h.clubIntro)
+	group.Handle(h.layout.Callback("user:events:filter:category"), h.eventsFilter)
+	group.Handle(h.layout.Callback("user:events:filter:club"), h.eventsFilter)
+	group.Handle(h.layout.Callback("user:events:filter:period"), h.eventsFilter)
+	group.Handle(h.layout.Callback("user:events:filter:set_category"), h.eventsSetFilter)
+	group.Handle(h.layout.Callback("user:events:filter:set_category:any"), h.eventsSetFilter)
+	group.Handle(h.layout.Callback("user:events:filter:set_club"), h.eventsSetFilter)
+	group.Handle(h.layout.Callback("user:events:filter:set_club:any"), h.eventsSetFilter)
+	group.Handle(h.layout.Callback("user:events:filter:set_period"), h.eventsSetFilter)
+	group.Handle(h.layout.Callback("user:events:filter:set_period:any"), h.eventsSetFilter)
+	group.Handle(h.layout.Callback("user:events:filter:reset"), h.eventsSetFilter)
 	group.Handle(h.layout.Callback("user:events:event"), h.event)
 	group.Handle(h.layout.Callback("user:events:event:cancel_registration"), h.eventCancelRegistration)
 	group.Handle(h.layout.Callback("user:myEvents:event:export"), h.eventExportToICS)
 	group.Handle(h.layout.Callback("user:myEvents:event:cancel_registration"), h.myEventCancelRegistration)
 	group.Handle(h.layout.Callback("user:events:event:register"), h.event)
+	group.Handle(h.layout.Callback("user:events:event:form:back"), h.event)
+	group.Handle(h.layout.Callback("user:events:event:guests:open"), h.eventGuests)
+	group.Handle(h.layout.Callback("user:events:event:guests:back"), h.eventGuests)
+	group.Handle(h.layout.Callback("user:events:event:guests:add"), h.eventGuestAdd)
+	group.Handle(h.layout.Callback("user:events:event:guests:remove"), h.eventGuestRemove)
+	group.Handle(h.layout.Callback("user:events:event:guests:event"), h.event)
+	group.Handle(h.layout.Callback("user:events:event:join_waitlist"), h.eventJoinWaitlist)
+	group.Handle(h.layout.Callback("user:events:event:register_anyway"), h.event)
+	group.Handle(h.layout.Callback("user:events:event:cancel_conflict"), h.eventCancelConflict)
+	group.Handle(h.layout.Callback("user:events:event:leave_waitlist"), h.eventLeaveWaitlist)
+	group.Handle(h.layout.Callback("waitlist:confirm"), h.waitlistConfirm)
+	group.Handle(h.layout.Callback("waitlist:decline"), h.waitlistDecline)
+	group.Handle(h.layout.Callback("feedback:rate:1"), h.feedbackRate)
+	group.Handle(h.layout.Callback("feedback:comment"), h.feedbackComment)
+	group.Handle(h.layout.Callback("feedback:comment:back"), h.feedbackCommentBack)
 
 	group.Handle(h.layout.Callback("mainMenu:personalAccount"), h.personalAccount)
 	group.Handle(h.layout.Callback("personalAccount:my_events"), h.myEvents)
@@ -1927,6 +2313,12 @@ func (h Handler) UserSetup(group *tele.Group) {
 	group.Handle(h.layout.Callback("personalAccount:change_role"), h.changeRole)
 	group.Handle(h.layout.Callback("changeRole:student:resend_email"), h.resendChangeRoleEmailConfirmationCode)
 	group.Handle(h.layout.Callback("personalAccount:back"), h.personalAccount)
+	group.Handle(h.layout.Callback("personalAccount:calendar"), h.calendarFeed)
+	group.Handle(h.layout.Callback("personalAccount:calendar:rotate"), h.rotateCalendarFeed)
+	group.Handle(h.layout.Callback("personalAccount:reminders"), h.reminders)
+	group.Handle(h.layout.Callback("personalAccount:reminder"), h.toggleReminder)
+	group.Handle(h.layout.Callback("personalAccount:reminders:mute"), h.toggleRemindersMute)
+	group.Handle(h.layout.Callback("personalAccount:reminders:reset"), h.resetReminders)
 
 	group.Handle(h.layout.Callback("mainMenu:qr"), h.qrCode)
 
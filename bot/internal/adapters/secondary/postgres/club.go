@@ -60,6 +60,10 @@ func (s *ClubRepository) Delete(ctx context.Context, id string) error {
 		return err
 	}
 	err = s.db.WithContext(ctx).Where("club_id = ?", id).Delete(&entity.Event{}).Error
+	if err != nil {
+		return err
+	}
+	err = s.db.WithContext(ctx).Where("club_id = ?", id).Delete(&entity.EventCoHost{}).Error
 	return err
 }
 
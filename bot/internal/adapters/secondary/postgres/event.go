@@ -2,12 +2,16 @@ package postgres
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/location"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/common/errorz"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/dto"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
 )
@@ -24,14 +28,19 @@ func NewEventRepository(db *gorm.DB) *EventRepository {
 
 // Create is a function that creates a new event in the database.
 func (s *EventRepository) Create(ctx context.Context, event *entity.Event) (*entity.Event, error) {
-	err := s.db.WithContext(ctx).Create(&event).Error
+	err := dbFromContext(ctx, s.db).Transaction(func(tx *gorm.DB) error {
+		if err := bookVenue(tx, event); err != nil {
+			return err
+		}
+		return tx.Create(&event).Error
+	})
 	return event, err
 }
 
 // Get is a function that gets an event from the database by id.
 func (s *EventRepository) Get(ctx context.Context, id string) (*entity.Event, error) {
 	var event entity.Event
-	err := s.db.WithContext(ctx).Where("id = ?", id).First(&event).Error
+	err := dbFromContext(ctx, s.db).Where("id = ?", id).First(&event).Error
 	return &event, err
 }
 
@@ -42,24 +51,30 @@ func (s *EventRepository) GetEventByID(ctx context.Context, eventID string) (*en
 
 func (s *EventRepository) GetByQRCodeID(ctx context.Context, qrCodeID string) (*entity.Event, error) {
 	var event entity.Event
-	err := s.db.WithContext(ctx).Where("qr_code_id = ?", qrCodeID).First(&event).Error
+	err := dbFromContext(ctx, s.db).Where("qr_code_id = ?", qrCodeID).First(&event).Error
+	return &event, err
+}
+
+func (s *EventRepository) GetByExitQRCodeID(ctx context.Context, qrCodeID string) (*entity.Event, error) {
+	var event entity.Event
+	err := dbFromContext(ctx, s.db).Where("exit_qr_code_id = ?", qrCodeID).First(&event).Error
 	return &event, err
 }
 
 func (s *EventRepository) GetMany(ctx context.Context, ids []string) ([]entity.Event, error) {
 	var events []entity.Event
-	err := s.db.WithContext(ctx).Where("id IN ?", ids).Find(&events).Error
+	err := dbFromContext(ctx, s.db).Where("id IN ?", ids).Find(&events).Error
 	return events, err
 }
 
 // GetAll is a function that gets all events from the database.
 func (s *EventRepository) GetAll(ctx context.Context) ([]entity.Event, error) {
 	var events []entity.Event
-	err := s.db.WithContext(ctx).Find(&events).Error
+	err := dbFromContext(ctx, s.db).Find(&events).Error
 	return events, err
 }
 
-// GetByClubID is a function that gets events of a club from the database.
+// GetByClubID is a function that gets events of a club (including co-hosted ones) from the database.
 // It returns events in the order of start_time (upcoming first [start_time ASC], then past [start_time DESC]).
 // If club has more events than limit, it returns only first limit events.
 // If club has fewer events than limit, it returns all events.
@@ -72,17 +87,17 @@ func (s *EventRepository) GetByClubID(ctx context.Context, limit, offset int, cl
 
 	// Count total upcoming events for this club.
 	var upcomingCount int64
-	if err := s.db.WithContext(ctx).
+	if err := dbFromContext(ctx, s.db).
 		Model(&entity.Event{}).
-		Where("club_id = ? AND start_time > ?", clubID, currentTime).
+		Where(clubEventsCondition+" AND events.start_time > ?", clubID, clubID, currentTime).
 		Count(&upcomingCount).Error; err != nil {
 		return nil, err
 	}
 
 	// If offset is within upcoming events, get upcoming events
 	if offset < int(upcomingCount) {
-		if err := s.db.WithContext(ctx).
-			Where("club_id = ? AND start_time > ?", clubID, currentTime).
+		if err := dbFromContext(ctx, s.db).
+			Where(clubEventsCondition+" AND events.start_time > ?", clubID, clubID, currentTime).
 			Order("start_time asc").
 			Limit(limit).
 			Offset(offset).
@@ -96,8 +111,8 @@ func (s *EventRepository) GetByClubID(ctx context.Context, limit, offset int, cl
 	if remainingLimit > 0 {
 		pastOffset := max(0, offset-int(upcomingCount)) // Adjust offset for past events
 		var pastEvents []entity.Event
-		if err := s.db.WithContext(ctx).
-			Where("club_id = ? AND start_time <= ?", clubID, currentTime).
+		if err := dbFromContext(ctx, s.db).
+			Where(clubEventsCondition+" AND events.start_time <= ?", clubID, clubID, currentTime).
 			Order("start_time desc").
 			Limit(remainingLimit).
 			Offset(pastOffset).
@@ -111,8 +126,8 @@ func (s *EventRepository) GetByClubID(ctx context.Context, limit, offset int, cl
 }
 
 // GetFutureByClubID retrieves future events for a specific club from the database.
-// The events are filtered by club ID and a start time greater than the current time
-// minus the additional time parameter. The results are ordered and paginated
+// The events are filtered by club ID (co-hosted events are included), a start time greater than the current time
+// minus the additional time parameter and the status: drafts and cancelled events are skipped. The results are ordered and paginated
 // according to the provided parameters.
 //
 // Parameters:
@@ -135,8 +150,9 @@ func (s *EventRepository) GetFutureByClubID(
 	additionalTime time.Duration,
 ) ([]entity.Event, error) {
 	var events []entity.Event
-	err := s.db.WithContext(ctx).
-		Where("club_id = ? AND start_time > ?", clubID, time.Now().In(location.Location()).Add(-additionalTime)).
+	err := dbFromContext(ctx, s.db).
+		Where(clubEventsCondition+" AND events.start_time > ?", clubID, clubID, time.Now().In(location.Location()).Add(-additionalTime)).
+		Where("events.status = ?", entity.EventStatusActive).
 		Order(order).
 		Limit(limit).
 		Offset(offset).
@@ -144,18 +160,77 @@ func (s *EventRepository) GetFutureByClubID(
 	return events, err
 }
 
-// GetUpcomingEvents returns all events that start before the given time
+// GetUpcomingEvents returns all not cancelled events that start before the given time
 func (s *EventRepository) GetUpcomingEvents(ctx context.Context, before time.Time) ([]entity.Event, error) {
 	var events []entity.Event
-	err := s.db.WithContext(ctx).
+	err := dbFromContext(ctx, s.db).
 		Where("start_time <= ? AND start_time > ?", before.In(location.Location()), time.Now().In(location.Location())).
+		Where("status <> ?", entity.EventStatusCancelled).
+		Find(&events).Error
+	return events, err
+}
+
+// GetEndedEvents returns not cancelled events that ended (or started, if the end time is not set) in [from, to)
+func (s *EventRepository) GetEndedEvents(ctx context.Context, from, to time.Time) ([]entity.Event, error) {
+	var events []entity.Event
+	err := dbFromContext(ctx, s.db).
+		Where("GREATEST(end_time, start_time) >= ? AND GREATEST(end_time, start_time) < ?", from, to).
+		Where("status <> ?", entity.EventStatusCancelled).
+		Find(&events).Error
+	return events, err
+}
+
+// GetDraftsBySeriesID returns unpublished occurrences of the series
+func (s *EventRepository) GetDraftsBySeriesID(ctx context.Context, seriesID string) ([]entity.Event, error) {
+	var events []entity.Event
+	err := dbFromContext(ctx, s.db).
+		Where("series_id = ? AND status = ?", seriesID, entity.EventStatusDraft).
+		Order("start_time ASC").
+		Find(&events).Error
+	return events, err
+}
+
+// GetScheduledDrafts returns drafts whose publication time has come
+func (s *EventRepository) GetScheduledDrafts(ctx context.Context, before time.Time) ([]entity.Event, error) {
+	var events []entity.Event
+	err := dbFromContext(ctx, s.db).
+		Where("status = ? AND publish_at IS NOT NULL AND publish_at <= ?", entity.EventStatusDraft, before).
+		Find(&events).Error
+	return events, err
+}
+
+// GetFutureBySeriesID returns not cancelled events of the series that start not earlier than from, ordered by start time
+func (s *EventRepository) GetFutureBySeriesID(ctx context.Context, seriesID string, from time.Time) ([]entity.Event, error) {
+	var events []entity.Event
+	err := dbFromContext(ctx, s.db).
+		Where("series_id = ? AND start_time >= ? AND status <> ?", seriesID, from, entity.EventStatusCancelled).
+		Order("start_time asc").
 		Find(&events).Error
 	return events, err
 }
 
+// GetVenueBookings returns events in the venue that overlap the given time slot, cancelled and online events
+// don't book the venue. Events without the end time are considered to last one hour, like in entity.Event.End
+func (s *EventRepository) GetVenueBookings(ctx context.Context, venueID string, start, end time.Time, excludeID string) ([]entity.Event, error) {
+	return getVenueBookings(dbFromContext(ctx, s.db), venueID, start, end, excludeID)
+}
+
+func getVenueBookings(db *gorm.DB, venueID string, start, end time.Time, excludeID string) ([]entity.Event, error) {
+	var events []entity.Event
+	query := db.
+		Where("venue_id = ? AND status <> ? AND format <> ?", venueID, entity.EventStatusCancelled, entity.EventFormatOnline).
+		Where("start_time < ?", end).
+		Where("(CASE WHEN end_time > start_time THEN end_time ELSE start_time + interval '1 hour' END) > ?", start)
+	if excludeID != "" {
+		query = query.Where("id <> ?", excludeID)
+	}
+	err := query.Order("start_time asc").Find(&events).Error
+	return events, err
+}
+
 // func (s *EventRepo) CountFutureByClubID(ctx context.Context, clubID string) (int64, error) {
 //	var count int64
-//	err := s.db.WithContext(ctx).
+//	err := dbFromContext(ctx, s.db).
 //		Where("club_id = ? AND start_time > ?", clubID, time.Now().In(location.Location)).
 //		Count(&count).Error
 //	return count, err
@@ -163,48 +238,213 @@ func (s *EventRepository) GetUpcomingEvents(ctx context.Context, before time.Tim
 
 // Update is a function that updates an event in the database.
 func (s *EventRepository) Update(ctx context.Context, event *entity.Event) (*entity.Event, error) {
-	err := s.db.WithContext(ctx).Save(&event).Error
+	err := dbFromContext(ctx, s.db).Save(&event).Error
 	return event, err
 }
 
+// UpdateAndBookVenue is a function that updates an event in the database, checking that its venue is free at the time of the event.
+//
+// Returns errorz.ErrVenueBooked if the venue is booked by another event.
+func (s *EventRepository) UpdateAndBookVenue(ctx context.Context, event *entity.Event) (*entity.Event, error) {
+	err := dbFromContext(ctx, s.db).Transaction(func(tx *gorm.DB) error {
+		if err := bookVenue(tx, event); err != nil {
+			return err
+		}
+		return tx.Save(&event).Error
+	})
+	return event, err
+}
+
+// bookVenue checks that the venue of the event is free at the time of the event.
+//
+// The venue row is locked for the duration of the transaction, so concurrent bookings of the venue
+// wait until the event is saved and can't take the same time slot
+func bookVenue(tx *gorm.DB, event *entity.Event) error {
+	if !event.BooksVenue() {
+		return nil
+	}
+
+	var venue entity.Venue
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", *event.VenueID).First(&venue).Error; err != nil {
+		return err
+	}
+
+	bookings, err := getVenueBookings(tx, venue.ID, event.StartTime, event.End(), event.ID)
+	if err != nil {
+		return err
+	}
+	if len(bookings) > 0 {
+		return errorz.ErrVenueBooked
+	}
+	return nil
+}
+
+// WithLock is a function that runs fn in a transaction while the event row is locked (SELECT ... FOR UPDATE).
+//
+// Concurrent calls for the same event and registrations on it (see EventParticipantRepository.Create) wait until fn returns.
+// The context passed to fn carries the transaction, event, participant and waitlist repositories called with it
+// run their queries in the transaction. Changes are rolled back if fn returns an error.
+func (s *EventRepository) WithLock(ctx context.Context, id string, fn func(ctx context.Context) error) error {
+	return dbFromContext(ctx, s.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&entity.Event{}).Error; err != nil {
+			return err
+		}
+		return fn(contextWithTx(ctx, tx))
+	})
+}
+
 // Delete is a function that deletes an event from the database.
 func (s *EventRepository) Delete(ctx context.Context, id string) error {
-	err := s.db.WithContext(ctx).Where("id = ?", id).Delete(&entity.Event{}).Error
+	err := dbFromContext(ctx, s.db).Where("id = ?", id).Delete(&entity.Event{}).Error
 	return err
 }
 
-// Count is a function that gets the count of events from the database.
-func (s *EventRepository) Count(ctx context.Context, role string) (int64, error) {
+// Count is a function that gets the count of events matching the filter from the database.
+func (s *EventRepository) Count(ctx context.Context, role string, filter dto.EventFilter) (int64, error) {
 	var count int64
-	query := s.db.WithContext(ctx).Model(&entity.Event{}).
-		Where("registration_end > ?", time.Now().In(location.Location())).
-		Where("? = ANY(allowed_roles)", role)
+	query := filterEvents(s.availableEvents(ctx, role), filter)
 
 	err := query.Count(&count).Error
 	return count, err
 }
 
+// CountByFilters counts available events for each category, club and period.
+//
+// Every group is counted with the other filters applied, e.g. category counts respect the chosen club and period
+func (s *EventRepository) CountByFilters(ctx context.Context, role string, filter dto.EventFilter) (dto.EventFilterCounts, error) {
+	counts := dto.EventFilterCounts{
+		Categories: make(map[entity.EventCategory]int64),
+		Periods:    make(map[dto.EventPeriod]int64),
+	}
+
+	categoryFilter := filter
+	categoryFilter.Category = ""
+	var categories []struct {
+		Category string
+		Count    int64
+	}
+	err := filterEvents(s.availableEvents(ctx, role), categoryFilter).
+		Select("unnest(events.categories) AS category, COUNT(*) AS count").
+		Group("category").
+		Scan(&categories).Error
+	if err != nil {
+		return counts, err
+	}
+	for _, c := range categories {
+		counts.Categories[entity.EventCategory(c.Category)] = c.Count
+	}
+
+	clubFilter := filter
+	clubFilter.ClubID = ""
+	err = filterEvents(s.availableEvents(ctx, role), clubFilter).
+		Select("clubs.id AS club_id, clubs.name AS club_name, COUNT(*) AS count").
+		Joins("JOIN clubs ON (clubs.id = events.club_id OR clubs.id IN (SELECT club_id FROM event_co_hosts WHERE event_id = events.id AND accepted)) AND clubs.deleted_at IS NULL").
+		Group("clubs.id, clubs.name").
+		Order("count DESC, clubs.name ASC").
+		Scan(&counts.Clubs).Error
+	if err != nil {
+		return counts, err
+	}
+
+	periodFilter := filter
+	periodFilter.Period = ""
+	now := time.Now()
+	columns := make([]string, 0, len(dto.EventPeriods))
+	args := make([]interface{}, 0, 2*len(dto.EventPeriods))
+	for _, period := range dto.EventPeriods {
+		from, to := period.Range(now)
+		columns = append(columns, fmt.Sprintf("COUNT(*) FILTER (WHERE events.start_time >= ? AND events.start_time < ?) AS %s", period))
+		args = append(args, from, to)
+	}
+	periods := make(map[string]interface{})
+	err = filterEvents(s.availableEvents(ctx, role), periodFilter).
+		Select(strings.Join(columns, ", "), args...).
+		Scan(&periods).Error
+	if err != nil {
+		return counts, err
+	}
+	for _, period := range dto.EventPeriods {
+		if count, ok := periods[string(period)].(int64); ok {
+			counts.Periods[period] = count
+		}
+	}
+
+	return counts, nil
+}
+
+// availableEvents returns the query of published events users can still register for (if role is empty, events with any role)
+func (s *EventRepository) availableEvents(ctx context.Context, role string) *gorm.DB {
+	query := dbFromContext(ctx, s.db).
+		Model(&entity.Event{}). // Use Model() to ensure deleted_at IS NULL filter
+		Where("events.registration_end > ? AND events.status = ?", time.Now().In(location.Location()), entity.EventStatusActive)
+
+	return withRole(query, role)
+}
+
+// publicEvents returns query of upcoming active events of the clubs shown to users
+func (s *EventRepository) publicEvents(ctx context.Context, role string) *gorm.DB {
+	query := dbFromContext(ctx, s.db).
+		Model(&entity.Event{}).
+		Where("events.start_time > ? AND events.status = ?", time.Now().In(location.Location()), entity.EventStatusActive).
+		Where("events.club_id IN (SELECT id FROM clubs WHERE should_show AND deleted_at IS NULL)")
+
+	return withRole(query, role)
+}
+
+// withRole keeps only events available for the role, empty role matches any role
+func withRole(query *gorm.DB, role string) *gorm.DB {
+	if role != "" {
+		query = query.Where("? = ANY(events.allowed_roles)", role)
+	}
+	return query
+}
+
+// filterEvents applies the filter to the events query
+func filterEvents(query *gorm.DB, filter dto.EventFilter) *gorm.DB {
+	if filter.Category != "" {
+		query = query.Where("? = ANY(events.categories)", filter.Category.String())
+	}
+	if filter.ClubID != "" {
+		query = query.Where(clubEventsCondition, filter.ClubID, filter.ClubID)
+	}
+	if filter.Period != "" {
+		from, to := filter.Period.Range(time.Now())
+		query = query.Where("events.start_time >= ? AND events.start_time < ?", from, to)
+	}
+	return query
+}
+
 func (s *EventRepository) CountByClubID(ctx context.Context, clubID string) (int64, error) {
 	var count int64
 
-	err := s.db.WithContext(ctx).Model(&entity.Event{}).
-		Where("club_id = ? AND deleted_at IS NULL", clubID).
+	err := dbFromContext(ctx, s.db).Model(&entity.Event{}).
+		Where(clubEventsCondition+" AND events.deleted_at IS NULL", clubID, clubID).
 		Count(&count).Error
 	return count, err
 }
 
-// GetWithPagination is a function that gets a list of events from the database with pagination. (if role is empty, it will return all events)
+// GetPublic returns upcoming events of shown clubs matching the filter, ordered by start_time.
 // If role is empty, it will return events with any role.
-func (s *EventRepository) GetWithPagination(ctx context.Context, limit, offset int, order string, role string, userID int64) ([]dto.Event, error) {
-	// Create the base query with all conditions
-	baseQuery := s.db.WithContext(ctx).
-		Model(&entity.Event{}). // Use Model() to ensure deleted_at IS NULL filter
-		Where("registration_end > ?", time.Now().In(location.Location()))
+func (s *EventRepository) GetPublic(ctx context.Context, role string, filter dto.EventFilter) ([]entity.Event, error) {
+	var events []entity.Event
+	err := filterEvents(s.publicEvents(ctx, role), filter).
+		Order("events.start_time ASC").
+		Find(&events).Error
+	return events, err
+}
 
-	// Apply role filtering if specified
-	if role != "" {
-		baseQuery = baseQuery.Where("? = ANY(allowed_roles)", role)
-	}
+// GetWithPagination is a function that gets a list of events matching the filter from the database with pagination.
+// If role is empty, it will return events with any role.
+func (s *EventRepository) GetWithPagination(
+	ctx context.Context,
+	limit, offset int,
+	order string,
+	role string,
+	userID int64,
+	filter dto.EventFilter,
+) ([]dto.Event, error) {
+	// Create the base query with all conditions
+	baseQuery := filterEvents(s.availableEvents(ctx, role), filter)
 
 	// Apply ordering and pagination to get the correct subset of events
 	var eventIDs []string
@@ -223,7 +463,7 @@ func (s *EventRepository) GetWithPagination(ctx context.Context, limit, offset i
 		IsRegistered bool
 	}
 
-	err := s.db.WithContext(ctx).
+	err := dbFromContext(ctx, s.db).
 		Model(&entity.Event{}). // Use Model() here too for consistency
 		Select("events.*, CASE WHEN ep.user_id IS NOT NULL THEN true ELSE false END as is_registered").
 		Joins("LEFT JOIN event_participants ep ON events.id = ep.event_id AND ep.user_id = ?", userID).
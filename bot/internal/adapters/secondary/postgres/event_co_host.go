@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+)
+
+// clubEventsCondition matches events of the club, including the ones it co-hosts, it takes club id twice
+const clubEventsCondition = "(events.club_id = ? OR events.id IN (SELECT event_id FROM event_co_hosts WHERE club_id = ? AND accepted))"
+
+type EventCoHostRepository struct {
+	db *gorm.DB
+}
+
+func NewEventCoHostRepository(db *gorm.DB) *EventCoHostRepository {
+	return &EventCoHostRepository{
+		db: db,
+	}
+}
+
+func (s *EventCoHostRepository) Create(ctx context.Context, coHost *entity.EventCoHost) (*entity.EventCoHost, error) {
+	err := s.db.WithContext(ctx).Create(coHost).Error
+	return coHost, err
+}
+
+func (s *EventCoHostRepository) Get(ctx context.Context, eventID, clubID string) (*entity.EventCoHost, error) {
+	var coHost entity.EventCoHost
+	err := s.db.WithContext(ctx).
+		Preload("Club").
+		Where("event_id = ? AND club_id = ?", eventID, clubID).
+		First(&coHost).Error
+	return &coHost, err
+}
+
+func (s *EventCoHostRepository) Update(ctx context.Context, coHost *entity.EventCoHost) (*entity.EventCoHost, error) {
+	err := s.db.WithContext(ctx).Omit("Club").Save(coHost).Error
+	return coHost, err
+}
+
+func (s *EventCoHostRepository) Delete(ctx context.Context, eventID, clubID string) error {
+	err := s.db.WithContext(ctx).Where("event_id = ? AND club_id = ?", eventID, clubID).Delete(&entity.EventCoHost{}).Error
+	return err
+}
+
+// GetByEventID returns co-hosts of the event (both accepted and pending) with their clubs, ordered by invitation time
+func (s *EventCoHostRepository) GetByEventID(ctx context.Context, eventID string) ([]entity.EventCoHost, error) {
+	var coHosts []entity.EventCoHost
+	err := s.db.WithContext(ctx).
+		Preload("Club").
+		Where("event_id = ?", eventID).
+		Order("created_at asc").
+		Find(&coHosts).Error
+	return coHosts, err
+}
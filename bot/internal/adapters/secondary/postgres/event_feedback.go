@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/dto"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+)
+
+// feedbackSummarySelect aggregates event_feedbacks rows into dto.FeedbackSummary
+const feedbackSummarySelect = "COUNT(*) AS count, COALESCE(AVG(event_feedbacks.rating), 0) AS average, COUNT(NULLIF(event_feedbacks.comment, '')) AS comments_count"
+
+type EventFeedbackRepository struct {
+	db *gorm.DB
+}
+
+func NewEventFeedbackRepository(db *gorm.DB) *EventFeedbackRepository {
+	return &EventFeedbackRepository{
+		db: db,
+	}
+}
+
+// Save creates the feedback or updates the existing one
+func (s *EventFeedbackRepository) Save(ctx context.Context, feedback *entity.EventFeedback) (*entity.EventFeedback, error) {
+	err := s.db.WithContext(ctx).Omit("User").Save(feedback).Error
+	return feedback, err
+}
+
+func (s *EventFeedbackRepository) Get(ctx context.Context, eventID string, userID int64) (*entity.EventFeedback, error) {
+	var feedback entity.EventFeedback
+	err := s.db.WithContext(ctx).
+		Where("event_id = ? AND user_id = ?", eventID, userID).
+		First(&feedback).Error
+	return &feedback, err
+}
+
+// GetByEventID returns all feedback of the event with users, newest first
+func (s *EventFeedbackRepository) GetByEventID(ctx context.Context, eventID string) ([]entity.EventFeedback, error) {
+	var feedback []entity.EventFeedback
+	err := s.db.WithContext(ctx).
+		Preload("User").
+		Where("event_id = ?", eventID).
+		Order("created_at desc").
+		Find(&feedback).Error
+	return feedback, err
+}
+
+func (s *EventFeedbackRepository) GetSummaryByEventID(ctx context.Context, eventID string) (dto.FeedbackSummary, error) {
+	var summary dto.FeedbackSummary
+	err := s.db.WithContext(ctx).
+		Model(&entity.EventFeedback{}).
+		Select(feedbackSummarySelect).
+		Where("event_feedbacks.event_id = ?", eventID).
+		Scan(&summary).Error
+	return summary, err
+}
+
+// GetSummaryByClubID aggregates feedback of all events of the club, including co-hosted ones
+func (s *EventFeedbackRepository) GetSummaryByClubID(ctx context.Context, clubID string) (dto.FeedbackSummary, error) {
+	var summary dto.FeedbackSummary
+	err := s.db.WithContext(ctx).
+		Model(&entity.EventFeedback{}).
+		Select(feedbackSummarySelect).
+		Joins("JOIN events ON events.id = event_feedbacks.event_id AND events.deleted_at IS NULL").
+		Where(clubEventsCondition, clubID, clubID).
+		Scan(&summary).Error
+	return summary, err
+}
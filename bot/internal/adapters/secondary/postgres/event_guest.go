@@ -0,0 +1,161 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/common/errorz"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/deadline"
+)
+
+type EventGuestRepository struct {
+	db *gorm.DB
+	// deadlines - registration deadlines checked while the event is locked
+	deadlines deadline.Policy
+}
+
+func NewEventGuestRepository(db *gorm.DB, deadlines deadline.Policy) *EventGuestRepository {
+	return &EventGuestRepository{
+		db:        db,
+		deadlines: deadlines,
+	}
+}
+
+// Create adds the guest of the participant to the event.
+//
+// The event row is locked like in EventParticipantRepository.Create, so the guest can't take
+// the last seat concurrently with a registration. Returns errorz.ErrNotRegistered, errorz.ErrGuestsNotAllowed,
+// errorz.ErrTooManyGuests, errorz.ErrRegistrationClosed, errorz.ErrEventFull or errorz.ErrRoleQuotaFull if the guest can't be added.
+func (s *EventGuestRepository) Create(ctx context.Context, guest *entity.EventGuest) (*entity.EventGuest, error) {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var event entity.Event
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", guest.EventID).First(&event).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("event with id %s not found", guest.EventID)
+			}
+			return err
+		}
+
+		var participant entity.EventParticipant
+		if err := tx.Where("event_id = ? AND user_id = ?", guest.EventID, guest.UserID).First(&participant).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errorz.ErrNotRegistered
+			}
+			return err
+		}
+		// Guests of a pending registration would take seats before the owner decides
+		if participant.IsPending() {
+			return errorz.ErrNotRegistered
+		}
+
+		if event.IsCancelled() {
+			return errorz.ErrEventCancelled
+		}
+		if event.MaxGuests <= 0 {
+			return errorz.ErrGuestsNotAllowed
+		}
+
+		var user entity.User
+		if err := tx.Where("id = ?", guest.UserID).First(&user).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if !event.IsRegistrationOpen(user.Role, now, s.deadlines) {
+			return errorz.ErrRegistrationClosed
+		}
+
+		var guestsCount int64
+		if err := tx.Model(&entity.EventGuest{}).
+			Where("event_id = ? AND user_id = ?", guest.EventID, guest.UserID).
+			Count(&guestsCount).Error; err != nil {
+			return err
+		}
+		if int(guestsCount) >= event.MaxGuests {
+			return errorz.ErrTooManyGuests
+		}
+
+		if event.MaxParticipants > 0 {
+			takenCount, err := countTakenSeats(tx, event.ID)
+			if err != nil {
+				return err
+			}
+
+			var offeredCount int64
+			if err := tx.Model(&entity.EventWaitlist{}).
+				Where("event_id = ? AND offer_expires_at > ?", event.ID, now).
+				Count(&offeredCount).Error; err != nil {
+				return err
+			}
+
+			if int(takenCount+offeredCount) >= event.MaxParticipants {
+				return errorz.ErrEventFull
+			}
+		}
+
+		// The guest takes a seat from the quota of the participant's role
+		if quota := event.RoleQuota(user.Role); quota > 0 {
+			if err := checkRoleQuota(tx, event.ID, user.Role, quota, 0, now); err != nil {
+				return err
+			}
+		}
+
+		return tx.Omit("User").Create(guest).Error
+	})
+
+	return guest, err
+}
+
+func (s *EventGuestRepository) Get(ctx context.Context, id string) (*entity.EventGuest, error) {
+	var guest entity.EventGuest
+	err := s.db.WithContext(ctx).Where("id = ?", id).First(&guest).Error
+	return &guest, err
+}
+
+func (s *EventGuestRepository) Delete(ctx context.Context, id string) error {
+	return s.db.WithContext(ctx).Where("id = ?", id).Delete(&entity.EventGuest{}).Error
+}
+
+// DeleteByEventAndUser removes all guests of the participant from the event
+func (s *EventGuestRepository) DeleteByEventAndUser(ctx context.Context, eventID string, userID int64) error {
+	return s.db.WithContext(ctx).
+		Where("event_id = ? AND user_id = ?", eventID, userID).
+		Delete(&entity.EventGuest{}).Error
+}
+
+// GetByEventAndUser returns the guests of the participant in the order they were added
+func (s *EventGuestRepository) GetByEventAndUser(ctx context.Context, eventID string, userID int64) ([]entity.EventGuest, error) {
+	var guests []entity.EventGuest
+	err := s.db.WithContext(ctx).
+		Where("event_id = ? AND user_id = ?", eventID, userID).
+		Order("created_at asc").
+		Find(&guests).Error
+	return guests, err
+}
+
+// GetByEventID returns all guests of the event with the participants who brought them
+func (s *EventGuestRepository) GetByEventID(ctx context.Context, eventID string) ([]entity.EventGuest, error) {
+	var guests []entity.EventGuest
+	err := s.db.WithContext(ctx).
+		Preload("User").
+		Where("event_id = ?", eventID).
+		Order("created_at asc").
+		Find(&guests).Error
+	return guests, err
+}
+
+// GetMany returns the guests by ids with the participants who brought them
+func (s *EventGuestRepository) GetMany(ctx context.Context, ids []string) ([]entity.EventGuest, error) {
+	var guests []entity.EventGuest
+	err := s.db.WithContext(ctx).
+		Preload("User").
+		Where("id IN ?", ids).
+		Find(&guests).Error
+	return guests, err
+}
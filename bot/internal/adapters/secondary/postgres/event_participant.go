@@ -2,47 +2,95 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/common/errorz"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/dto"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/deadline"
 )
 
 type EventParticipantRepository struct {
 	db *gorm.DB
+	// deadlines - registration deadlines checked while the event is locked
+	deadlines deadline.Policy
 }
 
-func NewEventParticipantRepository(db *gorm.DB) *EventParticipantRepository {
+func NewEventParticipantRepository(db *gorm.DB, deadlines deadline.Policy) *EventParticipantRepository {
 	return &EventParticipantRepository{
-		db: db,
+		db:        db,
+		deadlines: deadlines,
 	}
 }
 
-func (s *EventParticipantRepository) Create(ctx context.Context, eventParticipant *entity.EventParticipant) (*entity.EventParticipant, error) {
-	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// Check if event exists
-		var eventExists int64
-		if err := tx.Model(&entity.Event{}).Where("id = ?", eventParticipant.EventID).Count(&eventExists).Error; err != nil {
+// Create registers a participant on the event.
+//
+// The event row is locked for the duration of the transaction, so concurrent registrations
+// can't exceed MaxParticipants and the quota of the user's role. Seats offered to waitlisted users are treated as taken,
+// except the seat offered to the registering user. Registration deadline is checked only if checkDeadline is set.
+func (s *EventParticipantRepository) Create(ctx context.Context, eventParticipant *entity.EventParticipant, checkDeadline bool) (*entity.EventParticipant, error) {
+	err := dbFromContext(ctx, s.db).Transaction(func(tx *gorm.DB) error {
+		var event entity.Event
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", eventParticipant.EventID).First(&event).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("event with id %s not found", eventParticipant.EventID)
+			}
 			return err
 		}
-		if eventExists == 0 {
-			return fmt.Errorf("event with id %s not found", eventParticipant.EventID)
-		}
 
-		// Check if user exists
-		var userExists int64
-		if err := tx.Model(&entity.User{}).Where("id = ?", eventParticipant.UserID).Count(&userExists).Error; err != nil {
+		var user entity.User
+		if err := tx.Where("id = ?", eventParticipant.UserID).First(&user).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("user with id %d not found", eventParticipant.UserID)
+			}
 			return err
 		}
-		if userExists == 0 {
-			return fmt.Errorf("user with id %d not found", eventParticipant.UserID)
+
+		if event.IsCancelled() {
+			return errorz.ErrEventCancelled
+		}
+		if event.IsDraft() {
+			return errorz.ErrEventDraft
+		}
+		if !event.IsRoleAllowed(user.Role) {
+			return errorz.ErrRoleNotAllowed
+		}
+
+		now := time.Now()
+		if checkDeadline && !event.IsRegistrationOpen(user.Role, now, s.deadlines) {
+			return errorz.ErrRegistrationClosed
+		}
+
+		if event.MaxParticipants > 0 {
+			participantsCount, err := countTakenSeats(tx, event.ID)
+			if err != nil {
+				return err
+			}
+
+			var offeredCount int64
+			if err := tx.Model(&entity.EventWaitlist{}).
+				Where("event_id = ? AND user_id <> ? AND offer_expires_at > ?", event.ID, eventParticipant.UserID, now).
+				Count(&offeredCount).Error; err != nil {
+				return err
+			}
+
+			if int(participantsCount+offeredCount) >= event.MaxParticipants {
+				return errorz.ErrEventFull
+			}
 		}
 
-		// Create participant
-		return tx.Create(&eventParticipant).Error
+		if quota := event.RoleQuota(user.Role); quota > 0 {
+			if err := checkRoleQuota(tx, event.ID, user.Role, quota, eventParticipant.UserID, now); err != nil {
+				return err
+			}
+		}
+
+		return tx.Create(eventParticipant).Error
 	})
 
 	return eventParticipant, err
@@ -50,35 +98,98 @@ func (s *EventParticipantRepository) Create(ctx context.Context, eventParticipan
 
 func (s *EventParticipantRepository) Get(ctx context.Context, eventID string, userID int64) (*entity.EventParticipant, error) {
 	var eventParticipant entity.EventParticipant
-	err := s.db.WithContext(ctx).Where("event_id = ? AND user_id = ?", eventID, userID).First(&eventParticipant).Error
+	err := dbFromContext(ctx, s.db).Where("event_id = ? AND user_id = ?", eventID, userID).First(&eventParticipant).Error
 	return &eventParticipant, err
 }
 
 func (s *EventParticipantRepository) Update(ctx context.Context, eventParticipant *entity.EventParticipant) (*entity.EventParticipant, error) {
-	err := s.db.WithContext(ctx).Save(&eventParticipant).Error
+	err := dbFromContext(ctx, s.db).Save(&eventParticipant).Error
 	return eventParticipant, err
 }
 
 func (s *EventParticipantRepository) Delete(ctx context.Context, eventID string, userID int64) error {
-	err := s.db.WithContext(ctx).Where("event_id = ? AND user_id = ?", eventID, userID).Delete(&entity.EventParticipant{}).Error
+	err := dbFromContext(ctx, s.db).Where("event_id = ? AND user_id = ?", eventID, userID).Delete(&entity.EventParticipant{}).Error
 	return err
 }
 
 func (s *EventParticipantRepository) GetByEventID(ctx context.Context, eventID string) ([]entity.EventParticipant, error) {
 	var eventParticipants []entity.EventParticipant
-	err := s.db.WithContext(ctx).Where("event_id = ?", eventID).Find(&eventParticipants).Error
+	err := dbFromContext(ctx, s.db).Where("event_id = ?", eventID).Find(&eventParticipants).Error
 	return eventParticipants, err
 }
 
+// CountByEventID returns the number of taken seats of the event: participants together with their guests
 func (s *EventParticipantRepository) CountByEventID(ctx context.Context, eventID string) (int64, error) {
+	return countTakenSeats(dbFromContext(ctx, s.db), eventID)
+}
+
+// countTakenSeats counts participants and their guests, every guest takes a seat of the event
+func countTakenSeats(db *gorm.DB, eventID string) (int64, error) {
 	var count int64
-	err := s.db.WithContext(ctx).Model(&entity.EventParticipant{}).Where("event_id = ?", eventID).Count(&count).Error
+	err := db.Raw(
+		"SELECT (SELECT COUNT(*) FROM event_participants WHERE event_id = ?) + (SELECT COUNT(*) FROM event_guests WHERE event_id = ?)",
+		eventID, eventID,
+	).Scan(&count).Error
 	return count, err
 }
 
+// CountByRole returns the number of taken seats of the event by the roles of the participants,
+// guests are counted with the role of the participant who brought them
+func (s *EventParticipantRepository) CountByRole(ctx context.Context, eventID string) (map[entity.Role]int64, error) {
+	var rows []struct {
+		Role  entity.Role
+		Count int64
+	}
+	err := dbFromContext(ctx, s.db).Raw(
+		`SELECT users.role AS role, COUNT(*) AS count
+		FROM (SELECT user_id FROM event_participants WHERE event_id = ? UNION ALL SELECT user_id FROM event_guests WHERE event_id = ?) seats
+		JOIN users ON users.id = seats.user_id
+		GROUP BY users.role`,
+		eventID, eventID,
+	).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[entity.Role]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Role] = row.Count
+	}
+	return counts, nil
+}
+
+// checkRoleQuota returns errorz.ErrRoleQuotaFull if the seats taken by the role and offered to waitlisted users
+// with the role reach the quota. The offer to exceptUserID is not counted, it is the seat the user is taking
+func checkRoleQuota(tx *gorm.DB, eventID string, role entity.Role, quota int, exceptUserID int64, now time.Time) error {
+	var takenCount int64
+	if err := tx.Raw(
+		`SELECT (SELECT COUNT(*) FROM event_participants JOIN users ON users.id = event_participants.user_id
+			WHERE event_participants.event_id = ? AND users.role = ?)
+		+ (SELECT COUNT(*) FROM event_guests JOIN users ON users.id = event_guests.user_id
+			WHERE event_guests.event_id = ? AND users.role = ?)`,
+		eventID, role, eventID, role,
+	).Scan(&takenCount).Error; err != nil {
+		return err
+	}
+
+	var offeredCount int64
+	if err := tx.Model(&entity.EventWaitlist{}).
+		Joins("JOIN users ON users.id = event_waitlists.user_id").
+		Where("event_waitlists.event_id = ? AND event_waitlists.user_id <> ? AND event_waitlists.offer_expires_at > ? AND users.role = ?",
+			eventID, exceptUserID, now, role).
+		Count(&offeredCount).Error; err != nil {
+		return err
+	}
+
+	if int(takenCount+offeredCount) >= quota {
+		return errorz.ErrRoleQuotaFull
+	}
+	return nil
+}
+
 func (s *EventParticipantRepository) CountVisitedByEventID(ctx context.Context, eventID string) (int64, error) {
 	var count int64
-	err := s.db.WithContext(ctx).Model(&entity.EventParticipant{}).Where("event_id = ? AND (is_event_qr = true OR is_user_qr = true)", eventID).Count(&count).Error
+	err := dbFromContext(ctx, s.db).Model(&entity.EventParticipant{}).Where("event_id = ? AND (is_event_qr = true OR is_user_qr = true)", eventID).Count(&count).Error
 	return count, err
 }
 
@@ -100,7 +211,7 @@ func (s *EventParticipantRepository) GetUserEvents(ctx context.Context, userID i
 
 	// Count total upcoming events for this user
 	var upcomingCount int64
-	if err := s.db.WithContext(ctx).
+	if err := dbFromContext(ctx, s.db).
 		Model(&entity.Event{}).
 		Joins("JOIN event_participants ON events.id = event_participants.event_id").
 		Where("event_participants.user_id = ? AND events.start_time > ?", userID, currentTime).
@@ -110,7 +221,7 @@ func (s *EventParticipantRepository) GetUserEvents(ctx context.Context, userID i
 
 	// If offset is within upcoming events, get upcoming events
 	if offset < int(upcomingCount) {
-		if err := s.db.WithContext(ctx).
+		if err := dbFromContext(ctx, s.db).
 			Table("events").
 			Select("events.*, event_participants.is_user_qr, event_participants.is_event_qr").
 			Joins("JOIN event_participants ON event_participants.event_id = events.id").
@@ -128,7 +239,7 @@ func (s *EventParticipantRepository) GetUserEvents(ctx context.Context, userID i
 	if remainingLimit > 0 {
 		pastOffset := max(0, offset-int(upcomingCount)) // Adjust offset for past events
 		var pastEvents []eventWithQR
-		if err := s.db.WithContext(ctx).
+		if err := dbFromContext(ctx, s.db).
 			Table("events").
 			Select("events.*, event_participants.is_user_qr, event_participants.is_event_qr").
 			Joins("JOIN event_participants ON event_participants.event_id = events.id").
@@ -153,9 +264,57 @@ func (s *EventParticipantRepository) GetUserEvents(ctx context.Context, userID i
 
 func (s *EventParticipantRepository) CountUserEvents(ctx context.Context, userID int64) (int64, error) {
 	var count int64
-	err := s.db.WithContext(ctx).Model(&entity.EventParticipant{}).
+	err := dbFromContext(ctx, s.db).Model(&entity.EventParticipant{}).
 		Joins("JOIN events ON event_participants.event_id = events.id").
 		Where("event_participants.user_id = ? AND events.deleted_at IS NULL", userID).
 		Count(&count).Error
 	return count, err
 }
+
+// GetUpcomingUserEvents returns events with approved registrations of the user that have not ended by from,
+// ordered by start_time
+func (s *EventParticipantRepository) GetUpcomingUserEvents(ctx context.Context, userID int64, from time.Time) ([]entity.Event, error) {
+	var events []entity.Event
+	err := dbFromContext(ctx, s.db).
+		Model(&entity.Event{}).
+		Joins("JOIN event_participants ON event_participants.event_id = events.id").
+		Where("event_participants.user_id = ? AND event_participants.status = ?", userID, entity.ParticipantStatusApproved).
+		Where("events.start_time > ? OR events.end_time > ?", from, from).
+		Order("events.start_time ASC").
+		Find(&events).Error
+	return events, err
+}
+
+// GetNoShows returns the end times of the no-shows of the users: approved registrations for offline events that ended
+// within [from, to) where the user was not checked in. Events where nobody was checked in are skipped, the owner might not
+// have scanned QR codes at all. No-shows that ended before the reset of the user's no-shows are skipped too
+func (s *EventParticipantRepository) GetNoShows(ctx context.Context, userIDs []int64, from, to time.Time) (map[int64][]time.Time, error) {
+	var rows []struct {
+		UserID  int64
+		EndedAt time.Time
+	}
+	err := dbFromContext(ctx, s.db).
+		Model(&entity.EventParticipant{}).
+		Select("event_participants.user_id, GREATEST(events.end_time, events.start_time) AS ended_at").
+		Joins("JOIN events ON events.id = event_participants.event_id AND events.deleted_at IS NULL").
+		Joins("JOIN users ON users.id = event_participants.user_id").
+		Where("event_participants.user_id IN ? AND event_participants.status = ?", userIDs, entity.ParticipantStatusApproved).
+		Where("NOT event_participants.is_user_qr AND NOT event_participants.is_event_qr").
+		Where("events.status = ? AND events.format = ?", entity.EventStatusActive, entity.EventFormatOffline).
+		Where("GREATEST(events.end_time, events.start_time) >= ? AND GREATEST(events.end_time, events.start_time) < ?", from, to).
+		Where("(users.no_shows_reset_at IS NULL OR GREATEST(events.end_time, events.start_time) > users.no_shows_reset_at)").
+		Where(`EXISTS (
+			SELECT 1 FROM event_participants visited
+			WHERE visited.event_id = events.id AND (visited.is_user_qr OR visited.is_event_qr)
+		)`).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	noShows := make(map[int64][]time.Time, len(userIDs))
+	for _, row := range rows {
+		noShows[row.UserID] = append(noShows[row.UserID], row.EndedAt)
+	}
+	return noShows, nil
+}
@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+)
+
+type EventQuestionRepository struct {
+	db *gorm.DB
+}
+
+func NewEventQuestionRepository(db *gorm.DB) *EventQuestionRepository {
+	return &EventQuestionRepository{
+		db: db,
+	}
+}
+
+// GetByEventID returns the registration form questions of the event in the order of their positions
+func (s *EventQuestionRepository) GetByEventID(ctx context.Context, eventID string) ([]entity.EventQuestion, error) {
+	var questions []entity.EventQuestion
+	err := s.db.WithContext(ctx).
+		Where("event_id = ?", eventID).
+		Order("position asc").
+		Find(&questions).Error
+	return questions, err
+}
+
+// SaveAnswers replaces the answers of the user to the registration form of the event
+func (s *EventQuestionRepository) SaveAnswers(ctx context.Context, eventID string, userID int64, answers []entity.EventAnswer) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("event_id = ? AND user_id = ?", eventID, userID).Delete(&entity.EventAnswer{}).Error
+		if err != nil {
+			return err
+		}
+		if len(answers) == 0 {
+			return nil
+		}
+		return tx.Create(&answers).Error
+	})
+}
+
+func (s *EventQuestionRepository) DeleteAnswers(ctx context.Context, eventID string, userID int64) error {
+	return s.db.WithContext(ctx).
+		Where("event_id = ? AND user_id = ?", eventID, userID).
+		Delete(&entity.EventAnswer{}).Error
+}
+
+func (s *EventQuestionRepository) GetAnswersByEventID(ctx context.Context, eventID string) ([]entity.EventAnswer, error) {
+	var answers []entity.EventAnswer
+	err := s.db.WithContext(ctx).
+		Where("event_id = ?", eventID).
+		Find(&answers).Error
+	return answers, err
+}
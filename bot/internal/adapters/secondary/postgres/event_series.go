@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+)
+
+type EventSeriesRepository struct {
+	db *gorm.DB
+}
+
+func NewEventSeriesRepository(db *gorm.DB) *EventSeriesRepository {
+	return &EventSeriesRepository{
+		db: db,
+	}
+}
+
+// Create is a function that creates a new series together with its events in one transaction.
+//
+// Venues of the events are checked in the same transaction, see bookVenue.
+func (s *EventSeriesRepository) Create(ctx context.Context, series *entity.EventSeries) (*entity.EventSeries, error) {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i := range series.Events {
+			if err := bookVenue(tx, &series.Events[i]); err != nil {
+				return err
+			}
+		}
+		return tx.Create(series).Error
+	})
+	return series, err
+}
+
+// Get is a function that gets a series with its events (ordered by start_time) from the database by id.
+func (s *EventSeriesRepository) Get(ctx context.Context, id string) (*entity.EventSeries, error) {
+	var series entity.EventSeries
+	err := s.db.WithContext(ctx).
+		Preload("Events", func(db *gorm.DB) *gorm.DB {
+			return db.Order("start_time asc")
+		}).
+		Where("id = ?", id).
+		First(&series).Error
+	return &series, err
+}
+
+// Update is a function that updates the series without its events.
+func (s *EventSeriesRepository) Update(ctx context.Context, series *entity.EventSeries) (*entity.EventSeries, error) {
+	err := s.db.WithContext(ctx).Omit(clause.Associations).Save(series).Error
+	return series, err
+}
+
+// Split is a function that updates the series, creates the next series and moves the events to it in one transaction.
+func (s *EventSeriesRepository) Split(
+	ctx context.Context,
+	series *entity.EventSeries,
+	next *entity.EventSeries,
+	eventIDs []string,
+) (*entity.EventSeries, error) {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(series).Error; err != nil {
+			return err
+		}
+		if err := tx.Omit(clause.Associations).Create(next).Error; err != nil {
+			return err
+		}
+		return tx.Model(&entity.Event{}).Where("id IN ?", eventIDs).Update("series_id", next.ID).Error
+	})
+	return next, err
+}
@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+)
+
+type EventWaitlistRepository struct {
+	db *gorm.DB
+}
+
+func NewEventWaitlistRepository(db *gorm.DB) *EventWaitlistRepository {
+	return &EventWaitlistRepository{
+		db: db,
+	}
+}
+
+func (s *EventWaitlistRepository) Create(ctx context.Context, entry *entity.EventWaitlist) (*entity.EventWaitlist, error) {
+	err := dbFromContext(ctx, s.db).Create(entry).Error
+	return entry, err
+}
+
+func (s *EventWaitlistRepository) Get(ctx context.Context, eventID string, userID int64) (*entity.EventWaitlist, error) {
+	var entry entity.EventWaitlist
+	err := dbFromContext(ctx, s.db).Where("event_id = ? AND user_id = ?", eventID, userID).First(&entry).Error
+	return &entry, err
+}
+
+func (s *EventWaitlistRepository) Update(ctx context.Context, entry *entity.EventWaitlist) (*entity.EventWaitlist, error) {
+	err := dbFromContext(ctx, s.db).Omit("User").Save(entry).Error
+	return entry, err
+}
+
+func (s *EventWaitlistRepository) Delete(ctx context.Context, eventID string, userID int64) error {
+	return dbFromContext(ctx, s.db).Where("event_id = ? AND user_id = ?", eventID, userID).Delete(&entity.EventWaitlist{}).Error
+}
+
+// GetByEventID returns the waitlist of the event in queue order with users preloaded
+func (s *EventWaitlistRepository) GetByEventID(ctx context.Context, eventID string) ([]entity.EventWaitlist, error) {
+	var entries []entity.EventWaitlist
+	err := dbFromContext(ctx, s.db).
+		Preload("User").
+		Where("event_id = ?", eventID).
+		Order("created_at ASC").
+		Find(&entries).Error
+	return entries, err
+}
+
+// GetNext returns the first user in the queue who has not been offered a seat yet with user preloaded.
+// Users with excludedRoles are skipped, their roles have no free seats. Users from lastUserIDs are moved
+// to the end of the queue
+func (s *EventWaitlistRepository) GetNext(ctx context.Context, eventID string, excludedRoles entity.Roles, lastUserIDs []int64) (*entity.EventWaitlist, error) {
+	query := dbFromContext(ctx, s.db).
+		Preload("User").
+		Where("event_waitlists.event_id = ? AND event_waitlists.offer_expires_at IS NULL", eventID)
+	if len(excludedRoles) > 0 {
+		query = query.
+			Joins("JOIN users ON users.id = event_waitlists.user_id").
+			Where("users.role NOT IN ?", excludedRoles)
+	}
+
+	if len(lastUserIDs) > 0 {
+		query = query.Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "event_waitlists.user_id IN ?",
+			Vars:               []interface{}{lastUserIDs},
+			WithoutParentheses: true,
+		}})
+	}
+
+	var entry entity.EventWaitlist
+	err := query.Order("event_waitlists.created_at ASC").First(&entry).Error
+	return &entry, err
+}
+
+// GetPosition returns the 1-based position of the user in the event waitlist
+func (s *EventWaitlistRepository) GetPosition(ctx context.Context, eventID string, userID int64) (int64, error) {
+	entry, err := s.Get(ctx, eventID, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	var position int64
+	err = dbFromContext(ctx, s.db).
+		Model(&entity.EventWaitlist{}).
+		Where("event_id = ? AND created_at <= ?", eventID, entry.CreatedAt).
+		Count(&position).Error
+	return position, err
+}
+
+func (s *EventWaitlistRepository) CountByEventID(ctx context.Context, eventID string) (int64, error) {
+	var count int64
+	err := dbFromContext(ctx, s.db).Model(&entity.EventWaitlist{}).Where("event_id = ?", eventID).Count(&count).Error
+	return count, err
+}
+
+// CountActiveOffers returns the number of seats that are offered to waitlisted users and not yet expired
+func (s *EventWaitlistRepository) CountActiveOffers(ctx context.Context, eventID string, now time.Time) (int64, error) {
+	var count int64
+	err := dbFromContext(ctx, s.db).
+		Model(&entity.EventWaitlist{}).
+		Where("event_id = ? AND offer_expires_at > ?", eventID, now).
+		Count(&count).Error
+	return count, err
+}
+
+// CountActiveOffersByRole returns the number of seats offered to waitlisted users by their roles
+func (s *EventWaitlistRepository) CountActiveOffersByRole(ctx context.Context, eventID string, now time.Time) (map[entity.Role]int64, error) {
+	var rows []struct {
+		Role  entity.Role
+		Count int64
+	}
+	err := dbFromContext(ctx, s.db).
+		Model(&entity.EventWaitlist{}).
+		Select("users.role AS role, COUNT(*) AS count").
+		Joins("JOIN users ON users.id = event_waitlists.user_id").
+		Where("event_waitlists.event_id = ? AND event_waitlists.offer_expires_at > ?", eventID, now).
+		Group("users.role").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[entity.Role]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Role] = row.Count
+	}
+	return counts, nil
+}
+
+func (s *EventWaitlistRepository) GetExpiredOffers(ctx context.Context, now time.Time) ([]entity.EventWaitlist, error) {
+	var entries []entity.EventWaitlist
+	err := dbFromContext(ctx, s.db).Where("offer_expires_at <= ?", now).Find(&entries).Error
+	return entries, err
+}
+
+// GetWaitingEventIDs returns ids of upcoming events that have users waiting for an offer
+func (s *EventWaitlistRepository) GetWaitingEventIDs(ctx context.Context) ([]string, error) {
+	var eventIDs []string
+	err := dbFromContext(ctx, s.db).
+		Model(&entity.EventWaitlist{}).
+		Joins("JOIN events ON events.id = event_waitlists.event_id").
+		Where("event_waitlists.offer_expires_at IS NULL AND events.deleted_at IS NULL AND events.registration_end > ?", time.Now()).
+		Where("events.status <> ?", entity.EventStatusCancelled).
+		Distinct().
+		Pluck("event_waitlists.event_id", &eventIDs).Error
+	return eventIDs, err
+}
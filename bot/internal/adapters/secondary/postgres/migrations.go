@@ -8,8 +8,22 @@ var Migrations = []interface{}{
 	&entity.Club{},
 	&entity.ClubOwner{},
 	&entity.IgnoreMailing{},
+	&entity.Venue{},
+	&entity.EventSeries{},
 	&entity.Event{},
+	&entity.EventCoHost{},
+	&entity.EventQuestion{},
+	&entity.EventAnswer{},
 	&entity.EventParticipant{},
+	&entity.EventWaitlist{},
+	&entity.EventGuest{},
 	&entity.EventNotification{},
+	&entity.EventFeedback{},
 	&entity.Pass{},
 }
+
+// Indexes is a list of indexes that can't be described with gorm tags, they are created after Migrations.
+var Indexes = []string{
+	"CREATE INDEX IF NOT EXISTS idx_events_search ON events USING GIN (" + eventSearchVector + ")",
+	"CREATE INDEX IF NOT EXISTS idx_clubs_search ON clubs USING GIN (" + clubSearchVector + ")",
+}
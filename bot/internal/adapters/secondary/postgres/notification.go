@@ -22,14 +22,39 @@ func (s *NotificationRepository) Create(ctx context.Context, notification *entit
 	return s.db.WithContext(ctx).Create(notification).Error
 }
 
-// GetUnnotifiedUsers returns a list of users who have not been notified about an event for a specific notification type
+// GetUnnotifiedUsers returns a list of users who have not been notified about an event for a specific notification type.
+// Pending registrations are skipped until the club owner approves them
 func (s *NotificationRepository) GetUnnotifiedUsers(ctx context.Context, eventID string, notificationType entity.NotificationType) ([]entity.EventParticipant, error) {
 	var participants []entity.EventParticipant
 
 	err := s.db.WithContext(ctx).
 		Joins("LEFT JOIN event_notifications ON event_notifications.user_id = event_participants.user_id AND event_notifications.event_id = event_participants.event_id AND event_notifications.type = ?", notificationType).
-		Where("event_participants.event_id = ? AND event_notifications.id IS NULL", eventID).
+		Where("event_participants.event_id = ? AND event_participants.status = ? AND event_notifications.id IS NULL", eventID, entity.ParticipantStatusApproved).
 		Find(&participants).Error
 
 	return participants, err
 }
+
+// GetNotifiedUserIDs returns ids of users who have already got the notification of the given type about the event
+func (s *NotificationRepository) GetNotifiedUserIDs(ctx context.Context, eventID string, notificationType entity.NotificationType) ([]int64, error) {
+	var userIDs []int64
+
+	err := s.db.WithContext(ctx).
+		Model(&entity.EventNotification{}).
+		Where("event_id = ? AND type = ?", eventID, notificationType).
+		Pluck("user_id", &userIDs).Error
+
+	return userIDs, err
+}
+
+// DeleteRemindersByEventID deletes sent reminder records of the event, so reminders will be sent again.
+// Other notifications (feedback requests, attendance reports) are kept
+func (s *NotificationRepository) DeleteRemindersByEventID(ctx context.Context, eventID string) error {
+	return s.db.WithContext(ctx).
+		Where("event_id = ? AND (type IN ? OR type LIKE ?)",
+			eventID,
+			[]entity.NotificationType{entity.NotificationTypeDay, entity.NotificationTypeHour},
+			`reminder\_%`,
+		).
+		Delete(&entity.EventNotification{}).Error
+}
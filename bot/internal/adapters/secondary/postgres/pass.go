@@ -7,6 +7,7 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/valueobject"
 )
 
 type PassRepository struct {
@@ -89,10 +90,10 @@ func (s *PassRepository) CreateBulkPasses(ctx context.Context, passes []entity.P
 	return err
 }
 
-// GetActivePassForUser is a function that gets the active pass for a user and event.
+// GetActivePassForUser is a function that gets the active pass for a user and event (passes of the user's guests are not included).
 func (s *PassRepository) GetActivePassForUser(ctx context.Context, eventID string, userID int64) (*entity.Pass, error) {
 	var pass entity.Pass
-	err := s.db.WithContext(ctx).Where("event_id = ? AND user_id = ? AND status != ?", eventID, userID, entity.PassStatusCancelled).Order("created_at desc").First(&pass).Error
+	err := s.db.WithContext(ctx).Where("event_id = ? AND user_id = ? AND guest_id IS NULL AND status != ?", eventID, userID, entity.PassStatusCancelled).Order("created_at desc").First(&pass).Error
 	if err != nil {
 		return nil, err
 	}
@@ -102,11 +103,27 @@ func (s *PassRepository) GetActivePassForUser(ctx context.Context, eventID strin
 // HasActivePass is a function that checks if a user has an active pass for an event.
 func (s *PassRepository) HasActivePass(ctx context.Context, eventID string, userID int64) (bool, error) {
 	var count int64
-	err := s.db.WithContext(ctx).Model(&entity.Pass{}).Where("event_id = ? AND user_id = ? AND status != ?", eventID, userID, entity.PassStatusCancelled).Count(&count).Error
+	err := s.db.WithContext(ctx).Model(&entity.Pass{}).Where("event_id = ? AND user_id = ? AND guest_id IS NULL AND status != ?", eventID, userID, entity.PassStatusCancelled).Count(&count).Error
 	return count > 0, err
 }
 
-// CancelPassesByEventAndUser is a function that cancels all active passes for a user and event.
+// HasActiveGuestPass is a function that checks if a guest has an active pass.
+func (s *PassRepository) HasActiveGuestPass(ctx context.Context, guestID string) (bool, error) {
+	var count int64
+	err := s.db.WithContext(ctx).Model(&entity.Pass{}).Where("guest_id = ? AND status != ?", guestID, entity.PassStatusCancelled).Count(&count).Error
+	return count > 0, err
+}
+
+// CancelGuestPasses is a function that cancels all active passes of a guest.
+func (s *PassRepository) CancelGuestPasses(ctx context.Context, guestID string) error {
+	err := s.db.WithContext(ctx).Model(&entity.Pass{}).Where("guest_id = ? AND status != ?", guestID, entity.PassStatusCancelled).Updates(map[string]interface{}{
+		"status":     entity.PassStatusCancelled,
+		"updated_at": time.Now(),
+	}).Error
+	return err
+}
+
+// CancelPassesByEventAndUser is a function that cancels all active passes for a user and event, including the passes of the user's guests.
 func (s *PassRepository) CancelPassesByEventAndUser(ctx context.Context, eventID string, userID int64) error {
 	err := s.db.WithContext(ctx).Model(&entity.Pass{}).Where("event_id = ? AND user_id = ? AND status != ?", eventID, userID, entity.PassStatusCancelled).Updates(map[string]interface{}{
 		"status":     entity.PassStatusCancelled,
@@ -115,6 +132,37 @@ func (s *PassRepository) CancelPassesByEventAndUser(ctx context.Context, eventID
 	return err
 }
 
+// CancelPendingPassesByEventID is a function that cancels all pending passes of the event.
+func (s *PassRepository) CancelPendingPassesByEventID(ctx context.Context, eventID string) error {
+	err := s.db.WithContext(ctx).Model(&entity.Pass{}).Where("event_id = ? AND status = ?", eventID, entity.PassStatusPending).Updates(map[string]interface{}{
+		"status":     entity.PassStatusCancelled,
+		"updated_at": time.Now(),
+	}).Error
+	return err
+}
+
+// ReschedulePendingPasses is a function that sets a new scheduled time for pending passes of the event issued to users with the role.
+// Guest passes are not affected, see RescheduleGuestPasses.
+func (s *PassRepository) ReschedulePendingPasses(ctx context.Context, eventID string, role valueobject.Role, scheduledAt time.Time) error {
+	err := s.db.WithContext(ctx).Model(&entity.Pass{}).
+		Where("event_id = ? AND status = ? AND guest_id IS NULL", eventID, entity.PassStatusPending).
+		Where("user_id IN (?)", s.db.Model(&entity.User{}).Select("id").Where("role = ?", role)).
+		Updates(map[string]interface{}{
+			"scheduled_at": scheduledAt,
+			"updated_at":   time.Now(),
+		}).Error
+	return err
+}
+
+// RescheduleGuestPasses is a function that sets a new scheduled time for pending guest passes of the event.
+func (s *PassRepository) RescheduleGuestPasses(ctx context.Context, eventID string, scheduledAt time.Time) error {
+	err := s.db.WithContext(ctx).Model(&entity.Pass{}).Where("event_id = ? AND status = ? AND guest_id IS NOT NULL", eventID, entity.PassStatusPending).Updates(map[string]interface{}{
+		"scheduled_at": scheduledAt,
+		"updated_at":   time.Now(),
+	}).Error
+	return err
+}
+
 // GetPassesByRequester is a function that gets passes by requester type and ID with pagination.
 func (s *PassRepository) GetPassesByRequester(ctx context.Context, requesterType entity.PassRequesterType, requesterID string, limit, offset int) ([]entity.Pass, error) {
 	var passes []entity.Pass
@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/dto"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/location"
+)
+
+// Выражения должны совпадать с выражениями индексов (см. Indexes), иначе индекс не используется
+const (
+	eventSearchVector = "to_tsvector('russian', coalesce(name, '') || ' ' || coalesce(description, '') || ' ' || coalesce(location, ''))"
+	clubSearchVector  = "to_tsvector('russian', coalesce(name, '') || ' ' || coalesce(description, ''))"
+)
+
+// searchQuery selects events available for the role and shown clubs matching the query, ranked by relevance.
+// Parameters: query, now, role, role, query
+const searchQuery = `
+SELECT 'event' AS type, id, name, start_time, ts_rank(` + eventSearchVector + `, q) AS rank
+FROM events, websearch_to_tsquery('russian', ?) q
+WHERE deleted_at IS NULL
+	AND registration_end > ?
+	AND status = '` + string(entity.EventStatusActive) + `'
+	AND (? = '' OR ? = ANY(allowed_roles))
+	AND ` + eventSearchVector + ` @@ q
+UNION ALL
+SELECT 'club' AS type, id, name, NULL AS start_time, ts_rank(` + clubSearchVector + `, q) AS rank
+FROM clubs, websearch_to_tsquery('russian', ?) q
+WHERE deleted_at IS NULL
+	AND should_show
+	AND ` + clubSearchVector + ` @@ q
+`
+
+type SearchRepository struct {
+	db *gorm.DB
+}
+
+func NewSearchRepository(db *gorm.DB) *SearchRepository {
+	return &SearchRepository{
+		db: db,
+	}
+}
+
+// Search is a function that finds events and clubs by the query using the full-text index.
+// Results are ordered by rank. If role is empty, events with any role are searched.
+func (s *SearchRepository) Search(ctx context.Context, query string, role string, limit, offset int) ([]dto.SearchResult, error) {
+	var results []dto.SearchResult
+	err := s.db.WithContext(ctx).
+		Raw(searchQuery+"ORDER BY rank DESC, name ASC LIMIT ? OFFSET ?", s.searchArgs(query, role, limit, offset)...).
+		Scan(&results).Error
+	return results, err
+}
+
+// Count is a function that gets the count of events and clubs found by the query.
+func (s *SearchRepository) Count(ctx context.Context, query string, role string) (int64, error) {
+	var count int64
+	err := s.db.WithContext(ctx).
+		Raw("SELECT COUNT(*) FROM ("+searchQuery+") results", s.searchArgs(query, role)...).
+		Scan(&count).Error
+	return count, err
+}
+
+func (s *SearchRepository) searchArgs(query string, role string, pagination ...int) []interface{} {
+	args := []interface{}{query, time.Now().In(location.Location()), role, role, query}
+	for _, p := range pagination {
+		args = append(args, p)
+	}
+	return args
+}
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -47,6 +48,13 @@ func (s *UserRepository) GetByQRCodeID(ctx context.Context, qrCodeID string) (*e
 	return &user, err
 }
 
+// GetByCalendarToken is a function that gets a user from the database by the calendar feed token.
+func (s *UserRepository) GetByCalendarToken(ctx context.Context, token string) (*entity.User, error) {
+	var user entity.User
+	err := s.db.WithContext(ctx).Where("calendar_token = ?", token).First(&user).Error
+	return &user, err
+}
+
 func (s *UserRepository) GetMany(ctx context.Context, ids []int64) ([]entity.User, error) {
 	var users []entity.User
 	err := s.db.WithContext(ctx).Where("id IN ?", ids).Find(&users).Error
@@ -70,8 +78,10 @@ func (s *UserRepository) GetAll(ctx context.Context) ([]entity.User, error) {
 func (s *UserRepository) GetEventUsers(ctx context.Context, eventID string) ([]dto.EventUser, error) {
 	type userWithQR struct {
 		entity.User
-		IsUserQr  bool
-		IsEventQr bool
+		IsUserQr     bool
+		IsEventQr    bool
+		CheckedInAt  *time.Time
+		CheckedOutAt *time.Time
 	}
 
 	var users []userWithQR
@@ -79,7 +89,7 @@ func (s *UserRepository) GetEventUsers(ctx context.Context, eventID string) ([]d
 	err := s.db.
 		WithContext(ctx).
 		Table("event_participants").
-		Select("users.*, event_participants.is_user_qr, event_participants.is_event_qr").
+		Select("users.*, event_participants.is_user_qr, event_participants.is_event_qr, event_participants.checked_in_at, event_participants.checked_out_at").
 		Joins("inner join users on event_participants.user_id = users.id").
 		Where("event_participants.event_id = ?", eventID).
 		Preload("IgnoreMailing").
@@ -91,6 +101,8 @@ func (s *UserRepository) GetEventUsers(ctx context.Context, eventID string) ([]d
 	result := make([]dto.EventUser, len(users))
 	for i, user := range users {
 		result[i] = dto.NewEventUserFromEntity(user.User, user.IsUserQr || user.IsEventQr)
+		result[i].CheckedInAt = user.CheckedInAt
+		result[i].CheckedOutAt = user.CheckedOutAt
 	}
 
 	return result, nil
@@ -111,7 +123,7 @@ func (s *UserRepository) GetUsersByEventID(ctx context.Context, eventID string)
 	return users, err
 }
 
-// GetUsersByClubID is a function that returns all user that registered to club event at least once
+// GetUsersByClubID is a function that returns all user that registered to club event (or event co-hosted by the club) at least once
 func (s *UserRepository) GetUsersByClubID(ctx context.Context, clubID string) ([]entity.User, error) {
 	var users []entity.User
 
@@ -121,7 +133,7 @@ func (s *UserRepository) GetUsersByClubID(ctx context.Context, clubID string) ([
 		Select("DISTINCT users.*").
 		Joins("inner join users on event_participants.user_id = users.id").
 		Joins("inner join events on event_participants.event_id = events.id").
-		Where("events.club_id = ?", clubID).
+		Where(clubEventsCondition, clubID, clubID).
 		Preload("IgnoreMailing").
 		Find(&users).Error
 	return users, err
@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+)
+
+type VenueRepository struct {
+	db *gorm.DB
+}
+
+func NewVenueRepository(db *gorm.DB) *VenueRepository {
+	return &VenueRepository{
+		db: db,
+	}
+}
+
+func (s *VenueRepository) Create(ctx context.Context, venue *entity.Venue) (*entity.Venue, error) {
+	err := s.db.WithContext(ctx).Create(&venue).Error
+	return venue, err
+}
+
+func (s *VenueRepository) Get(ctx context.Context, id string) (*entity.Venue, error) {
+	var venue entity.Venue
+	err := s.db.WithContext(ctx).Where("id = ?", id).First(&venue).Error
+	return &venue, err
+}
+
+func (s *VenueRepository) GetAll(ctx context.Context) ([]entity.Venue, error) {
+	var venues []entity.Venue
+	err := s.db.WithContext(ctx).Order("name asc").Find(&venues).Error
+	return venues, err
+}
+
+func (s *VenueRepository) Update(ctx context.Context, venue *entity.Venue) (*entity.Venue, error) {
+	err := s.db.WithContext(ctx).Save(&venue).Error
+	return venue, err
+}
+
+// Delete is a function that deletes a venue, its events keep the location text but are no longer linked to it.
+func (s *VenueRepository) Delete(ctx context.Context, id string) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ?", id).Delete(&entity.Venue{}).Error; err != nil {
+			return err
+		}
+		return tx.
+			Model(&entity.Event{}).
+			Where("venue_id = ?", id).
+			Update("venue_id", nil).Error
+	})
+}
@@ -3,9 +3,11 @@ package events
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/dto"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
 
 	"github.com/redis/go-redis/v9"
@@ -56,3 +58,53 @@ func (s *Storage) SetEventID(userID int64, key, eventID string, expiration time.
 func (s *Storage) Clear(userID int64) {
 	s.redis.Del(context.Background(), fmt.Sprintf("%d", userID))
 }
+
+func (s *Storage) GetSeries(userID int64) (entity.EventSeries, error) {
+	seriesBytes, err := s.redis.Get(context.Background(), fmt.Sprintf("series:%d", userID)).Result()
+	if err != nil {
+		return entity.EventSeries{}, err
+	}
+
+	var series entity.EventSeries
+	if err = json.Unmarshal([]byte(seriesBytes), &series); err != nil {
+		return entity.EventSeries{}, err
+	}
+
+	return series, nil
+}
+
+func (s *Storage) SetSeries(userID int64, series entity.EventSeries, expiration time.Duration) {
+	seriesBytes, _ := json.Marshal(series)
+	s.redis.Set(context.Background(), fmt.Sprintf("series:%d", userID), seriesBytes, expiration)
+}
+
+func (s *Storage) ClearSeries(userID int64) {
+	s.redis.Del(context.Background(), fmt.Sprintf("series:%d", userID))
+}
+
+// GetFilter returns the events list filter chosen by the user, empty filter if it is not set
+func (s *Storage) GetFilter(userID int64) (dto.EventFilter, error) {
+	filterBytes, err := s.redis.Get(context.Background(), fmt.Sprintf("filter:%d", userID)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return dto.EventFilter{}, nil
+		}
+		return dto.EventFilter{}, err
+	}
+
+	var filter dto.EventFilter
+	if err = json.Unmarshal([]byte(filterBytes), &filter); err != nil {
+		return dto.EventFilter{}, err
+	}
+
+	return filter, nil
+}
+
+func (s *Storage) SetFilter(userID int64, filter dto.EventFilter, expiration time.Duration) {
+	filterBytes, _ := json.Marshal(filter)
+	s.redis.Set(context.Background(), fmt.Sprintf("filter:%d", userID), filterBytes, expiration)
+}
+
+func (s *Storage) ClearFilter(userID int64) {
+	s.redis.Del(context.Background(), fmt.Sprintf("filter:%d", userID))
+}
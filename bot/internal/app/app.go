@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"go.uber.org/zap/zapcore"
 
@@ -62,9 +63,26 @@ func (a *App) Run() {
 		a.serviceProvider.Bot().Start()
 	}()
 
+	// Start HTTP server with calendar feeds
+	if a.serviceProvider.Cfg().HTTP.Enabled() {
+		a.serviceProvider.HTTPServer().Start()
+	}
+
 	// Start notification scheduler
 	a.serviceProvider.NotifyService().StartNotifyScheduler()
 
+	// Start feedback scheduler
+	a.serviceProvider.FeedbackService().StartFeedbackScheduler()
+
+	// Start attendance report scheduler
+	a.serviceProvider.AttendanceReportService().StartReportScheduler()
+
+	// Start waitlist scheduler
+	a.serviceProvider.EventParticipantService().StartWaitlistScheduler()
+
+	// Start drafts publish scheduler
+	a.serviceProvider.EventPublishService().StartPublishScheduler()
+
 	// Start pass scheduler
 	err := a.serviceProvider.PassService().StartScheduler()
 	if err != nil {
@@ -89,6 +107,18 @@ func (a *App) gracefulShutdown() {
 			logger.Log.Info("Pass scheduler stopped")
 		}
 
+		// Stop the HTTP server
+		if a.serviceProvider.httpServer != nil {
+			logger.Log.Info("Stopping HTTP server...")
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			if err := a.serviceProvider.httpServer.Shutdown(ctx); err != nil {
+				logger.Log.Errorf("Error stopping HTTP server: %v", err)
+			} else {
+				logger.Log.Info("HTTP server stopped")
+			}
+			cancel()
+		}
+
 		// Stop the bot
 		if a.serviceProvider.Bot() != nil {
 			logger.Log.Info("Stopping bot...")
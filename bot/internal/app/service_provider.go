@@ -15,6 +15,7 @@ import (
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/ports/secondary"
 
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/adapters/config"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/adapters/primary/httpserver"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/adapters/primary/telegram/bot"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/adapters/primary/telegram/handlers/admin"
 	clubowner "github.com/Badsnus/cu-clubs-bot/bot/internal/adapters/primary/telegram/handlers/clubOwner"
@@ -43,14 +44,25 @@ type serviceProvider struct {
 	// Bot dependencies
 	bot *bot.Bot
 
+	// HTTP server
+	httpServer *httpserver.Server
+
 	// Storage layer
 	userRepo             secondary.UserRepository
 	clubRepo             secondary.ClubRepository
 	eventRepo            secondary.EventRepository
+	eventSeriesRepo      secondary.EventSeriesRepository
 	eventParticipantRepo secondary.EventParticipantRepository
+	eventWaitlistRepo    secondary.EventWaitlistRepository
+	eventQuestionRepo    secondary.EventQuestionRepository
+	eventGuestRepo       secondary.EventGuestRepository
 	passRepo             secondary.PassRepository
 	clubOwnerRepo        secondary.ClubOwnerRepository
 	notificationRepo     secondary.NotificationRepository
+	searchRepo           secondary.SearchRepository
+	eventCoHostRepo      secondary.EventCoHostRepository
+	eventFeedbackRepo    secondary.EventFeedbackRepository
+	venueRepo            secondary.VenueRepository
 
 	// Service layer
 	userService             primary.UserService
@@ -62,6 +74,12 @@ type serviceProvider struct {
 	notifyService           primary.NotifyService
 	qrService               primary.QrService
 	versionService          primary.VersionService
+	searchService           primary.SearchService
+	eventCoHostService      primary.EventCoHostService
+	feedbackService         primary.FeedbackService
+	eventPublishService     primary.EventPublishService
+	venueService            primary.VenueService
+	attendanceReportService primary.AttendanceReportService
 
 	// Handlers
 	adminHandler       *admin.Handler
@@ -144,6 +162,11 @@ func (s *serviceProvider) DB() *gorm.DB {
 		if errMigrate != nil {
 			panic(fmt.Errorf("failed to migrate database: %w", errMigrate))
 		}
+		for _, index := range postgres.Indexes {
+			if errIndex := database.Exec(index).Error; errIndex != nil {
+				panic(fmt.Errorf("failed to create index: %w", errIndex))
+			}
+		}
 
 		s.db = database
 	}
@@ -219,14 +242,46 @@ func (s *serviceProvider) EventRepo() secondary.EventRepository {
 	return s.eventRepo
 }
 
+func (s *serviceProvider) EventSeriesRepo() secondary.EventSeriesRepository {
+	if s.eventSeriesRepo == nil {
+		s.eventSeriesRepo = postgres.NewEventSeriesRepository(s.DB())
+	}
+
+	return s.eventSeriesRepo
+}
+
 func (s *serviceProvider) EventParticipantRepo() secondary.EventParticipantRepository {
 	if s.eventParticipantRepo == nil {
-		s.eventParticipantRepo = postgres.NewEventParticipantRepository(s.DB())
+		s.eventParticipantRepo = postgres.NewEventParticipantRepository(s.DB(), s.cfg.Deadlines)
 	}
 
 	return s.eventParticipantRepo
 }
 
+func (s *serviceProvider) EventWaitlistRepo() secondary.EventWaitlistRepository {
+	if s.eventWaitlistRepo == nil {
+		s.eventWaitlistRepo = postgres.NewEventWaitlistRepository(s.DB())
+	}
+
+	return s.eventWaitlistRepo
+}
+
+func (s *serviceProvider) EventQuestionRepo() secondary.EventQuestionRepository {
+	if s.eventQuestionRepo == nil {
+		s.eventQuestionRepo = postgres.NewEventQuestionRepository(s.DB())
+	}
+
+	return s.eventQuestionRepo
+}
+
+func (s *serviceProvider) EventGuestRepo() secondary.EventGuestRepository {
+	if s.eventGuestRepo == nil {
+		s.eventGuestRepo = postgres.NewEventGuestRepository(s.DB(), s.cfg.Deadlines)
+	}
+
+	return s.eventGuestRepo
+}
+
 func (s *serviceProvider) PassRepo() secondary.PassRepository {
 	if s.passRepo == nil {
 		s.passRepo = postgres.NewPassRepository(s.DB())
@@ -251,6 +306,38 @@ func (s *serviceProvider) NotificationRepo() secondary.NotificationRepository {
 	return s.notificationRepo
 }
 
+func (s *serviceProvider) SearchRepo() secondary.SearchRepository {
+	if s.searchRepo == nil {
+		s.searchRepo = postgres.NewSearchRepository(s.DB())
+	}
+
+	return s.searchRepo
+}
+
+func (s *serviceProvider) EventCoHostRepo() secondary.EventCoHostRepository {
+	if s.eventCoHostRepo == nil {
+		s.eventCoHostRepo = postgres.NewEventCoHostRepository(s.DB())
+	}
+
+	return s.eventCoHostRepo
+}
+
+func (s *serviceProvider) EventFeedbackRepo() secondary.EventFeedbackRepository {
+	if s.eventFeedbackRepo == nil {
+		s.eventFeedbackRepo = postgres.NewEventFeedbackRepository(s.DB())
+	}
+
+	return s.eventFeedbackRepo
+}
+
+func (s *serviceProvider) VenueRepo() secondary.VenueRepository {
+	if s.venueRepo == nil {
+		s.venueRepo = postgres.NewVenueRepository(s.DB())
+	}
+
+	return s.venueRepo
+}
+
 // Service layer
 
 func (s *serviceProvider) UserService() primary.UserService {
@@ -274,14 +361,48 @@ func (s *serviceProvider) ClubService() primary.ClubService {
 	return s.clubService
 }
 
+func (s *serviceProvider) SearchService() primary.SearchService {
+	if s.searchService == nil {
+		s.searchService = service.NewSearchService(s.SearchRepo())
+	}
+
+	return s.searchService
+}
+
 func (s *serviceProvider) EventService() primary.EventService {
 	if s.eventService == nil {
-		s.eventService = service.NewEventService(s.EventRepo())
+		s.eventService = service.NewEventService(
+			s.EventRepo(),
+			s.EventSeriesRepo(),
+			s.PassRepo(),
+			s.NotificationRepo(),
+			s.VenueRepo(),
+			s.cfg.Deadlines,
+		)
 	}
 
 	return s.eventService
 }
 
+func (s *serviceProvider) VenueService() primary.VenueService {
+	if s.venueService == nil {
+		s.venueService = service.NewVenueService(s.VenueRepo())
+	}
+
+	return s.venueService
+}
+
+func (s *serviceProvider) EventCoHostService() primary.EventCoHostService {
+	if s.eventCoHostService == nil {
+		s.eventCoHostService = service.NewEventCoHostService(
+			s.EventCoHostRepo(),
+			s.EventRepo(),
+		)
+	}
+
+	return s.eventCoHostService
+}
+
 func (s *serviceProvider) EventParticipantService() primary.EventParticipantService {
 	if s.eventParticipantService == nil {
 		botLogger, err := logger.Named("event-participant")
@@ -295,7 +416,14 @@ func (s *serviceProvider) EventParticipantService() primary.EventParticipantServ
 			s.EventRepo(),
 			s.PassRepo(),
 			s.UserRepo(),
+			s.EventWaitlistRepo(),
+			s.EventQuestionRepo(),
+			s.EventGuestRepo(),
+			s.NotifyService(),
 			s.cfg.App.PassExcludedRoles(),
+			s.cfg.App.WaitlistOfferTTL(),
+			s.cfg.Deadlines,
+			s.cfg.NoShows,
 		)
 	}
 
@@ -315,6 +443,7 @@ func (s *serviceProvider) PassService() primary.PassService {
 			s.PassRepo(),
 			s.EventRepo(),
 			s.UserRepo(),
+			s.EventGuestRepo(),
 			s.ClubRepo(),
 			s.SMTPClient(),
 			s.cfg.App.PassEmails(),
@@ -351,12 +480,79 @@ func (s *serviceProvider) NotifyService() primary.NotifyService {
 			s.EventRepo(),
 			s.NotificationRepo(),
 			s.EventParticipantRepo(),
+			s.UserRepo(),
 		)
 	}
 
 	return s.notifyService
 }
 
+func (s *serviceProvider) FeedbackService() primary.FeedbackService {
+	if s.feedbackService == nil {
+		feedbackLogger, err := logger.Named("feedback")
+		if err != nil {
+			panic(fmt.Errorf("failed to create feedback logger: %w", err))
+		}
+
+		s.feedbackService = service.NewFeedbackService(
+			s.Bot().Bot,
+			s.Bot().Layout,
+			feedbackLogger,
+			s.EventFeedbackRepo(),
+			s.EventRepo(),
+			s.EventParticipantRepo(),
+			s.NotificationRepo(),
+			s.cfg.App.FeedbackDelay(),
+		)
+	}
+
+	return s.feedbackService
+}
+
+func (s *serviceProvider) AttendanceReportService() primary.AttendanceReportService {
+	if s.attendanceReportService == nil {
+		reportLogger, err := logger.Named("attendance-report")
+		if err != nil {
+			panic(fmt.Errorf("failed to create attendance report logger: %w", err))
+		}
+
+		s.attendanceReportService = service.NewAttendanceReportService(
+			s.Bot().Bot,
+			s.Bot().Layout,
+			reportLogger,
+			s.EventRepo(),
+			s.NotificationRepo(),
+			s.EventCoHostRepo(),
+			s.ClubOwnerService(),
+			s.UserService(),
+			s.EventParticipantService(),
+			s.cfg.App.AttendanceReportDelay(),
+		)
+	}
+
+	return s.attendanceReportService
+}
+
+func (s *serviceProvider) EventPublishService() primary.EventPublishService {
+	if s.eventPublishService == nil {
+		publishLogger, err := logger.Named("event-publish")
+		if err != nil {
+			panic(fmt.Errorf("failed to create event publish logger: %w", err))
+		}
+
+		s.eventPublishService = service.NewEventPublishService(
+			s.Bot().Bot,
+			s.Bot().Layout,
+			publishLogger,
+			s.EventRepo(),
+			s.ClubRepo(),
+			s.UserRepo(),
+		)
+	}
+
+	return s.eventPublishService
+}
+
 func (s *serviceProvider) QrService() primary.QrService {
 	if s.qrService == nil {
 		qrSrvc, err := service.NewQrService(
@@ -405,6 +601,27 @@ func (s *serviceProvider) setBot(b *bot.Bot) {
 	s.bot = b
 }
 
+// HTTP server
+
+func (s *serviceProvider) HTTPServer() *httpserver.Server {
+	if s.httpServer == nil {
+		server, err := httpserver.New(
+			s.cfg.HTTP.Address(),
+			s.Bot().Me.Username,
+			s.UserService(),
+			s.EventService(),
+			s.ClubService(),
+		)
+		if err != nil {
+			panic(fmt.Errorf("failed to create http server: %w", err))
+		}
+
+		s.httpServer = server
+	}
+
+	return s.httpServer
+}
+
 // Handlers
 
 func (s *serviceProvider) AdminHandler() *admin.Handler {
@@ -413,6 +630,7 @@ func (s *serviceProvider) AdminHandler() *admin.Handler {
 			s.UserService(),
 			s.ClubService(),
 			s.ClubOwnerService(),
+			s.VenueService(),
 			s.Bot().Bot,
 			s.Bot().Layout,
 			s.Bot().Logger,
@@ -431,6 +649,8 @@ func (s *serviceProvider) UserHandler() *user.Handler {
 			s.EventParticipantService(),
 			s.QrService(),
 			s.NotifyService(),
+			s.SearchService(),
+			s.FeedbackService(),
 			s.MenuHandler(),
 			s.Redis().Codes,
 			s.Redis().Emails,
@@ -445,6 +665,7 @@ func (s *serviceProvider) UserHandler() *user.Handler {
 			s.Cfg().Session.EmailTTL(),
 			s.Cfg().Session.AuthTTL(),
 			s.Cfg().Session.ResendTTL(),
+			s.Cfg().HTTP.PublicURL(),
 		)
 	}
 	return s.userHandler
@@ -507,13 +728,18 @@ func (s *serviceProvider) ClubOwnerHandler() *clubowner.Handler {
 			s.Bot().Logger,
 			s.Bot().Input,
 			s.Redis().Events,
+			s.Redis().Callbacks,
 			s.ClubService(),
 			s.ClubOwnerService(),
 			s.UserService(),
 			s.EventService(),
 			s.EventParticipantService(),
+			s.EventCoHostService(),
+			s.FeedbackService(),
+			s.EventPublishService(),
 			s.QrService(),
 			s.NotifyService(),
+			s.VenueService(),
 			s.Cfg().Bot.MailingChannelID(),
 			s.Cfg().Bot.AvatarChannelID(),
 			s.Cfg().Bot.IntroChannelID(),
@@ -1,8 +1,41 @@
 package errorz
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrInvalidCallbackData = errors.New("invalid callback data")
 	ErrInvalidCode         = errors.New("invalid code")
+
+	ErrEventFull            = errors.New("event is full")
+	ErrRegistrationClosed   = errors.New("registration is closed")
+	ErrRoleNotAllowed       = errors.New("role is not allowed")
+	ErrWaitlistOfferExpired = errors.New("waitlist offer expired")
+	ErrEventCancelled       = errors.New("event is cancelled")
+	ErrEventDraft           = errors.New("event is not published yet")
+	ErrEventNotDraft        = errors.New("event is already published")
+	ErrGuestsNotAllowed     = errors.New("event does not allow guests")
+	ErrTooManyGuests        = errors.New("guests limit of the participant is reached")
+	ErrNotRegistered        = errors.New("user is not registered for the event")
+	ErrNotPending           = errors.New("registration is not waiting for approval")
+	ErrRegistrationBlocked  = errors.New("registration is blocked for no-shows")
+
+	// ErrRoleQuotaFull wraps ErrEventFull, so the user can join the waitlist like for a full event
+	ErrRoleQuotaFull = fmt.Errorf("%w: no seats left for the role", ErrEventFull)
+
+	ErrEmptySeries = errors.New("series has no occurrences")
+
+	ErrInvalidAnswer = errors.New("answer does not fit the registration form question")
+
+	ErrInvalidFeedbackRating = errors.New("invalid feedback rating")
+	ErrFeedbackNotAllowed    = errors.New("only visitors of the event can leave feedback")
+
+	ErrCoHostIsPrimaryClub  = errors.New("club is the primary host of the event")
+	ErrCoHostAlreadyInvited = errors.New("club is already invited to co-host the event")
+	ErrNotEventHost         = errors.New("user does not own any host club of the event")
+
+	ErrVenueBooked           = errors.New("venue is booked by another event at this time")
+	ErrVenueCapacityExceeded = errors.New("max participants exceed the venue capacity")
 )
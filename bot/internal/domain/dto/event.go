@@ -16,6 +16,7 @@ type Event struct {
 	RegistrationEnd time.Time
 	StartTime       time.Time
 	EndTime         time.Time
+	IsOnline        bool
 	IsRegistered    bool
 }
 
@@ -30,6 +31,7 @@ func NewEventFromEntity(event entity.Event, isRegistered bool) Event {
 		RegistrationEnd: event.RegistrationEnd,
 		StartTime:       event.StartTime,
 		EndTime:         event.EndTime,
+		IsOnline:        event.HasOnlinePart(),
 		IsRegistered:    isRegistered,
 	}
 }
@@ -0,0 +1,80 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/location"
+)
+
+type EventPeriod string
+
+const (
+	EventPeriodToday   EventPeriod = "today"
+	EventPeriodWeek    EventPeriod = "week"
+	EventPeriodWeekend EventPeriod = "weekend"
+)
+
+// EventPeriods - all periods in the order they are shown to users
+var EventPeriods = []EventPeriod{EventPeriodToday, EventPeriodWeek, EventPeriodWeekend}
+
+// IsValid checks if the period is one of EventPeriods
+func (p EventPeriod) IsValid() bool {
+	switch p {
+	case EventPeriodToday, EventPeriodWeek, EventPeriodWeekend:
+		return true
+	}
+	return false
+}
+
+// Range returns the time range [from, to) of the period relative to now
+//
+// The week ends on Sunday, the weekend is the nearest Saturday and Sunday (the current one on weekends)
+func (p EventPeriod) Range(now time.Time) (time.Time, time.Time) {
+	now = now.In(location.Location())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	nextMonday := today.AddDate(0, 0, 7-(int(today.Weekday())+6)%7)
+
+	switch p {
+	case EventPeriodToday:
+		return today, today.AddDate(0, 0, 1)
+	case EventPeriodWeek:
+		return today, nextMonday
+	case EventPeriodWeekend:
+		from := nextMonday.AddDate(0, 0, -2)
+		if today.After(from) {
+			from = today
+		}
+		return from, nextMonday
+	}
+	return time.Time{}, time.Time{}
+}
+
+// EventFilter - filter of the events list, empty fields are not applied
+type EventFilter struct {
+	Category entity.EventCategory
+	ClubID   string
+	Period   EventPeriod
+}
+
+// IsEmpty checks if no filter is applied
+func (f EventFilter) IsEmpty() bool {
+	return f.Category == "" && f.ClubID == "" && f.Period == ""
+}
+
+// ClubEventsCount - number of events of the club matching the filter
+type ClubEventsCount struct {
+	ClubID   string
+	ClubName string
+	Count    int64
+}
+
+// EventFilterCounts - number of events for each value of the filters.
+//
+// Each count is computed with the other filters applied, so it is the number of events
+// the user will see after choosing this value
+type EventFilterCounts struct {
+	Categories map[entity.EventCategory]int64
+	Clubs      []ClubEventsCount
+	Periods    map[EventPeriod]int64
+}
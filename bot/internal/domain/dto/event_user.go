@@ -1,12 +1,22 @@
 package dto
 
 import (
+	"time"
+
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
 )
 
 type EventUser struct {
-	User      entity.User
-	UserVisit bool
+	User         entity.User
+	UserVisit    bool
+	CheckedInAt  *time.Time
+	CheckedOutAt *time.Time
+}
+
+// AttendanceDuration returns the time the user spent on the event, 0 if the user has not checked out
+func (u EventUser) AttendanceDuration() time.Duration {
+	participant := entity.EventParticipant{CheckedInAt: u.CheckedInAt, CheckedOutAt: u.CheckedOutAt}
+	return participant.AttendanceDuration()
 }
 
 func NewEventUserFromEntity(user entity.User, userVisit bool) EventUser {
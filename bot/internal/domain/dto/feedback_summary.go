@@ -0,0 +1,8 @@
+package dto
+
+// FeedbackSummary is an aggregated feedback of an event or of all events of a club
+type FeedbackSummary struct {
+	Count         int64
+	Average       float64
+	CommentsCount int64
+}
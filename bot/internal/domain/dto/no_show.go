@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+// NoShowStanding - no-shows of the user within the period of the no-show policy
+type NoShowStanding struct {
+	NoShows    int
+	Limit      int
+	PeriodDays int
+	// Restricted - the limit is reached
+	Restricted bool
+	// BlockedUntil - zero if the user can register
+	BlockedUntil time.Time
+	// WaitlistLast - restricted users are the last in waitlists
+	WaitlistLast bool
+}
+
+// IsEnabled checks if the no-show policy is configured
+func (s NoShowStanding) IsEnabled() bool {
+	return s.Limit > 0
+}
+
+// IsBlocked checks if the user can't register at the moment
+func (s NoShowStanding) IsBlocked(now time.Time) bool {
+	return now.Before(s.BlockedUntil)
+}
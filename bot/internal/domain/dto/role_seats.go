@@ -0,0 +1,26 @@
+package dto
+
+import (
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+)
+
+// RoleSeats - seats of the event taken by users with the role
+type RoleSeats struct {
+	Role entity.Role
+	// Quota - 0 if the role has no quota
+	Quota int
+	// Taken - participants with the role together with their guests
+	Taken int
+	// Offered - seats offered to waitlisted users with the role
+	Offered int
+}
+
+// Left returns the number of free seats of the quota
+func (s RoleSeats) Left() int {
+	return max(0, s.Quota-s.Taken-s.Offered)
+}
+
+// IsFull checks if the role has a quota and it is reached
+func (s RoleSeats) IsFull() bool {
+	return s.Quota > 0 && s.Taken+s.Offered >= s.Quota
+}
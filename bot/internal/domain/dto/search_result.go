@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+type SearchResultType string
+
+const (
+	SearchResultEvent SearchResultType = "event"
+	SearchResultClub  SearchResultType = "club"
+)
+
+// SearchResult - an event or a club found by the search query
+type SearchResult struct {
+	Type SearchResultType
+	ID   string
+	Name string
+	// StartTime - start time of the event, nil for clubs
+	StartTime *time.Time
+	Rank      float64
+}
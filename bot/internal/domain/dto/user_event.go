@@ -23,6 +23,10 @@ type UserEvent struct {
 	ExpectedParticipants  int
 	AllowedRoles          pq.StringArray
 	IsVisited             bool
+	IsCancelled           bool
+	CancelReason          string
+	// HasConflict - the event overlaps another upcoming event the user is registered on
+	HasConflict bool
 }
 
 func NewUserEventFromEntity(event entity.Event, isVisited bool) UserEvent {
@@ -40,9 +44,24 @@ func NewUserEventFromEntity(event entity.Event, isVisited bool) UserEvent {
 		ExpectedParticipants:  event.ExpectedParticipants,
 		AllowedRoles:          event.AllowedRoles,
 		IsVisited:             isVisited,
+		IsCancelled:           event.IsCancelled(),
+		CancelReason:          event.CancelReason,
 	}
 }
 
 func (e *UserEvent) IsOver(additionalTime time.Duration) bool {
 	return e.StartTime.Before(time.Now().In(location.Location()).Add(-additionalTime))
 }
+
+// End returns the end time of the event, events without the end time are considered to last one hour
+func (e *UserEvent) End() time.Time {
+	if e.EndTime.IsZero() {
+		return e.StartTime.Add(time.Hour)
+	}
+	return e.EndTime
+}
+
+// Overlaps checks if the events run at the same time, events that touch each other do not overlap
+func (e *UserEvent) Overlaps(other UserEvent) bool {
+	return e.StartTime.Before(other.End()) && other.StartTime.Before(e.End())
+}
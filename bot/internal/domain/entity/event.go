@@ -1,16 +1,114 @@
 package entity
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
 	"slices"
 	"time"
 
 	"github.com/lib/pq"
 	"gorm.io/gorm"
 
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/deadline"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/location"
 )
 
+type EventStatus string
+
+const (
+	EventStatusActive    EventStatus = "active"
+	EventStatusCancelled EventStatus = "cancelled"
+	// EventStatusDraft - the event is visible only to its club owners until it is published
+	EventStatusDraft EventStatus = "draft"
+)
+
+type EventFormat string
+
+const (
+	EventFormatOffline EventFormat = "offline"
+	EventFormatOnline  EventFormat = "online"
+	// EventFormatHybrid - the event takes place on site and is streamed online at the same time
+	EventFormatHybrid EventFormat = "hybrid"
+)
+
+// EventFormats is the order in which the club owner switches the format of the event
+var EventFormats = []EventFormat{
+	EventFormatOffline,
+	EventFormatOnline,
+	EventFormatHybrid,
+}
+
+func (f EventFormat) String() string {
+	return string(f)
+}
+
+// Next returns the format that follows f in EventFormats
+func (f EventFormat) Next() EventFormat {
+	i := slices.Index(EventFormats, f)
+	return EventFormats[(i+1)%len(EventFormats)]
+}
+
+// RoleDeadlines - registration deadlines set by the club owner for particular roles of the event,
+// they take precedence over the deadline policy from the config
+type RoleDeadlines map[string]time.Time
+
+func (d RoleDeadlines) Value() (driver.Value, error) {
+	if len(d) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(d)
+}
+
+func (d *RoleDeadlines) Scan(value interface{}) error {
+	if value == nil {
+		*d = nil
+		return nil
+	}
+
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return errors.New("unsupported type for RoleDeadlines")
+	}
+	return json.Unmarshal(data, d)
+}
+
+// RoleQuotas - the maximum number of seats for users with particular roles, guests take seats
+// from the quota of the participant who brought them. Roles without a quota are limited only by MaxParticipants
+type RoleQuotas map[string]int
+
+func (q RoleQuotas) Value() (driver.Value, error) {
+	if len(q) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(q)
+}
+
+func (q *RoleQuotas) Scan(value interface{}) error {
+	if value == nil {
+		*q = nil
+		return nil
+	}
+
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return errors.New("unsupported type for RoleQuotas")
+	}
+	return json.Unmarshal(data, q)
+}
+
 type Event struct {
 	ID                    string `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
 	CreatedAt             time.Time
@@ -30,6 +128,35 @@ type Event struct {
 	QRFileID              string
 	AllowedRoles          pq.StringArray `gorm:"type:text[]"`
 	PassRequired          bool           `gorm:"default:false"`
+	Categories            pq.StringArray `gorm:"type:text[]"`
+	// ExitQRCodeID - the QR code that participants scan when they leave the event
+	ExitQRCodeID string
+	ExitQRFileID string
+	// MaxGuests - how many guests every participant can bring, 0 if guests are not allowed
+	MaxGuests int `gorm:"not null;default:0"`
+	// ApprovalRequired - registrations stay pending until a club owner approves them
+	ApprovalRequired bool          `gorm:"not null;default:false"`
+	Format           EventFormat   `gorm:"not null;default:'offline'"`
+	RoleDeadlines    RoleDeadlines `gorm:"type:jsonb"`
+	RoleQuotas       RoleQuotas    `gorm:"type:jsonb"`
+	// MeetingURL - link to the online meeting, it is shown only to approved participants
+	MeetingURL string
+	// SeriesID - id of the EventSeries if the event is an occurrence of a recurring event
+	SeriesID *string `gorm:"type:uuid;index"`
+	// VenueID - id of the Venue from the catalogue, nil if the location is entered as free text
+	VenueID *string `gorm:"type:uuid;index"`
+	// Status - cancelled events are kept for participants' history instead of being deleted
+	Status       EventStatus `gorm:"not null;default:'active'"`
+	CancelReason string
+	CancelledAt  *time.Time
+	// PublishAt - when the draft is published by the scheduler, nil if the draft is published manually
+	PublishAt *time.Time
+	// AnnounceOnPublish - send a club mailing about the event when the draft is published
+	AnnounceOnPublish bool `gorm:"not null;default:false"`
+	// ReminderOffsets - reminders chosen by the club owner, DefaultReminderOffsets are sent if it's empty
+	ReminderOffsets ReminderOffsets `gorm:"type:integer[]"`
+	// Questions - registration form, is filled only when the event is created
+	Questions []EventQuestion `gorm:"foreignKey:EventID"`
 }
 
 // IsOver checks if the event is over, considering the additional time
@@ -51,9 +178,199 @@ func (e *Event) Link(botName string) string {
 	return fmt.Sprintf("https://t.me/%s?start=event_%s", botName, e.ID)
 }
 
+// End returns the end time of the event, events without the end time are considered to last one hour
+func (e *Event) End() time.Time {
+	if e.EndTime.IsZero() {
+		return e.StartTime.Add(time.Hour)
+	}
+	return e.EndTime
+}
+
+// Reminders returns the offsets of the reminders about the event
+func (e *Event) Reminders() ReminderOffsets {
+	if len(e.ReminderOffsets) == 0 {
+		return DefaultReminderOffsets
+	}
+	return e.ReminderOffsets
+}
+
+// SetVenue places the event in the venue: the location is taken from the venue
+// and passes are required if the venue is on campus
+func (e *Event) SetVenue(venue *Venue) {
+	venueID := venue.ID
+	e.VenueID = &venueID
+	e.Location = venue.Location()
+	e.PassRequired = venue.OnCampus
+}
+
+// BooksVenue checks if the event takes its venue at its time, cancelled and online events don't
+func (e *Event) BooksVenue() bool {
+	return e.VenueID != nil && !e.IsCancelled() && !e.IsOnline()
+}
+
+// IsRecurring checks if the event is an occurrence of a recurring event
+func (e *Event) IsRecurring() bool {
+	return e.SeriesID != nil
+}
+
+// IsCancelled checks if the event was cancelled by the club owner
+func (e *Event) IsCancelled() bool {
+	return e.Status == EventStatusCancelled
+}
+
+// IsDraft checks if the event has not been published yet
+func (e *Event) IsDraft() bool {
+	return e.Status == EventStatusDraft
+}
+
+// IsOnline checks if the event takes place only online, such events never need passes
+func (e *Event) IsOnline() bool {
+	return e.Format == EventFormatOnline
+}
+
+// HasOnlinePart checks if participants can join the event online
+func (e *Event) HasOnlinePart() bool {
+	return e.Format == EventFormatOnline || e.Format == EventFormatHybrid
+}
+
+// IsPassRequired checks if passes are issued for the event, online events skip them even if PassRequired is set
+func (e *Event) IsPassRequired() bool {
+	return e.PassRequired && !e.IsOnline()
+}
+
+// Publish makes the draft visible to users
+func (e *Event) Publish() {
+	e.Status = EventStatusActive
+	e.PublishAt = nil
+}
+
+// Duplicate returns a draft with the content and the registration settings of the event.
+// The dates are left empty, questions are copied without their ids so they are created with the new event.
+func (e *Event) Duplicate(questions []EventQuestion) Event {
+	form := make([]EventQuestion, 0, len(questions))
+	for _, question := range questions {
+		form = append(form, EventQuestion{
+			Position: question.Position,
+			Text:     question.Text,
+			Type:     question.Type,
+			Options:  slices.Clone(question.Options),
+			Required: question.Required,
+		})
+	}
+
+	return Event{
+		ClubID:                e.ClubID,
+		Name:                  e.Name,
+		Description:           e.Description,
+		AfterRegistrationText: e.AfterRegistrationText,
+		Location:              e.Location,
+		VenueID:               e.VenueID,
+		MaxParticipants:       e.MaxParticipants,
+		ExpectedParticipants:  e.ExpectedParticipants,
+		AllowedRoles:          slices.Clone(e.AllowedRoles),
+		PassRequired:          e.PassRequired,
+		Categories:            slices.Clone(e.Categories),
+		MaxGuests:             e.MaxGuests,
+		RoleQuotas:            maps.Clone(e.RoleQuotas),
+		ApprovalRequired:      e.ApprovalRequired,
+		Format:                e.Format,
+		MeetingURL:            e.MeetingURL,
+		ReminderOffsets:       slices.Clone(e.ReminderOffsets),
+		Status:                EventStatusDraft,
+		Questions:             form,
+	}
+}
+
+// Cancel marks the event as cancelled with the given reason
+func (e *Event) Cancel(reason string, now time.Time) {
+	e.Status = EventStatusCancelled
+	e.CancelReason = reason
+	e.CancelledAt = &now
+}
+
+// HasCategory checks if the event is tagged with the given category
+func (e *Event) HasCategory(category EventCategory) bool {
+	return slices.Contains(e.Categories, category.String())
+}
+
+// ToggleCategory adds the category to the event or removes it if it is already there
+func (e *Event) ToggleCategory(category EventCategory) {
+	i := slices.Index(e.Categories, category.String())
+	if i == -1 {
+		e.Categories = append(e.Categories, category.String())
+		return
+	}
+	e.Categories = slices.Delete(e.Categories, i, i+1)
+}
+
+// IsRoleAllowed checks if users with the given role can register for the event
+func (e *Event) IsRoleAllowed(role Role) bool {
+	return slices.Contains(e.AllowedRoles, role.String())
+}
+
+// RegistrationDeadline returns the registration end for the given role
+//
+// The deadline set for the role on the event wins, otherwise the rule of the role from the deadline policy is used.
+// In both cases the deadline is never later than RegistrationEnd
+func (e *Event) RegistrationDeadline(role Role, policy deadline.Policy) time.Time {
+	if at, ok := e.RoleDeadlines[role.String()]; ok {
+		if at.Before(e.RegistrationEnd) {
+			return at
+		}
+		return e.RegistrationEnd
+	}
+
+	return policy.Rule(role).Deadline(e.StartTime, e.RegistrationEnd)
+}
+
+// SetRoleDeadline overrides the deadline policy for the role, nil at removes the override
+func (e *Event) SetRoleDeadline(role Role, at *time.Time) {
+	if at == nil {
+		delete(e.RoleDeadlines, role.String())
+		return
+	}
+	if e.RoleDeadlines == nil {
+		e.RoleDeadlines = make(RoleDeadlines)
+	}
+	e.RoleDeadlines[role.String()] = at.UTC()
+}
+
+// HasRoleDeadline checks if the club owner has set the deadline for the role
+func (e *Event) HasRoleDeadline(role Role) bool {
+	_, ok := e.RoleDeadlines[role.String()]
+	return ok
+}
+
+// RoleQuota returns the quota of the role, 0 if the role has no quota
+func (e *Event) RoleQuota(role Role) int {
+	return e.RoleQuotas[role.String()]
+}
+
+// SetRoleQuota sets the quota of the role, 0 removes the quota
+func (e *Event) SetRoleQuota(role Role, quota int) {
+	if quota <= 0 {
+		delete(e.RoleQuotas, role.String())
+		return
+	}
+	if e.RoleQuotas == nil {
+		e.RoleQuotas = make(RoleQuotas)
+	}
+	e.RoleQuotas[role.String()] = quota
+}
+
+// HasRoleQuotas checks if any role of the event has a quota
+func (e *Event) HasRoleQuotas() bool {
+	return len(e.RoleQuotas) > 0
+}
+
+// IsRegistrationOpen checks if users with the given role can still register for the event
+func (e *Event) IsRegistrationOpen(role Role, now time.Time, policy deadline.Policy) bool {
+	return !e.IsCancelled() && e.RegistrationDeadline(role, policy).After(now)
+}
+
 // IsPassRequiredForUser checks if a pass is required for the given user
 func (e *Event) IsPassRequiredForUser(user *User, excludedRoles []string) bool {
-	if !e.PassRequired {
+	if !e.IsPassRequired() {
 		return false
 	}
 
@@ -64,44 +381,13 @@ func (e *Event) IsPassRequiredForUser(user *User, excludedRoles []string) bool {
 	return true
 }
 
-// CalculateScheduledAt calculates the scheduled time for pass sending based on cron schedule
-//
-// Правила отправки:
-// - События в Воскресенье или Понедельник → отправка в предыдущую Субботу 12:00
-// - События во Вторник-Пятницу → отправка в предыдущий день 16:00
-// - События в Субботу → отправка в Пятницу 16:00
-func (e *Event) CalculateScheduledAt() time.Time {
-	loc := location.Location()
-	eventStart := e.StartTime.In(loc)
-	eventWeekday := eventStart.Weekday()
-
-	var scheduledAt time.Time
-
-	switch eventWeekday {
-	case time.Sunday:
-		// Воскресенье → отправка в Субботу 12:00
-		daysToSubtract := 1
-		sendDay := eventStart.AddDate(0, 0, -daysToSubtract)
-		scheduledAt = time.Date(sendDay.Year(), sendDay.Month(), sendDay.Day(), 12, 0, 0, 0, loc)
-
-	case time.Monday:
-		// Понедельник → отправка в Субботу 12:00
-		daysToSubtract := 2
-		sendDay := eventStart.AddDate(0, 0, -daysToSubtract)
-		scheduledAt = time.Date(sendDay.Year(), sendDay.Month(), sendDay.Day(), 12, 0, 0, 0, loc)
-
-	case time.Saturday:
-		// Суббота → отправка в Пятницу 16:00
-		daysToSubtract := 1
-		sendDay := eventStart.AddDate(0, 0, -daysToSubtract)
-		scheduledAt = time.Date(sendDay.Year(), sendDay.Month(), sendDay.Day(), 16, 0, 0, 0, loc)
-
-	default:
-		// Вторник-Пятница → отправка в предыдущий день 16:00
-		daysToSubtract := 1
-		sendDay := eventStart.AddDate(0, 0, -daysToSubtract)
-		scheduledAt = time.Date(sendDay.Year(), sendDay.Month(), sendDay.Day(), 16, 0, 0, 0, loc)
-	}
+// PassScheduledAt returns when the pass of the participant with the given role is sent:
+// passes are sent as soon as the registration for the role ends
+func (e *Event) PassScheduledAt(role Role, policy deadline.Policy) time.Time {
+	return e.RegistrationDeadline(role, policy)
+}
 
-	return scheduledAt
+// GuestPassScheduledAt returns when the guest passes are sent
+func (e *Event) GuestPassScheduledAt(policy deadline.Policy) time.Time {
+	return policy.GuestRule().Deadline(e.StartTime, e.RegistrationEnd)
 }
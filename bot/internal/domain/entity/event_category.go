@@ -0,0 +1,35 @@
+package entity
+
+import "slices"
+
+type EventCategory string
+
+const (
+	EventCategoryLecture   EventCategory = "lecture"
+	EventCategoryWorkshop  EventCategory = "workshop"
+	EventCategorySport     EventCategory = "sport"
+	EventCategoryParty     EventCategory = "party"
+	EventCategoryHackathon EventCategory = "hackathon"
+	EventCategoryMeetup    EventCategory = "meetup"
+	EventCategoryOther     EventCategory = "other"
+)
+
+// EventCategories - all categories in the order they are shown to users
+var EventCategories = []EventCategory{
+	EventCategoryLecture,
+	EventCategoryWorkshop,
+	EventCategorySport,
+	EventCategoryParty,
+	EventCategoryHackathon,
+	EventCategoryMeetup,
+	EventCategoryOther,
+}
+
+// IsValid checks if the category is one of EventCategories
+func (c EventCategory) IsValid() bool {
+	return slices.Contains(EventCategories, c)
+}
+
+func (c EventCategory) String() string {
+	return string(c)
+}
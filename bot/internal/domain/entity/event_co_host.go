@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// EventCoHost links an event to a club that hosts it together with the primary club (Event.ClubID).
+// The primary club invites a co-host, and the link takes effect once an owner of the co-host club accepts it
+type EventCoHost struct {
+	EventID   string `gorm:"primaryKey;type:uuid"`
+	ClubID    string `gorm:"primaryKey;type:uuid"`
+	Accepted  bool   `gorm:"default:false"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	Club Club `gorm:"foreignKey:ClubID"`
+}
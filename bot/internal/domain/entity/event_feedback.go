@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+const (
+	MinFeedbackRating = 1
+	MaxFeedbackRating = 5
+)
+
+// EventFeedback is a rating with an optional comment left by a participant who visited the event
+type EventFeedback struct {
+	EventID   string `gorm:"primaryKey;type:uuid"`
+	UserID    int64  `gorm:"primaryKey"`
+	Rating    int    `gorm:"not null"`
+	Comment   string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	User User `gorm:"foreignKey:UserID"`
+}
+
+// IsValidFeedbackRating reports whether the rating is in the allowed range
+func IsValidFeedbackRating(rating int) bool {
+	return rating >= MinFeedbackRating && rating <= MaxFeedbackRating
+}
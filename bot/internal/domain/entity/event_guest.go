@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// MaxEventGuests - the upper limit of Event.MaxGuests
+const MaxEventGuests = 5
+
+// EventGuest is a visitor without a Telegram account brought to the event by the participant UserID.
+// The guest takes a seat of the event and gets its own pass.
+type EventGuest struct {
+	ID        string `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	EventID   string `gorm:"not null;type:uuid;index"`
+	UserID    int64  `gorm:"not null;index"`
+	Name      string `gorm:"not null"`
+	Email     string
+	CreatedAt time.Time
+
+	User User `gorm:"foreignKey:UserID"`
+}
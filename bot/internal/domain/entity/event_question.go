@@ -0,0 +1,85 @@
+package entity
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// MaxEventQuestions - the maximum number of questions in the registration form of an event
+const MaxEventQuestions = 5
+
+type EventQuestionType string
+
+const (
+	EventQuestionTypeText   EventQuestionType = "text"
+	EventQuestionTypeChoice EventQuestionType = "choice"
+	EventQuestionTypeYesNo  EventQuestionType = "yes_no"
+)
+
+// Answers of the yes/no questions
+const (
+	AnswerYes = "yes"
+	AnswerNo  = "no"
+)
+
+var EventQuestionTypes = []EventQuestionType{
+	EventQuestionTypeText,
+	EventQuestionTypeChoice,
+	EventQuestionTypeYesNo,
+}
+
+func (t EventQuestionType) String() string {
+	return string(t)
+}
+
+func (t EventQuestionType) IsValid() bool {
+	return slices.Contains(EventQuestionTypes, t)
+}
+
+// EventQuestion is a question of the registration form, the user answers it before the registration completes
+type EventQuestion struct {
+	ID        string            `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	EventID   string            `gorm:"not null;type:uuid;index"`
+	Position  int               `gorm:"not null"`
+	Text      string            `gorm:"not null"`
+	Type      EventQuestionType `gorm:"not null"`
+	Options   pq.StringArray    `gorm:"type:text[]"`
+	Required  bool              `gorm:"not null;default:false"`
+	CreatedAt time.Time
+}
+
+// Choices returns the answers the user can choose from, nil for the text questions
+func (q *EventQuestion) Choices() []string {
+	switch q.Type {
+	case EventQuestionTypeChoice:
+		return q.Options
+	case EventQuestionTypeYesNo:
+		return []string{AnswerYes, AnswerNo}
+	default:
+		return nil
+	}
+}
+
+// IsValidAnswer checks if the answer fits the question, an empty answer is valid only for optional questions
+func (q *EventQuestion) IsValidAnswer(answer string) bool {
+	if strings.TrimSpace(answer) == "" {
+		return !q.Required
+	}
+	if q.Type == EventQuestionTypeText {
+		return true
+	}
+	return slices.Contains(q.Choices(), answer)
+}
+
+// EventAnswer is the answer of the participant to the question of the registration form
+type EventAnswer struct {
+	QuestionID string `gorm:"primaryKey;type:uuid"`
+	UserID     int64  `gorm:"primaryKey"`
+	EventID    string `gorm:"not null;type:uuid;index"`
+	Answer     string `gorm:"not null"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
@@ -0,0 +1,182 @@
+package entity
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/location"
+)
+
+type RecurrenceFrequency string
+
+const (
+	RecurrenceWeekly   RecurrenceFrequency = "weekly"
+	RecurrenceBiweekly RecurrenceFrequency = "biweekly"
+)
+
+// MaxSeriesOccurrences is the maximum number of events that can be generated for one series
+const MaxSeriesOccurrences = 52
+
+// rruleWeekdays maps time.Weekday to the iCalendar BYDAY values
+var rruleWeekdays = [7]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// EventSeries represents a recurring event
+//
+// Each occurrence of the series is stored as a separate Event with SeriesID set,
+// so occurrences can be edited, registered for and deleted independently
+type EventSeries struct {
+	ID        string `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt
+	ClubID    string              `gorm:"not null;type:uuid"`
+	Frequency RecurrenceFrequency `gorm:"not null"`
+	// Weekdays - days of the week (time.Weekday) on which the series repeats,
+	// if empty, the series repeats on the weekday of StartTime
+	Weekdays pq.Int64Array `gorm:"type:integer[]"`
+	// StartTime - start time of the first occurrence
+	StartTime time.Time `gorm:"not null"`
+	// Until - the series ends on this time (inclusive), ignored if Count is set
+	Until *time.Time
+	// Count - number of occurrences in the series
+	Count int
+
+	Events []Event `gorm:"foreignKey:SeriesID"`
+}
+
+// Interval returns the number of weeks between the repetitions
+func (s *EventSeries) Interval() int {
+	if s.Frequency == RecurrenceBiweekly {
+		return 2
+	}
+	return 1
+}
+
+// IsBounded checks if the end of the series is set
+func (s *EventSeries) IsBounded() bool {
+	return s.Count > 0 || s.Until != nil
+}
+
+// HasWeekday checks if the series repeats on the given weekday
+func (s *EventSeries) HasWeekday(day time.Weekday) bool {
+	return slices.Contains(s.Days(), day)
+}
+
+// ToggleWeekday adds the weekday to the series or removes it if it is already there
+func (s *EventSeries) ToggleWeekday(day time.Weekday) {
+	i := slices.Index(s.Weekdays, int64(day))
+	if i == -1 {
+		s.Weekdays = append(s.Weekdays, int64(day))
+		return
+	}
+	s.Weekdays = slices.Delete(s.Weekdays, i, i+1)
+}
+
+// Reschedule moves the series so that it starts at startTime, the weekdays and the end of the series
+// are moved by the same number of days as the start
+func (s *EventSeries) Reschedule(startTime time.Time) {
+	loc := location.Location()
+	days := int(startTime.In(loc).Weekday()) - int(s.StartTime.In(loc).Weekday())
+	for i, day := range s.Weekdays {
+		s.Weekdays[i] = (day + int64(days) + 7) % 7
+	}
+	if s.Until != nil {
+		until := s.Until.Add(startTime.Sub(s.StartTime))
+		s.Until = &until
+	}
+	s.StartTime = startTime.UTC()
+}
+
+// Days returns the weekdays of the series in the order of the week starting from Monday
+func (s *EventSeries) Days() []time.Weekday {
+	if len(s.Weekdays) == 0 {
+		return []time.Weekday{s.StartTime.In(location.Location()).Weekday()}
+	}
+
+	days := make([]time.Weekday, 0, len(s.Weekdays))
+	for _, day := range s.Weekdays {
+		if !slices.Contains(days, time.Weekday(day)) {
+			days = append(days, time.Weekday(day))
+		}
+	}
+	slices.SortFunc(days, func(a, b time.Weekday) int {
+		return mondayIndex(a) - mondayIndex(b)
+	})
+	return days
+}
+
+// Occurrences returns start times of all events of the series
+//
+// The first occurrence is not earlier than StartTime, every occurrence has the time of day of StartTime.
+// The number of occurrences is limited by Count, Until and MaxSeriesOccurrences
+func (s *EventSeries) Occurrences() []time.Time {
+	loc := location.Location()
+	start := s.StartTime.In(loc)
+
+	limit := MaxSeriesOccurrences
+	if s.Count > 0 && s.Count < limit {
+		limit = s.Count
+	}
+
+	days := s.Days()
+	weekStart := start.AddDate(0, 0, -mondayIndex(start.Weekday()))
+
+	var occurrences []time.Time
+	for week := 0; ; week += s.Interval() {
+		for _, day := range days {
+			date := weekStart.AddDate(0, 0, week*7+mondayIndex(day))
+			occurrence := time.Date(date.Year(), date.Month(), date.Day(), start.Hour(), start.Minute(), 0, 0, loc)
+			if occurrence.Before(start) {
+				continue
+			}
+			if s.Count == 0 && s.Until != nil && occurrence.After(*s.Until) {
+				return occurrences
+			}
+
+			occurrences = append(occurrences, occurrence)
+			if len(occurrences) == limit {
+				return occurrences
+			}
+		}
+	}
+}
+
+// RRule returns the recurrence rule of the series in the iCalendar format (RFC 5545)
+//
+// The rule is built for DTSTART in UTC, so weekdays are shifted
+// if the start time in UTC falls on another day than in the local timezone
+func (s *EventSeries) RRule() string {
+	start := s.StartTime.In(location.Location())
+	shift := (int(s.StartTime.UTC().Weekday()) - int(start.Weekday()) + 7) % 7
+
+	byDay := make([]string, 0, len(s.Weekdays))
+	for _, day := range s.Days() {
+		byDay = append(byDay, rruleWeekdays[(int(day)+shift)%7])
+	}
+
+	rule := fmt.Sprintf(
+		"FREQ=WEEKLY;INTERVAL=%d;WKST=%s;BYDAY=%s",
+		s.Interval(),
+		rruleWeekdays[(int(time.Monday)+shift)%7],
+		strings.Join(byDay, ","),
+	)
+
+	switch {
+	case s.Count > 0:
+		rule += fmt.Sprintf(";COUNT=%d", s.Count)
+	case s.Until != nil:
+		rule += ";UNTIL=" + s.Until.UTC().Format("20060102T150405Z")
+	}
+
+	return rule
+}
+
+// mondayIndex returns the index of the weekday in the week starting from Monday
+func mondayIndex(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
@@ -7,6 +7,10 @@ type NotificationType string
 const (
 	NotificationTypeDay  NotificationType = "day"
 	NotificationTypeHour NotificationType = "hour"
+	// NotificationTypeFeedback - request to rate the event after it has ended
+	NotificationTypeFeedback NotificationType = "feedback"
+	// NotificationTypeAttendanceReport - attendance summary sent to a club owner after the event has ended
+	NotificationTypeAttendanceReport NotificationType = "attendance_report"
 )
 
 // EventNotification represents a notification that has been sent to a user
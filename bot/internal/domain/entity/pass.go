@@ -36,6 +36,7 @@ type Pass struct {
 
 	EventID string     `gorm:"type:uuid;not null;index"`
 	UserID  int64      `gorm:"not null;index"`
+	GuestID *string    `gorm:"type:uuid;index"` // Гость участника UserID, если пропуск выписан на гостя
 	Type    PassType   `gorm:"not null;default:'event'"`
 	Status  PassStatus `gorm:"not null;default:'pending'"`
 
@@ -106,6 +107,10 @@ func (p *Pass) GetRequesterClubID() (string, error) {
 	return p.RequesterID, nil
 }
 
+func (p *Pass) IsGuestPass() bool {
+	return p.GuestID != nil
+}
+
 func (p *Pass) IsRequestedByUser() bool {
 	return p.RequesterType == PassRequesterTypeUser
 }
@@ -0,0 +1,124 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ReminderOffset - how many minutes before the start of the event the reminder is sent
+type ReminderOffset int64
+
+const (
+	minutesInHour = 60
+	minutesInDay  = 24 * minutesInHour
+)
+
+// ReminderOffsetOptions are the offsets club owners and users choose from, the earliest first
+var ReminderOffsetOptions = ReminderOffsets{
+	7 * minutesInDay,
+	3 * minutesInDay,
+	minutesInDay,
+	3 * minutesInHour,
+	minutesInHour,
+	15,
+}
+
+// DefaultReminderOffsets are used for events whose club owners haven't chosen the reminders
+var DefaultReminderOffsets = ReminderOffsets{minutesInDay, minutesInHour}
+
+func (o ReminderOffset) Duration() time.Duration {
+	return time.Duration(o) * time.Minute
+}
+
+// Days, Hours and Minutes split the offset for the texts, only the largest whole unit is non-zero
+func (o ReminderOffset) Days() int {
+	if o%minutesInDay == 0 {
+		return int(o / minutesInDay)
+	}
+	return 0
+}
+
+func (o ReminderOffset) Hours() int {
+	if o%minutesInDay != 0 && o%minutesInHour == 0 {
+		return int(o / minutesInHour)
+	}
+	return 0
+}
+
+func (o ReminderOffset) Minutes() int {
+	if o%minutesInHour != 0 {
+		return int(o)
+	}
+	return 0
+}
+
+// IsDue checks if the reminder has to be sent to the event starting in timeUntilStart.
+// The reminder is sent during a window after the offset, so a missed scheduler tick doesn't lose it
+func (o ReminderOffset) IsDue(timeUntilStart time.Duration) bool {
+	window := max(o.Duration()/24, 5*time.Minute)
+	return timeUntilStart <= o.Duration() && timeUntilStart >= o.Duration()-window
+}
+
+// NotificationType returns the type of the sent reminder records. Day and hour reminders keep the types
+// they had before the offsets became configurable, so they are not sent twice
+func (o ReminderOffset) NotificationType() NotificationType {
+	switch o {
+	case minutesInDay:
+		return NotificationTypeDay
+	case minutesInHour:
+		return NotificationTypeHour
+	default:
+		return NotificationType(fmt.Sprintf("reminder_%dm", o))
+	}
+}
+
+// ReminderOffsets is stored as an integer array
+type ReminderOffsets []ReminderOffset
+
+func (o ReminderOffsets) Value() (driver.Value, error) {
+	if o == nil {
+		return nil, nil
+	}
+	array := make(pq.Int64Array, len(o))
+	for i, offset := range o {
+		array[i] = int64(offset)
+	}
+	return array.Value()
+}
+
+func (o *ReminderOffsets) Scan(value interface{}) error {
+	var array pq.Int64Array
+	if err := array.Scan(value); err != nil {
+		return err
+	}
+	if array == nil {
+		*o = nil
+		return nil
+	}
+
+	offsets := make(ReminderOffsets, len(array))
+	for i, offset := range array {
+		offsets[i] = ReminderOffset(offset)
+	}
+	*o = offsets
+	return nil
+}
+
+// Toggle adds the offset if it's missing and removes it otherwise, the result is ordered from the earliest reminder
+func (o ReminderOffsets) Toggle(offset ReminderOffset) ReminderOffsets {
+	if slices.Contains(o, offset) {
+		return slices.DeleteFunc(slices.Clone(o), func(other ReminderOffset) bool {
+			return other == offset
+		})
+	}
+
+	offsets := append(slices.Clone(o), offset)
+	slices.SortFunc(offsets, func(a, b ReminderOffset) int {
+		return int(b - a)
+	})
+	return offsets
+}
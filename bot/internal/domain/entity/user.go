@@ -25,6 +25,14 @@ type User struct {
 	IsBanned      bool            `gorm:"default:false"`
 	Clubs         []Club          `gorm:"many2many:club_owners;foreignKey:ID;joinForeignKey:UserID;References:ID;JoinReferences:ClubID"`
 	IgnoreMailing []IgnoreMailing `gorm:"foreignKey:UserID;references:ID"`
+	// CalendarToken - secret part of the personal calendar feed URL, empty until the user requests the feed
+	CalendarToken string `gorm:"uniqueIndex:idx_users_calendar_token,where:calendar_token <> ''"`
+	// NoShowsResetAt - no-shows of events that ended before it are not counted, set when an admin resets them
+	NoShowsResetAt *time.Time
+	// RemindersMuted - the user doesn't get reminders about events
+	RemindersMuted bool `gorm:"not null;default:false"`
+	// ReminderOffsets - reminders chosen by the user instead of the reminders of events, empty if not chosen
+	ReminderOffsets ReminderOffsets `gorm:"type:integer[]"`
 }
 
 type ClubOwner struct {
@@ -34,6 +42,13 @@ type ClubOwner struct {
 	CreatedAt time.Time
 }
 
+type ParticipantStatus string
+
+const (
+	ParticipantStatusPending  ParticipantStatus = "pending"
+	ParticipantStatusApproved ParticipantStatus = "approved"
+)
+
 type EventParticipant struct {
 	EventID   string `gorm:"primaryKey;type:uuid"`
 	UserID    int64  `gorm:"primaryKey"`
@@ -41,6 +56,62 @@ type EventParticipant struct {
 	UpdatedAt time.Time
 	IsUserQr  bool
 	IsEventQr bool
+	// CheckedInAt - the first entry scan, CheckedOutAt - the last exit scan
+	CheckedInAt  *time.Time
+	CheckedOutAt *time.Time
+	// Status - pending registrations wait for the club owner's approval on events with ApprovalRequired
+	Status ParticipantStatus `gorm:"not null;default:'approved'"`
+}
+
+// IsPending reports whether the registration is waiting for approval
+func (p *EventParticipant) IsPending() bool {
+	return p.Status == ParticipantStatusPending
+}
+
+// CheckIn records the entry of the participant, repeated scans keep the first entry time
+func (p *EventParticipant) CheckIn(now time.Time) {
+	if p.CheckedInAt == nil {
+		p.CheckedInAt = &now
+	}
+}
+
+// CheckOut records the exit of the participant, returns false if the participant has not checked in
+func (p *EventParticipant) CheckOut(now time.Time) bool {
+	if p.CheckedInAt == nil {
+		return false
+	}
+	p.CheckedOutAt = &now
+	return true
+}
+
+// AttendanceDuration returns the time between the entry and the last exit, 0 if the participant has not checked out
+func (p *EventParticipant) AttendanceDuration() time.Duration {
+	if p.CheckedInAt == nil || p.CheckedOutAt == nil {
+		return 0
+	}
+	return p.CheckedOutAt.Sub(*p.CheckedInAt)
+}
+
+// EventWaitlist is a queue entry for a user waiting for a free seat on a full event.
+// OfferExpiresAt is set when a seat has been offered to the user and is pending confirmation.
+type EventWaitlist struct {
+	EventID        string `gorm:"primaryKey;type:uuid"`
+	UserID         int64  `gorm:"primaryKey"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	OfferExpiresAt *time.Time
+
+	User User `gorm:"foreignKey:UserID"`
+}
+
+// IsOffered reports whether a seat is currently offered to the user
+func (w *EventWaitlist) IsOffered() bool {
+	return w.OfferExpiresAt != nil
+}
+
+// IsOfferExpired reports whether the seat offer has expired
+func (w *EventWaitlist) IsOfferExpired() bool {
+	return w.OfferExpiresAt != nil && w.OfferExpiresAt.Before(time.Now())
 }
 
 type IgnoreMailing struct {
@@ -59,6 +130,18 @@ func (u *User) GetEmail() valueobject.Email {
 	return u.Email
 }
 
+// Reminders returns the offsets of the reminders the user gets about the event
+func (u *User) Reminders(event *Event) ReminderOffsets {
+	switch {
+	case u.RemindersMuted:
+		return nil
+	case len(u.ReminderOffsets) > 0:
+		return u.ReminderOffsets
+	default:
+		return event.Reminders()
+	}
+}
+
 func (u *User) IsMailingAllowed(clubID string) bool {
 	for _, ignoreMailing := range u.IgnoreMailing {
 		if ignoreMailing.ClubID == clubID {
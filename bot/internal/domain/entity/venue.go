@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Venue is a room from the catalogue managed by admins, events booked in the same venue can't overlap
+type Venue struct {
+	ID        string `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt
+	// Name - unique among not deleted venues, so the name of a deleted venue can be used again
+	Name     string `gorm:"not null;uniqueIndex:idx_venues_name,where:deleted_at IS NULL"`
+	Building string
+	// Capacity - the maximum number of participants of events in the venue, 0 if it is not limited
+	Capacity int `gorm:"not null;default:0"`
+	// OnCampus - passes are issued for events in the venue
+	OnCampus bool `gorm:"not null;default:true"`
+}
+
+// Location returns the text that is shown to users as the location of events in the venue
+func (v *Venue) Location() string {
+	if v.Building == "" {
+		return v.Name
+	}
+	return v.Name + ", " + v.Building
+}
+
+// Fits checks if the event with the given max participants (0 - unlimited) fits into the venue
+func (v *Venue) Fits(maxParticipants int) bool {
+	if v.Capacity == 0 {
+		return true
+	}
+	return maxParticipants > 0 && maxParticipants <= v.Capacity
+}
@@ -0,0 +1,304 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"time"
+
+	tele "gopkg.in/telebot.v3"
+	"gopkg.in/telebot.v3/layout"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/dto"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/export"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/location"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/ports/primary"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/ports/secondary"
+	"github.com/Badsnus/cu-clubs-bot/bot/pkg/logger/types"
+)
+
+const (
+	// attendanceReportWindow limits how long after the end of the event the report is still sent,
+	// so owners don't get reports about long-past events after a restart
+	attendanceReportWindow = 24 * time.Hour
+	// attendanceReportMaxNoShows limits the no-show list in the message, the full list is in the spreadsheet
+	attendanceReportMaxNoShows = 50
+)
+
+type AttendanceReportService struct {
+	eventRepo               secondary.EventRepository
+	notificationRepo        secondary.NotificationRepository
+	coHostRepo              secondary.EventCoHostRepository
+	clubOwnerService        primary.ClubOwnerService
+	userService             primary.UserService
+	eventParticipantService primary.EventParticipantService
+
+	bot    *tele.Bot
+	layout *layout.Layout
+	logger *types.Logger
+
+	delay time.Duration
+}
+
+func NewAttendanceReportService(
+	bot *tele.Bot,
+	layout *layout.Layout,
+	logger *types.Logger,
+	eventStorage secondary.EventRepository,
+	notificationStorage secondary.NotificationRepository,
+	coHostStorage secondary.EventCoHostRepository,
+	clubOwnerSvc primary.ClubOwnerService,
+	userSvc primary.UserService,
+	eventParticipantSvc primary.EventParticipantService,
+	delay time.Duration,
+) *AttendanceReportService {
+	return &AttendanceReportService{
+		eventRepo:               eventStorage,
+		notificationRepo:        notificationStorage,
+		coHostRepo:              coHostStorage,
+		clubOwnerService:        clubOwnerSvc,
+		userService:             userSvc,
+		eventParticipantService: eventParticipantSvc,
+		bot:                     bot,
+		layout:                  layout,
+		logger:                  logger,
+		delay:                   delay,
+	}
+}
+
+// attendanceReport is the summary of the event that is sent to every club owner
+type attendanceReport struct {
+	Name        string
+	StartTime   string
+	Registered  int
+	Guests      int
+	Visited     int
+	UserQR      int
+	EventQR     int
+	NoShows     []string
+	MoreNoShows int
+
+	file []byte
+}
+
+// StartReportScheduler starts the scheduler that sends attendance reports of ended events to club owners
+func (s *AttendanceReportService) StartReportScheduler() {
+	s.logger.Debug("Starting attendance report scheduler")
+	go func() {
+		ticker := time.NewTicker(1 * time.Minute)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			s.sendReports(context.Background())
+		}
+	}()
+	s.logger.Info("Attendance report scheduler started")
+}
+
+// sendReports sends reports of events that ended at least delay ago to owners of the host clubs with enabled warnings
+//
+// NOTE: localisation is hardcoded for now (ru)
+func (s *AttendanceReportService) sendReports(ctx context.Context) {
+	to := time.Now().Add(-s.delay)
+	events, err := s.eventRepo.GetEndedEvents(ctx, to.Add(-attendanceReportWindow), to)
+	if err != nil {
+		s.logger.Errorf("failed to get ended events: %v", err)
+		return
+	}
+
+	for _, event := range events {
+		if event.IsDraft() {
+			continue
+		}
+
+		owners, err := s.hostOwners(ctx, event)
+		if err != nil {
+			s.logger.Errorf("failed to get owners of host clubs of event %s: %v", event.ID, err)
+			continue
+		}
+		notified, err := s.notificationRepo.GetNotifiedUserIDs(ctx, event.ID, entity.NotificationTypeAttendanceReport)
+		if err != nil {
+			s.logger.Errorf("failed to get owners with attendance report for event %s: %v", event.ID, err)
+			continue
+		}
+
+		// Отчёт собирается только если его ещё есть кому отправить
+		var report *attendanceReport
+		for _, owner := range owners {
+			if !owner.Warnings || owner.IsBanned || slices.Contains(notified, owner.UserID) {
+				continue
+			}
+
+			if report == nil {
+				report, err = s.buildReport(ctx, event)
+				if err != nil {
+					s.logger.Errorf("failed to build attendance report for event %s: %v", event.ID, err)
+					break
+				}
+			}
+
+			if err = s.sendReport(owner.UserID, report); err != nil {
+				s.logger.Errorf("failed to send attendance report to user %d: %v", owner.UserID, err)
+				continue
+			}
+
+			notification := &entity.EventNotification{
+				EventID: event.ID,
+				UserID:  owner.UserID,
+				Type:    entity.NotificationTypeAttendanceReport,
+			}
+			if err = s.notificationRepo.Create(ctx, notification); err != nil {
+				s.logger.Errorf("failed to create notification record: %v", err)
+			}
+		}
+	}
+}
+
+// hostOwners returns the owners of the primary club and the accepted co-host clubs of the event,
+// owners of several host clubs are returned once
+func (s *AttendanceReportService) hostOwners(ctx context.Context, event entity.Event) ([]dto.ClubOwner, error) {
+	clubIDs := []string{event.ClubID}
+	coHosts, err := s.coHostRepo.GetByEventID(ctx, event.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, coHost := range coHosts {
+		if coHost.Accepted {
+			clubIDs = append(clubIDs, coHost.ClubID)
+		}
+	}
+
+	var owners []dto.ClubOwner
+	for _, clubID := range clubIDs {
+		clubOwners, err := s.clubOwnerService.GetByClubID(ctx, clubID)
+		if err != nil {
+			return nil, err
+		}
+		for _, owner := range clubOwners {
+			if !slices.ContainsFunc(owners, func(other dto.ClubOwner) bool { return other.UserID == owner.UserID }) {
+				owners = append(owners, owner)
+			}
+		}
+	}
+	return owners, nil
+}
+
+func (s *AttendanceReportService) buildReport(ctx context.Context, event entity.Event) (*attendanceReport, error) {
+	participants, err := s.eventParticipantService.GetByEventID(ctx, event.ID)
+	if err != nil {
+		return nil, err
+	}
+	// Неподтверждённые заявки и их гости не считаются зарегистрированными
+	approved := make(map[int64]bool, len(participants))
+	for _, participant := range participants {
+		if !participant.IsPending() {
+			approved[participant.UserID] = true
+		}
+	}
+	guests, err := s.eventParticipantService.GetGuestsByEventID(ctx, event.ID)
+	if err != nil {
+		return nil, err
+	}
+	guestsCount := 0
+	for _, guest := range guests {
+		if approved[guest.UserID] {
+			guestsCount++
+		}
+	}
+	visited, err := s.eventParticipantService.CountVisitedByEventID(ctx, event.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &attendanceReport{
+		Name:       event.Name,
+		StartTime:  event.StartTime.In(location.Location()).Format("02.01.2006 15:04"),
+		Registered: len(approved),
+		Guests:     guestsCount,
+		Visited:    visited,
+	}
+
+	visitedParticipants, err := s.eventParticipantService.GetVisitedParticipants(ctx, event.ID)
+	if err != nil {
+		return nil, err
+	}
+	// Если участник отсканирован обоими способами, засчитываем его QR-код
+	for _, participant := range visitedParticipants {
+		if participant.IsUserQr {
+			report.UserQR++
+		} else {
+			report.EventQR++
+		}
+	}
+
+	notVisited, err := s.eventParticipantService.GetNotVisitedParticipants(ctx, event.ID)
+	if err != nil {
+		return nil, err
+	}
+	// Неподтверждённые заявки не могли прийти на мероприятие, поэтому в неявки не попадают
+	noShowIDs := make(map[int64]bool, len(notVisited))
+	for _, participant := range notVisited {
+		if !participant.IsPending() {
+			noShowIDs[participant.UserID] = true
+		}
+	}
+
+	users, err := s.userService.GetEventUsers(ctx, event.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		if !noShowIDs[user.User.ID] {
+			continue
+		}
+		if len(report.NoShows) == attendanceReportMaxNoShows {
+			report.MoreNoShows++
+			continue
+		}
+		name := user.User.FIO.String()
+		if user.User.Username != "" {
+			name += " (@" + user.User.Username + ")"
+		}
+		report.NoShows = append(report.NoShows, name)
+	}
+
+	waitlist, err := s.eventParticipantService.GetWaitlist(ctx, event.ID)
+	if err != nil {
+		return nil, err
+	}
+	questions, err := s.eventParticipantService.GetQuestions(ctx, event.ID)
+	if err != nil {
+		return nil, err
+	}
+	answers, err := s.eventParticipantService.GetAnswersByEventID(ctx, event.ID)
+	if err != nil {
+		return nil, err
+	}
+	buffer, err := export.UsersToXLSX(users, waitlist, guests, questions, answers)
+	if err != nil {
+		return nil, err
+	}
+	report.file = buffer.Bytes()
+
+	return report, nil
+}
+
+func (s *AttendanceReportService) sendReport(userID int64, report *attendanceReport) error {
+	s.logger.Infof("Sending attendance report to user (user_id=%d, event=%s)", userID, report.Name)
+	chat, err := s.bot.ChatByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if _, err = s.bot.Send(chat, s.layout.TextLocale("ru", "attendance_report_text", report)); err != nil {
+		return err
+	}
+
+	_, err = s.bot.Send(chat, &tele.Document{
+		File:     tele.FromReader(bytes.NewReader(report.file)),
+		Caption:  s.layout.TextLocale("ru", "attendance_report_file", report),
+		FileName: "users.xlsx",
+	})
+	return err
+}
@@ -2,28 +2,158 @@ package service
 
 import (
 	"context"
+	"maps"
+	"slices"
 	"time"
 
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/common/errorz"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/dto"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/deadline"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/valueobject"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/ports/secondary"
 
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
 )
 
 type EventService struct {
-	repo secondary.EventRepository
+	repo             secondary.EventRepository
+	seriesRepo       secondary.EventSeriesRepository
+	passRepo         secondary.PassRepository
+	notificationRepo secondary.NotificationRepository
+	venueRepo        secondary.VenueRepository
+
+	deadlines deadline.Policy
 }
 
-func NewEventService(storage secondary.EventRepository) *EventService {
+func NewEventService(
+	storage secondary.EventRepository,
+	seriesStorage secondary.EventSeriesRepository,
+	passStorage secondary.PassRepository,
+	notificationStorage secondary.NotificationRepository,
+	venueStorage secondary.VenueRepository,
+	deadlines deadline.Policy,
+) *EventService {
 	return &EventService{
-		repo: storage,
+		repo:             storage,
+		seriesRepo:       seriesStorage,
+		passRepo:         passStorage,
+		notificationRepo: notificationStorage,
+		venueRepo:        venueStorage,
+		deadlines:        deadlines,
 	}
 }
 
+// RegistrationDeadline returns the registration end of the event for the role, see entity.Event.RegistrationDeadline
+func (s *EventService) RegistrationDeadline(event *entity.Event, role valueobject.Role) time.Time {
+	return event.RegistrationDeadline(role, s.deadlines)
+}
+
+// IsRegistrationOpen checks if users with the role can still register for the event
+func (s *EventService) IsRegistrationOpen(event *entity.Event, role valueobject.Role, now time.Time) bool {
+	return event.IsRegistrationOpen(role, now, s.deadlines)
+}
+
+// Create saves the event, the event can't overlap other events in the same venue or exceed its capacity
 func (s *EventService) Create(ctx context.Context, event *entity.Event) (*entity.Event, error) {
+	if _, err := s.checkVenue(ctx, event, nil); err != nil {
+		return nil, err
+	}
 	return s.repo.Create(ctx, event)
 }
 
+// CreateSeries creates a recurring series and generates its events from the event template
+//
+// The template is used as the first occurrence: every generated event keeps its time of day,
+// duration and the gap between the registration end and the start
+func (s *EventService) CreateSeries(ctx context.Context, event entity.Event, series *entity.EventSeries) (*entity.EventSeries, error) {
+	series.ClubID = event.ClubID
+	series.StartTime = event.StartTime
+
+	occurrences := series.Occurrences()
+	if len(occurrences) == 0 {
+		return nil, errorz.ErrEmptySeries
+	}
+	// Серия должна начинаться с первого события, иначе DTSTART в календаре даст лишнее событие
+	series.StartTime = occurrences[0].UTC()
+	// Серия обрезана по лимиту, поэтому фиксируем количество событий
+	if len(occurrences) == entity.MaxSeriesOccurrences {
+		series.Count = entity.MaxSeriesOccurrences
+	}
+
+	series.Events = make([]entity.Event, 0, len(occurrences))
+	for _, startTime := range occurrences {
+		occurrence := event
+		occurrence.StartTime = startTime.UTC()
+
+		offset := occurrence.StartTime.Sub(event.StartTime)
+		if !event.EndTime.IsZero() {
+			occurrence.EndTime = event.EndTime.Add(offset)
+		}
+		occurrence.RegistrationEnd = event.RegistrationEnd.Add(offset)
+		// Every occurrence gets its own copy of the registration form
+		occurrence.Questions = slices.Clone(event.Questions)
+
+		if _, err := s.checkVenue(ctx, &occurrence, nil); err != nil {
+			return nil, err
+		}
+
+		series.Events = append(series.Events, occurrence)
+	}
+
+	return s.seriesRepo.Create(ctx, series)
+}
+
+func (s *EventService) GetSeries(ctx context.Context, id string) (*entity.EventSeries, error) {
+	return s.seriesRepo.Get(ctx, id)
+}
+
+// RescheduleSeries moves the occurrences of the series starting not earlier than from, so the first of them starts at startTime
+//
+// If earlier occurrences are left in place, the series is split: it ends before from,
+// and the moved events (eventIDs) get a new series with the rest of the occurrences.
+// Returns the series the moved events belong to
+func (s *EventService) RescheduleSeries(
+	ctx context.Context,
+	seriesID string,
+	from, startTime time.Time,
+	eventIDs []string,
+) (*entity.EventSeries, error) {
+	series, err := s.seriesRepo.Get(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	occurrences := series.Occurrences()
+	before := 0
+	for _, occurrence := range occurrences {
+		if occurrence.Before(from) {
+			before++
+		}
+	}
+	if before == 0 {
+		series.Reschedule(startTime)
+		return s.seriesRepo.Update(ctx, series)
+	}
+
+	next := &entity.EventSeries{
+		ClubID:    series.ClubID,
+		Frequency: series.Frequency,
+		Weekdays:  slices.Clone(series.Weekdays),
+		StartTime: from,
+		Count:     len(occurrences) - before,
+	}
+	next.Reschedule(startTime)
+
+	// Серия заканчивается перед перенесенными событиями
+	series.Count = before
+	series.Until = nil
+	return s.seriesRepo.Split(ctx, series, next, eventIDs)
+}
+
+func (s *EventService) GetFutureBySeriesID(ctx context.Context, seriesID string, from time.Time) ([]entity.Event, error) {
+	return s.repo.GetFutureBySeriesID(ctx, seriesID, from)
+}
+
 func (s *EventService) Get(ctx context.Context, id string) (*entity.Event, error) {
 	return s.repo.Get(ctx, id)
 }
@@ -32,6 +162,10 @@ func (s *EventService) GetByQRCodeID(ctx context.Context, qrCodeID string) (*ent
 	return s.repo.GetByQRCodeID(ctx, qrCodeID)
 }
 
+func (s *EventService) GetByExitQRCodeID(ctx context.Context, qrCodeID string) (*entity.Event, error) {
+	return s.repo.GetByExitQRCodeID(ctx, qrCodeID)
+}
+
 func (s *EventService) GetMany(ctx context.Context, ids []string) ([]entity.Event, error) {
 	return s.repo.GetMany(ctx, ids)
 }
@@ -63,18 +197,122 @@ func (s *EventService) GetFutureByClubID(
 //	return s.repo.CountFutureByClubID(ctx, clubID)
 //}
 
+// Update saves the event.
+//
+// The venue rules are checked only for the changed fields, see checkVenue.
+//
+// If the start time, the registration end or the role deadlines have changed, pending passes are rescheduled
+// according to the new deadlines. If the start time has changed, records of all sent reminders
+// (day, hour and the configurable ones) are deleted, so participants get the reminders again for the new time.
+// Feedback requests and attendance reports are kept
 func (s *EventService) Update(ctx context.Context, event *entity.Event) (*entity.Event, error) {
-	return s.repo.Update(ctx, event)
+	previous, err := s.repo.Get(ctx, event.ID)
+	if err != nil {
+		return nil, err
+	}
+	bookVenue, err := s.checkVenue(ctx, event, previous)
+	if err != nil {
+		return nil, err
+	}
+
+	if bookVenue {
+		event, err = s.repo.UpdateAndBookVenue(ctx, event)
+	} else {
+		event, err = s.repo.Update(ctx, event)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	startChanged := !previous.StartTime.Equal(event.StartTime)
+	deadlinesChanged := startChanged ||
+		!previous.RegistrationEnd.Equal(event.RegistrationEnd) ||
+		!maps.EqualFunc(previous.RoleDeadlines, event.RoleDeadlines, time.Time.Equal)
+	if !deadlinesChanged {
+		return event, nil
+	}
+
+	if err = s.reschedulePasses(ctx, event); err != nil {
+		return event, err
+	}
+	if !startChanged {
+		return event, nil
+	}
+	if err = s.notificationRepo.DeleteRemindersByEventID(ctx, event.ID); err != nil {
+		return event, err
+	}
+
+	return event, nil
+}
+
+// reschedulePasses moves pending passes of the event to the deadlines of their holders' roles
+func (s *EventService) reschedulePasses(ctx context.Context, event *entity.Event) error {
+	for _, role := range valueobject.AllRoles() {
+		if err := s.passRepo.ReschedulePendingPasses(ctx, event.ID, role, event.PassScheduledAt(role, s.deadlines)); err != nil {
+			return err
+		}
+	}
+	return s.passRepo.RescheduleGuestPasses(ctx, event.ID, event.GuestPassScheduledAt(s.deadlines))
+}
+
+// Cancel marks the event as cancelled and cancels pending passes of its participants.
+//
+// The event is not deleted, so participants still see it in their events
+func (s *EventService) Cancel(ctx context.Context, id string, reason string) (*entity.Event, error) {
+	event, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if event.IsCancelled() {
+		return event, nil
+	}
+
+	event.Cancel(reason, time.Now())
+	event, err = s.repo.Update(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.passRepo.CancelPendingPassesByEventID(ctx, event.ID); err != nil {
+		return event, err
+	}
+
+	return event, nil
 }
 
 func (s *EventService) Delete(ctx context.Context, id string) error {
 	return s.repo.Delete(ctx, id)
 }
 
-func (s *EventService) Count(ctx context.Context, role entity.Role) (int64, error) {
-	return s.repo.Count(ctx, string(role))
+func (s *EventService) Count(ctx context.Context, role entity.Role, filter dto.EventFilter) (int64, error) {
+	return s.repo.Count(ctx, string(role), filter)
+}
+
+func (s *EventService) CountByFilters(ctx context.Context, role entity.Role, filter dto.EventFilter) (dto.EventFilterCounts, error) {
+	return s.repo.CountByFilters(ctx, string(role), filter)
+}
+
+func (s *EventService) GetWithPagination(
+	ctx context.Context,
+	limit, offset int,
+	order string,
+	role entity.Role,
+	userID int64,
+	filter dto.EventFilter,
+) ([]dto.Event, error) {
+	return s.repo.GetWithPagination(ctx, limit, offset, order, string(role), userID, filter)
 }
 
-func (s *EventService) GetWithPagination(ctx context.Context, limit, offset int, order string, role entity.Role, userID int64) ([]dto.Event, error) {
-	return s.repo.GetWithPagination(ctx, limit, offset, order, string(role), userID)
+// GetPublic returns upcoming events of shown clubs for the public listings (website, signage).
+// Meeting links are removed, they are only for the participants of the event
+func (s *EventService) GetPublic(ctx context.Context, role entity.Role, filter dto.EventFilter) ([]entity.Event, error) {
+	events, err := s.repo.GetPublic(ctx, string(role), filter)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range events {
+		events[i].MeetingURL = ""
+	}
+	return events, nil
 }
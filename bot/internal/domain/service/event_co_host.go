@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/common/errorz"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/ports/secondary"
+)
+
+type EventCoHostService struct {
+	repo      secondary.EventCoHostRepository
+	eventRepo secondary.EventRepository
+}
+
+func NewEventCoHostService(storage secondary.EventCoHostRepository, eventStorage secondary.EventRepository) *EventCoHostService {
+	return &EventCoHostService{
+		repo:      storage,
+		eventRepo: eventStorage,
+	}
+}
+
+// Invite creates a pending co-host invitation of the club to the event
+func (s *EventCoHostService) Invite(ctx context.Context, eventID, clubID string) (*entity.EventCoHost, error) {
+	event, err := s.eventRepo.Get(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if event.IsCancelled() {
+		return nil, errorz.ErrEventCancelled
+	}
+	if event.ClubID == clubID {
+		return nil, errorz.ErrCoHostIsPrimaryClub
+	}
+
+	_, err = s.repo.Get(ctx, eventID, clubID)
+	if err == nil {
+		return nil, errorz.ErrCoHostAlreadyInvited
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	return s.repo.Create(ctx, &entity.EventCoHost{EventID: eventID, ClubID: clubID})
+}
+
+// Accept confirms the co-host invitation, after that the event is managed by the co-host club too
+func (s *EventCoHostService) Accept(ctx context.Context, eventID, clubID string) (*entity.EventCoHost, error) {
+	coHost, err := s.repo.Get(ctx, eventID, clubID)
+	if err != nil {
+		return nil, err
+	}
+
+	coHost.Accepted = true
+	return s.repo.Update(ctx, coHost)
+}
+
+// Remove declines the invitation or removes the club from the event co-hosts
+func (s *EventCoHostService) Remove(ctx context.Context, eventID, clubID string) error {
+	return s.repo.Delete(ctx, eventID, clubID)
+}
+
+func (s *EventCoHostService) Get(ctx context.Context, eventID, clubID string) (*entity.EventCoHost, error) {
+	return s.repo.Get(ctx, eventID, clubID)
+}
+
+func (s *EventCoHostService) GetByEventID(ctx context.Context, eventID string) ([]entity.EventCoHost, error) {
+	return s.repo.GetByEventID(ctx, eventID)
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/dto"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/ports/secondary"
+)
+
+// conflictsPageSize - how many registrations are loaded at once while looking for conflicts
+const conflictsPageSize = 50
+
+// upcomingUserEvents returns not cancelled events the user is registered on that have not ended by now
+func upcomingUserEvents(ctx context.Context, repo secondary.EventParticipantRepository, userID int64, now time.Time) ([]dto.UserEvent, error) {
+	var upcoming []dto.UserEvent
+	for offset := 0; ; offset += conflictsPageSize {
+		events, err := repo.GetUserEvents(ctx, userID, conflictsPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, event := range events {
+			// Started events go from the latest start, an earlier but longer event may still be running
+			if !event.End().After(now) {
+				continue
+			}
+			if !event.IsCancelled {
+				upcoming = append(upcoming, event)
+			}
+		}
+
+		if len(events) < conflictsPageSize {
+			return upcoming, nil
+		}
+	}
+}
+
+// overlapping returns the events that run at the same time as the event, the event itself is skipped
+func overlapping(event dto.UserEvent, events []dto.UserEvent) []dto.UserEvent {
+	var conflicts []dto.UserEvent
+	for _, other := range events {
+		if other.ID != event.ID && event.Overlaps(other) {
+			conflicts = append(conflicts, other)
+		}
+	}
+	return conflicts
+}
+
+// markConflicts sets HasConflict of the upcoming events that overlap other registrations of the user
+func markConflicts(ctx context.Context, repo secondary.EventParticipantRepository, userID int64, events []dto.UserEvent) error {
+	now := time.Now()
+	upcoming, err := upcomingUserEvents(ctx, repo, userID, now)
+	if err != nil {
+		return err
+	}
+
+	for i, event := range events {
+		if event.IsCancelled || !event.End().After(now) {
+			continue
+		}
+		events[i].HasConflict = len(overlapping(event, upcoming)) > 0
+	}
+	return nil
+}
@@ -3,13 +3,20 @@ package service
 import (
 	"context"
 	"errors"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/common/errorz"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/ports/primary"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/ports/secondary"
 
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/dto"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/deadline"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/noshow"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/valueobject"
 	"github.com/Badsnus/cu-clubs-bot/bot/pkg/logger/types"
 )
 
@@ -20,14 +27,26 @@ EventParticipantService - сервис для управления участн
 - Автоматическое создание пропусков при регистрации на события, требующие пропуск
 - Управление статусом посещения через QR-коды
 - Статистика участников и их активности
+- Лист ожидания с автоматическим предложением освободившихся мест
+- Ответы участников на вопросы регистрационной формы события
+- Гости участников без аккаунта в Telegram, которые занимают места и получают свои пропуска
+- Ручное подтверждение заявок владельцем клуба на мероприятиях с ApprovalRequired
 */
 type EventParticipantService struct {
-	logger        *types.Logger
-	storage       secondary.EventParticipantRepository
-	eventStorage  secondary.EventRepository
-	passStorage   secondary.PassRepository
-	userStorage   secondary.UserRepository
-	excludedRoles []string
+	logger          *types.Logger
+	storage         secondary.EventParticipantRepository
+	eventStorage    secondary.EventRepository
+	passStorage     secondary.PassRepository
+	userStorage     secondary.UserRepository
+	waitlistStorage secondary.EventWaitlistRepository
+	questionStorage secondary.EventQuestionRepository
+	guestStorage    secondary.EventGuestRepository
+	notifyService   primary.NotifyService
+
+	excludedRoles    []string
+	waitlistOfferTTL time.Duration
+	deadlines        deadline.Policy
+	noShows          noshow.Policy
 }
 
 func NewEventParticipantService(
@@ -36,30 +55,113 @@ func NewEventParticipantService(
 	eventRepo secondary.EventRepository,
 	passRepo secondary.PassRepository,
 	userRepo secondary.UserRepository,
+	waitlistRepo secondary.EventWaitlistRepository,
+	questionRepo secondary.EventQuestionRepository,
+	guestRepo secondary.EventGuestRepository,
+	notifyService primary.NotifyService,
 	excludedRoles []string,
+	waitlistOfferTTL time.Duration,
+	deadlines deadline.Policy,
+	noShows noshow.Policy,
 ) *EventParticipantService {
 	return &EventParticipantService{
-		logger:        logger,
-		storage:       repo,
-		eventStorage:  eventRepo,
-		passStorage:   passRepo,
-		userStorage:   userRepo,
-		excludedRoles: excludedRoles,
+		logger:           logger,
+		storage:          repo,
+		eventStorage:     eventRepo,
+		passStorage:      passRepo,
+		userStorage:      userRepo,
+		waitlistStorage:  waitlistRepo,
+		questionStorage:  questionRepo,
+		guestStorage:     guestRepo,
+		notifyService:    notifyService,
+		excludedRoles:    excludedRoles,
+		waitlistOfferTTL: waitlistOfferTTL,
+		deadlines:        deadlines,
+		noShows:          noShows,
 	}
 }
 
-func (s *EventParticipantService) Register(ctx context.Context, eventID string, userID int64) (*entity.EventParticipant, error) {
+// Register registers the user for the event and saves the answers to its registration form.
+//
+// If the event requires approval, the registration stays pending until a club owner approves it.
+//
+// Returns errorz.ErrEventFull, errorz.ErrRegistrationClosed, errorz.ErrRoleNotAllowed, errorz.ErrEventCancelled
+// or errorz.ErrEventDraft if the user can't be registered, errorz.ErrRegistrationBlocked if the user is blocked for no-shows
+// and errorz.ErrInvalidAnswer if the answers don't fit the form.
+func (s *EventParticipantService) Register(ctx context.Context, eventID string, userID int64, answers []entity.EventAnswer) (*entity.EventParticipant, error) {
+	answers, err := s.checkAnswers(ctx, eventID, userID, answers)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkNoShows(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	participant, err := s.register(ctx, eventID, userID, true, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.questionStorage.SaveAnswers(ctx, eventID, userID, answers); err != nil {
+		s.logger.Errorf("Failed to save answers of user %d for event %s: %v", userID, eventID, err)
+	}
+
+	return participant, nil
+}
+
+// RegisterOnSite registers the user who came to the event without registration (QR check-in).
+// Registration deadline is not checked, capacity and allowed roles are. The user is already on site,
+// so approval is not asked for.
+func (s *EventParticipantService) RegisterOnSite(ctx context.Context, eventID string, userID int64) (*entity.EventParticipant, error) {
+	return s.register(ctx, eventID, userID, false, false)
+}
+
+// register creates the participant, askApproval is false when the registration is made by the club owner
+// or on site and must not wait for approval even if the event requires it
+func (s *EventParticipantService) register(ctx context.Context, eventID string, userID int64, checkDeadline, askApproval bool) (*entity.EventParticipant, error) {
 	s.logger.Debugf("Registering user %d for event %s", userID, eventID)
 
+	status := entity.ParticipantStatusApproved
+	var event *entity.Event
+	if askApproval {
+		var err error
+		event, err = s.eventStorage.GetEventByID(ctx, eventID)
+		if err != nil {
+			return nil, err
+		}
+		if event.ApprovalRequired {
+			status = entity.ParticipantStatusPending
+		}
+	}
+
 	participant, err := s.storage.Create(ctx, &entity.EventParticipant{
 		UserID:  userID,
 		EventID: eventID,
-	})
+		Status:  status,
+	}, checkDeadline)
 	if err != nil {
+		if errors.Is(err, errorz.ErrEventFull) || errors.Is(err, errorz.ErrRegistrationClosed) || errors.Is(err, errorz.ErrRoleNotAllowed) ||
+			errors.Is(err, errorz.ErrEventCancelled) || errors.Is(err, errorz.ErrEventDraft) {
+			s.logger.Debugf("User %d can't be registered for event %s: %v", userID, eventID, err)
+			return nil, err
+		}
 		s.logger.Errorf("Failed to register user %d for event %s: %v", userID, eventID, err)
 		return nil, err
 	}
 
+	if err := s.waitlistStorage.Delete(ctx, eventID, userID); err != nil {
+		s.logger.Errorf("Failed to remove user %d from waitlist of event %s: %v", userID, eventID, err)
+	}
+
+	if participant.IsPending() {
+		// The pass is created only after the approval
+		if err := s.requestApproval(ctx, event, userID); err != nil {
+			s.logger.Errorf("Failed to send approval request for user %d, event %s: %v", userID, eventID, err)
+		}
+		s.logger.Debugf("Registration of user %d for event %s is waiting for approval", userID, eventID)
+		return participant, nil
+	}
+
 	if err := s.createPassIfRequired(ctx, eventID, userID); err != nil {
 		s.logger.Errorf("Failed to create pass for user %d, event %s: %v", userID, eventID, err)
 	}
@@ -68,6 +170,127 @@ func (s *EventParticipantService) Register(ctx context.Context, eventID string,
 	return participant, nil
 }
 
+func (s *EventParticipantService) requestApproval(ctx context.Context, event *entity.Event, userID int64) error {
+	user, err := s.userStorage.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return s.notifyService.SendApprovalRequest(*event, *user)
+}
+
+// Approve confirms the pending registration of the user, creates the pass if it's required and notifies the user.
+//
+// Returns errorz.ErrNotPending if the registration has already been approved.
+func (s *EventParticipantService) Approve(ctx context.Context, eventID string, userID int64) (*entity.EventParticipant, error) {
+	participant, err := s.storage.Get(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !participant.IsPending() {
+		return nil, errorz.ErrNotPending
+	}
+
+	participant.Status = entity.ParticipantStatusApproved
+	participant, err = s.storage.Update(ctx, participant)
+	if err != nil {
+		s.logger.Errorf("Failed to approve registration of user %d for event %s: %v", userID, eventID, err)
+		return nil, err
+	}
+
+	if err := s.createPassIfRequired(ctx, eventID, userID); err != nil {
+		s.logger.Errorf("Failed to create pass for user %d, event %s: %v", userID, eventID, err)
+	}
+
+	event, err := s.eventStorage.GetEventByID(ctx, eventID)
+	if err != nil {
+		s.logger.Errorf("Failed to get event %s for approval notification: %v", eventID, err)
+		return participant, nil
+	}
+	if err := s.notifyService.SendApprovalResult(userID, *event, true, ""); err != nil {
+		s.logger.Errorf("Failed to notify user %d about approval for event %s: %v", userID, eventID, err)
+	}
+
+	return participant, nil
+}
+
+// Reject declines the pending registration of the user, frees the seat and notifies the user,
+// reason is optional.
+//
+// Returns errorz.ErrNotPending if the registration has already been approved.
+func (s *EventParticipantService) Reject(ctx context.Context, eventID string, userID int64, reason string) error {
+	participant, err := s.storage.Get(ctx, eventID, userID)
+	if err != nil {
+		return err
+	}
+	if !participant.IsPending() {
+		return errorz.ErrNotPending
+	}
+
+	if err := s.storage.Delete(ctx, eventID, userID); err != nil {
+		s.logger.Errorf("Failed to reject registration of user %d for event %s: %v", userID, eventID, err)
+		return err
+	}
+
+	if err := s.questionStorage.DeleteAnswers(ctx, eventID, userID); err != nil {
+		s.logger.Errorf("Failed to delete answers of user %d for event %s: %v", userID, eventID, err)
+	}
+
+	event, err := s.eventStorage.GetEventByID(ctx, eventID)
+	if err != nil {
+		s.logger.Errorf("Failed to get event %s for rejection notification: %v", eventID, err)
+	} else if err := s.notifyService.SendApprovalResult(userID, *event, false, reason); err != nil {
+		s.logger.Errorf("Failed to notify user %d about rejection for event %s: %v", userID, eventID, err)
+	}
+
+	if err := s.promoteFromWaitlist(ctx, eventID); err != nil {
+		s.logger.Errorf("Failed to promote waitlist for event %s: %v", eventID, err)
+	}
+
+	return nil
+}
+
+// checkAnswers validates the answers against the registration form of the event
+// and returns the non-empty ones ready to be saved
+func (s *EventParticipantService) checkAnswers(ctx context.Context, eventID string, userID int64, answers []entity.EventAnswer) ([]entity.EventAnswer, error) {
+	questions, err := s.questionStorage.GetByEventID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	byQuestion := make(map[string]string, len(answers))
+	for _, answer := range answers {
+		byQuestion[answer.QuestionID] = strings.TrimSpace(answer.Answer)
+	}
+
+	checked := make([]entity.EventAnswer, 0, len(questions))
+	for _, question := range questions {
+		answer := byQuestion[question.ID]
+		if !question.IsValidAnswer(answer) {
+			return nil, errorz.ErrInvalidAnswer
+		}
+		if answer == "" {
+			continue
+		}
+		checked = append(checked, entity.EventAnswer{
+			QuestionID: question.ID,
+			UserID:     userID,
+			EventID:    eventID,
+			Answer:     answer,
+		})
+	}
+
+	return checked, nil
+}
+
+// GetQuestions returns the registration form of the event
+func (s *EventParticipantService) GetQuestions(ctx context.Context, eventID string) ([]entity.EventQuestion, error) {
+	return s.questionStorage.GetByEventID(ctx, eventID)
+}
+
+func (s *EventParticipantService) GetAnswersByEventID(ctx context.Context, eventID string) ([]entity.EventAnswer, error) {
+	return s.questionStorage.GetAnswersByEventID(ctx, eventID)
+}
+
 func (s *EventParticipantService) createPassIfRequired(ctx context.Context, eventID string, userID int64) error {
 	event, err := s.eventStorage.GetEventByID(ctx, eventID)
 	if err != nil {
@@ -95,7 +318,7 @@ func (s *EventParticipantService) createPassIfRequired(ctx context.Context, even
 		return nil
 	}
 
-	scheduledAt := event.CalculateScheduledAt()
+	scheduledAt := event.PassScheduledAt(user.Role, s.deadlines)
 
 	pass := &entity.Pass{
 		EventID:     eventID,
@@ -117,6 +340,49 @@ func (s *EventParticipantService) createPassIfRequired(ctx context.Context, even
 	return nil
 }
 
+// SyncPasses brings passes of the event participants in line with the event PassRequired flag and format.
+//
+// Used after the event location or format change: if a pass is no longer required, pending passes are cancelled,
+// otherwise missing passes are created for participants who need them.
+func (s *EventParticipantService) SyncPasses(ctx context.Context, eventID string) error {
+	event, err := s.eventStorage.Get(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	if !event.IsPassRequired() {
+		s.logger.Debugf("Pass is no longer required for event %s, cancelling pending passes", eventID)
+		return s.passStorage.CancelPendingPassesByEventID(ctx, eventID)
+	}
+
+	participants, err := s.storage.GetByEventID(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	for _, participant := range participants {
+		if participant.IsPending() {
+			continue
+		}
+		if err := s.createPassIfRequired(ctx, eventID, participant.UserID); err != nil {
+			s.logger.Errorf("Failed to create pass for user %d, event %s: %v", participant.UserID, eventID, err)
+		}
+	}
+
+	guests, err := s.guestStorage.GetByEventID(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	for _, guest := range guests {
+		if err := s.createGuestPassIfRequired(ctx, event, &guest); err != nil {
+			s.logger.Errorf("Failed to create pass for guest %s, event %s: %v", guest.ID, eventID, err)
+		}
+	}
+
+	return nil
+}
+
 func (s *EventParticipantService) Get(ctx context.Context, eventID string, userID int64) (*entity.EventParticipant, error) {
 	return s.storage.Get(ctx, eventID, userID)
 }
@@ -141,9 +407,111 @@ func (s *EventParticipantService) Delete(ctx context.Context, eventID string, us
 		return err
 	}
 
+	if err := s.questionStorage.DeleteAnswers(ctx, eventID, userID); err != nil {
+		s.logger.Errorf("Failed to delete answers of user %d for event %s: %v", userID, eventID, err)
+	}
+
+	// Guests can't come without the participant who brought them, their passes are already cancelled
+	if err := s.guestStorage.DeleteByEventAndUser(ctx, eventID, userID); err != nil {
+		s.logger.Errorf("Failed to delete guests of user %d for event %s: %v", userID, eventID, err)
+	}
+
+	if err := s.promoteFromWaitlist(ctx, eventID); err != nil {
+		s.logger.Errorf("Failed to promote waitlist for event %s: %v", eventID, err)
+	}
+
 	return nil
 }
 
+// AddGuest adds the guest of the registered participant to the event and creates a pass for the guest if it's required.
+//
+// Returns errorz.ErrNotRegistered, errorz.ErrGuestsNotAllowed, errorz.ErrTooManyGuests, errorz.ErrEventFull,
+// errorz.ErrRegistrationClosed or errorz.ErrEventCancelled if the guest can't be added.
+func (s *EventParticipantService) AddGuest(ctx context.Context, eventID string, userID int64, name, email string) (*entity.EventGuest, error) {
+	guest, err := s.guestStorage.Create(ctx, &entity.EventGuest{
+		EventID: eventID,
+		UserID:  userID,
+		Name:    name,
+		Email:   email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := s.eventStorage.GetEventByID(ctx, eventID)
+	if err != nil {
+		s.logger.Errorf("Failed to get event %s for guest pass: %v", eventID, err)
+		return guest, nil
+	}
+	if err := s.createGuestPassIfRequired(ctx, event, guest); err != nil {
+		s.logger.Errorf("Failed to create pass for guest %s, event %s: %v", guest.ID, eventID, err)
+	}
+
+	return guest, nil
+}
+
+// RemoveGuest removes the guest of the participant from the event and cancels the guest's pass
+func (s *EventParticipantService) RemoveGuest(ctx context.Context, eventID string, userID int64, guestID string) error {
+	guest, err := s.guestStorage.Get(ctx, guestID)
+	if err != nil {
+		return err
+	}
+	if guest.EventID != eventID || guest.UserID != userID {
+		return gorm.ErrRecordNotFound
+	}
+
+	if err := s.passStorage.CancelGuestPasses(ctx, guestID); err != nil {
+		s.logger.Errorf("Failed to cancel passes of guest %s: %v", guestID, err)
+	}
+
+	if err := s.guestStorage.Delete(ctx, guestID); err != nil {
+		return err
+	}
+
+	if err := s.promoteFromWaitlist(ctx, eventID); err != nil {
+		s.logger.Errorf("Failed to promote waitlist for event %s: %v", eventID, err)
+	}
+
+	return nil
+}
+
+func (s *EventParticipantService) GetGuests(ctx context.Context, eventID string, userID int64) ([]entity.EventGuest, error) {
+	return s.guestStorage.GetByEventAndUser(ctx, eventID, userID)
+}
+
+func (s *EventParticipantService) GetGuestsByEventID(ctx context.Context, eventID string) ([]entity.EventGuest, error) {
+	return s.guestStorage.GetByEventID(ctx, eventID)
+}
+
+// createGuestPassIfRequired creates a pass for the guest, guests have no role so only the event decides if it's required
+func (s *EventParticipantService) createGuestPassIfRequired(ctx context.Context, event *entity.Event, guest *entity.EventGuest) error {
+	if !event.IsPassRequired() {
+		return nil
+	}
+
+	hasActive, err := s.passStorage.HasActiveGuestPass(ctx, guest.ID)
+	if err != nil {
+		return err
+	}
+	if hasActive {
+		return nil
+	}
+
+	pass := &entity.Pass{
+		EventID:     event.ID,
+		UserID:      guest.UserID,
+		GuestID:     &guest.ID,
+		Type:        entity.PassTypeEvent,
+		Status:      entity.PassStatusPending,
+		ScheduledAt: event.GuestPassScheduledAt(s.deadlines),
+		Reason:      "guest registration",
+	}
+	pass.SetRequester(entity.PassRequesterTypeUser, guest.UserID)
+
+	_, err = s.passStorage.CreatePass(ctx, pass)
+	return err
+}
+
 func (s *EventParticipantService) GetByEventID(ctx context.Context, eventID string) ([]entity.EventParticipant, error) {
 	return s.storage.GetByEventID(ctx, eventID)
 }
@@ -158,8 +526,33 @@ func (s *EventParticipantService) CountVisitedByEventID(ctx context.Context, eve
 	return int(count), err
 }
 
+// GetUserEvents returns events the user is registered on with pagination, upcoming events that overlap
+// other registrations of the user are marked with HasConflict
 func (s *EventParticipantService) GetUserEvents(ctx context.Context, userID int64, limit, offset int) ([]dto.UserEvent, error) {
-	return s.storage.GetUserEvents(ctx, userID, limit, offset)
+	events, err := s.storage.GetUserEvents(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = markConflicts(ctx, s.storage, userID, events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// GetConflicts returns upcoming events the user is registered on that run at the same time as the event
+func (s *EventParticipantService) GetConflicts(ctx context.Context, eventID string, userID int64) ([]dto.UserEvent, error) {
+	event, err := s.eventStorage.Get(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	upcoming, err := upcomingUserEvents(ctx, s.storage, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return overlapping(dto.NewUserEventFromEntity(*event, false), upcoming), nil
 }
 
 func (s *EventParticipantService) CountUserEvents(ctx context.Context, userID int64) (int64, error) {
@@ -195,7 +588,7 @@ func (s *EventParticipantService) BulkRegister(ctx context.Context, eventID stri
 	var participants []entity.EventParticipant
 
 	for _, userID := range userIDs {
-		participant, err := s.Register(ctx, eventID, userID)
+		participant, err := s.register(ctx, eventID, userID, true, false)
 		if err != nil {
 			s.logger.Errorf("Failed to register user %d for event %s: %v", userID, eventID, err)
 			continue
@@ -237,3 +630,318 @@ func (s *EventParticipantService) GetNotVisitedParticipants(ctx context.Context,
 
 	return notVisitedParticipants, nil
 }
+
+// JoinWaitlist puts the user at the end of the event waitlist.
+// The answers to the registration form are saved right away, so the offered seat can be confirmed in one tap.
+func (s *EventParticipantService) JoinWaitlist(ctx context.Context, eventID string, userID int64, answers []entity.EventAnswer) (*entity.EventWaitlist, error) {
+	s.logger.Debugf("Adding user %d to waitlist of event %s", userID, eventID)
+
+	answers, err := s.checkAnswers(ctx, eventID, userID, answers)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkNoShows(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	event, err := s.eventStorage.GetEventByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userStorage.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !event.IsRoleAllowed(user.Role) {
+		return nil, errorz.ErrRoleNotAllowed
+	}
+	if !event.IsRegistrationOpen(user.Role, time.Now(), s.deadlines) {
+		return nil, errorz.ErrRegistrationClosed
+	}
+
+	entry, err := s.waitlistStorage.Create(ctx, &entity.EventWaitlist{
+		EventID: eventID,
+		UserID:  userID,
+	})
+	if err != nil {
+		s.logger.Errorf("Failed to add user %d to waitlist of event %s: %v", userID, eventID, err)
+		return nil, err
+	}
+
+	if err := s.questionStorage.SaveAnswers(ctx, eventID, userID, answers); err != nil {
+		s.logger.Errorf("Failed to save answers of user %d for event %s: %v", userID, eventID, err)
+	}
+
+	// A seat may have been freed while the user was looking at a full event
+	if err := s.promoteFromWaitlist(ctx, eventID); err != nil {
+		s.logger.Errorf("Failed to promote waitlist for event %s: %v", eventID, err)
+	}
+
+	return entry, nil
+}
+
+// LeaveWaitlist removes the user from the event waitlist. If the user had a pending
+// seat offer, the seat is offered to the next user in line.
+func (s *EventParticipantService) LeaveWaitlist(ctx context.Context, eventID string, userID int64) error {
+	entry, err := s.waitlistStorage.Get(ctx, eventID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if err := s.waitlistStorage.Delete(ctx, eventID, userID); err != nil {
+		s.logger.Errorf("Failed to remove user %d from waitlist of event %s: %v", userID, eventID, err)
+		return err
+	}
+
+	if err := s.questionStorage.DeleteAnswers(ctx, eventID, userID); err != nil {
+		s.logger.Errorf("Failed to delete answers of user %d for event %s: %v", userID, eventID, err)
+	}
+
+	if entry.IsOffered() {
+		if err := s.promoteFromWaitlist(ctx, eventID); err != nil {
+			s.logger.Errorf("Failed to promote waitlist for event %s: %v", eventID, err)
+		}
+	}
+
+	return nil
+}
+
+// ConfirmWaitlistOffer registers the user on the event if the seat offer is still valid
+func (s *EventParticipantService) ConfirmWaitlistOffer(ctx context.Context, eventID string, userID int64) (*entity.EventParticipant, error) {
+	entry, err := s.waitlistStorage.Get(ctx, eventID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorz.ErrWaitlistOfferExpired
+		}
+		return nil, err
+	}
+
+	if !entry.IsOffered() || entry.IsOfferExpired() {
+		return nil, errorz.ErrWaitlistOfferExpired
+	}
+	if err := s.checkNoShows(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	// The answers were saved when the user joined the waitlist
+	return s.register(ctx, eventID, userID, true, true)
+}
+
+// GetWaitlistPosition returns the 1-based position of the user in the waitlist or 0 if the user is not in it
+func (s *EventParticipantService) GetWaitlistPosition(ctx context.Context, eventID string, userID int64) (int, error) {
+	position, err := s.waitlistStorage.GetPosition(ctx, eventID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return int(position), nil
+}
+
+func (s *EventParticipantService) GetWaitlist(ctx context.Context, eventID string) ([]entity.EventWaitlist, error) {
+	return s.waitlistStorage.GetByEventID(ctx, eventID)
+}
+
+func (s *EventParticipantService) CountWaitlistByEventID(ctx context.Context, eventID string) (int, error) {
+	count, err := s.waitlistStorage.CountByEventID(ctx, eventID)
+	return int(count), err
+}
+
+// CountOfferedSeats returns the number of seats reserved for waitlisted users who have not confirmed yet
+func (s *EventParticipantService) CountOfferedSeats(ctx context.Context, eventID string) (int, error) {
+	count, err := s.waitlistStorage.CountActiveOffers(ctx, eventID, time.Now())
+	return int(count), err
+}
+
+// GetSeatsByRole returns the seats taken by every allowed role of the event in the order of valueobject.AllRoles
+func (s *EventParticipantService) GetSeatsByRole(ctx context.Context, eventID string) ([]dto.RoleSeats, error) {
+	event, err := s.eventStorage.GetEventByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.seatsByRole(ctx, event, time.Now())
+}
+
+func (s *EventParticipantService) seatsByRole(ctx context.Context, event *entity.Event, now time.Time) ([]dto.RoleSeats, error) {
+	taken, err := s.storage.CountByRole(ctx, event.ID)
+	if err != nil {
+		return nil, err
+	}
+	offered, err := s.waitlistStorage.CountActiveOffersByRole(ctx, event.ID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var seats []dto.RoleSeats
+	for _, role := range valueobject.AllRoles() {
+		if !event.IsRoleAllowed(role) {
+			continue
+		}
+		seats = append(seats, dto.RoleSeats{
+			Role:    role,
+			Quota:   event.RoleQuota(role),
+			Taken:   int(taken[role]),
+			Offered: int(offered[role]),
+		})
+	}
+	return seats, nil
+}
+
+// StartWaitlistScheduler starts the scheduler that expires stale seat offers and
+// offers free seats to the next users in line
+func (s *EventParticipantService) StartWaitlistScheduler() {
+	s.logger.Debug("Starting waitlist scheduler")
+	go func() {
+		ticker := time.NewTicker(1 * time.Minute)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			s.processWaitlists(context.Background())
+		}
+	}()
+	s.logger.Info("Waitlist scheduler started")
+}
+
+func (s *EventParticipantService) processWaitlists(ctx context.Context) {
+	eventIDs := make(map[string]struct{})
+
+	expired, err := s.waitlistStorage.GetExpiredOffers(ctx, time.Now())
+	if err != nil {
+		s.logger.Errorf("Failed to get expired waitlist offers: %v", err)
+		return
+	}
+	for _, entry := range expired {
+		s.logger.Infof("Waitlist offer expired (user_id=%d, event_id=%s)", entry.UserID, entry.EventID)
+		if err := s.waitlistStorage.Delete(ctx, entry.EventID, entry.UserID); err != nil {
+			s.logger.Errorf("Failed to remove user %d from waitlist of event %s: %v", entry.UserID, entry.EventID, err)
+			continue
+		}
+		eventIDs[entry.EventID] = struct{}{}
+	}
+
+	// Seats can also become free when the owner raises MaxParticipants
+	waiting, err := s.waitlistStorage.GetWaitingEventIDs(ctx)
+	if err != nil {
+		s.logger.Errorf("Failed to get events with waitlist: %v", err)
+	}
+	for _, eventID := range waiting {
+		eventIDs[eventID] = struct{}{}
+	}
+
+	for eventID := range eventIDs {
+		if err := s.promoteFromWaitlist(ctx, eventID); err != nil {
+			s.logger.Errorf("Failed to promote waitlist for event %s: %v", eventID, err)
+		}
+	}
+}
+
+// promoteFromWaitlist offers every free seat of the event to the next users in line,
+// users are skipped while the quota of their role is reached.
+//
+// The event is locked while the offers are saved, so concurrent promotions don't offer the same seat twice.
+// Users are notified after the lock is released
+func (s *EventParticipantService) promoteFromWaitlist(ctx context.Context, eventID string) error {
+	var (
+		event  *entity.Event
+		offers []entity.EventWaitlist
+	)
+	err := s.eventStorage.WithLock(ctx, eventID, func(ctx context.Context) error {
+		var err error
+		event, offers, err = s.offerFreeSeats(ctx, eventID)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, offer := range offers {
+		if err := s.notifyService.SendWaitlistOffer(offer.UserID, *event, *offer.OfferExpiresAt); err != nil {
+			s.logger.Errorf("Failed to send waitlist offer to user %d, event %s: %v", offer.UserID, eventID, err)
+		}
+	}
+	return nil
+}
+
+// offerFreeSeats saves the offers of the free seats of the event and returns them
+func (s *EventParticipantService) offerFreeSeats(ctx context.Context, eventID string) (*entity.Event, []entity.EventWaitlist, error) {
+	event, err := s.eventStorage.GetEventByID(ctx, eventID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	if event.IsCancelled() || event.RegistrationEnd.Before(now) {
+		return event, nil, nil
+	}
+
+	// Users who reached the no-show limit are offered seats only when nobody else is waiting
+	restricted, err := s.restrictedWaitlistUsers(ctx, eventID, now)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var offers []entity.EventWaitlist
+	for {
+		participantsCount, err := s.storage.CountByEventID(ctx, eventID)
+		if err != nil {
+			return nil, nil, err
+		}
+		offeredCount, err := s.waitlistStorage.CountActiveOffers(ctx, eventID, now)
+		if err != nil {
+			return nil, nil, err
+		}
+		if event.MaxParticipants > 0 && int(participantsCount+offeredCount) >= event.MaxParticipants {
+			return event, offers, nil
+		}
+
+		// Users whose roles have no free seats keep their place in the queue
+		var fullRoles entity.Roles
+		if event.HasRoleQuotas() {
+			seats, err := s.seatsByRole(ctx, event, now)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, roleSeats := range seats {
+				if roleSeats.IsFull() {
+					fullRoles = append(fullRoles, roleSeats.Role)
+				}
+			}
+		}
+
+		next, err := s.waitlistStorage.GetNext(ctx, eventID, fullRoles, restricted)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return event, offers, nil
+			}
+			return nil, nil, err
+		}
+
+		deadline := event.RegistrationDeadline(next.User.Role, s.deadlines)
+		if !deadline.After(now) {
+			s.logger.Infof("Registration closed for waitlisted user (user_id=%d, event_id=%s)", next.UserID, eventID)
+			if err := s.waitlistStorage.Delete(ctx, eventID, next.UserID); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+
+		expiresAt := now.Add(s.waitlistOfferTTL)
+		if expiresAt.After(deadline) {
+			expiresAt = deadline
+		}
+		next.OfferExpiresAt = &expiresAt
+		if _, err := s.waitlistStorage.Update(ctx, next); err != nil {
+			return nil, nil, err
+		}
+
+		s.logger.Infof("Offering seat from waitlist (user_id=%d, event_id=%s, expires_at=%s)", next.UserID, eventID, expiresAt.Format("2006-01-02 15:04:05"))
+		offers = append(offers, *next)
+	}
+}
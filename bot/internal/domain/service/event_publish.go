@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	tele "gopkg.in/telebot.v3"
+	"gopkg.in/telebot.v3/layout"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/common/errorz"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/location"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/ports/secondary"
+	"github.com/Badsnus/cu-clubs-bot/bot/pkg/logger/types"
+)
+
+/*
+EventPublishService - сервис публикации черновиков мероприятий.
+Основные функции:
+- Публикация черновика сразу или в запланированное время
+- Планировщик, который публикует черновики по расписанию
+- Рассылка участникам клуба с анонсом опубликованного мероприятия
+*/
+type EventPublishService struct {
+	eventRepo secondary.EventRepository
+	clubRepo  secondary.ClubRepository
+	userRepo  secondary.UserRepository
+
+	bot    *tele.Bot
+	layout *layout.Layout
+	logger *types.Logger
+}
+
+func NewEventPublishService(
+	bot *tele.Bot,
+	layout *layout.Layout,
+	logger *types.Logger,
+	eventStorage secondary.EventRepository,
+	clubStorage secondary.ClubRepository,
+	userStorage secondary.UserRepository,
+) *EventPublishService {
+	return &EventPublishService{
+		eventRepo: eventStorage,
+		clubRepo:  clubStorage,
+		userRepo:  userStorage,
+		bot:       bot,
+		layout:    layout,
+		logger:    logger,
+	}
+}
+
+// Publish publishes the draft right away, drafts of the other occurrences of the series are published with it.
+// If the draft has AnnounceOnPublish, the club mailing about the event is sent.
+//
+// Returns errorz.ErrEventNotDraft if the event has already been published.
+func (s *EventPublishService) Publish(ctx context.Context, eventID string) (*entity.Event, error) {
+	event, err := s.eventRepo.Get(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if !event.IsDraft() {
+		return nil, errorz.ErrEventNotDraft
+	}
+
+	return s.publish(ctx, event)
+}
+
+func (s *EventPublishService) publish(ctx context.Context, event *entity.Event) (*entity.Event, error) {
+	announce := event.AnnounceOnPublish
+
+	drafts := []entity.Event{*event}
+	if event.IsRecurring() {
+		var err error
+		drafts, err = s.eventRepo.GetDraftsBySeriesID(ctx, *event.SeriesID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range drafts {
+		drafts[i].Publish()
+		if _, err := s.eventRepo.Update(ctx, &drafts[i]); err != nil {
+			return nil, err
+		}
+	}
+	event.Publish()
+	s.logger.Infof("Event published (event_id=%s, occurrences=%d)", event.ID, len(drafts))
+
+	if announce {
+		if err := s.announce(ctx, *event); err != nil {
+			s.logger.Errorf("failed to announce event %s: %v", event.ID, err)
+		}
+	}
+
+	return event, nil
+}
+
+// Schedule sets the time when the scheduler publishes the draft and whether the club mailing is sent then.
+// The schedule is shared by all drafts of the series.
+//
+// Returns errorz.ErrEventNotDraft if the event has already been published.
+func (s *EventPublishService) Schedule(ctx context.Context, eventID string, publishAt *time.Time, announce bool) (*entity.Event, error) {
+	event, err := s.eventRepo.Get(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if !event.IsDraft() {
+		return nil, errorz.ErrEventNotDraft
+	}
+
+	drafts := []entity.Event{*event}
+	if event.IsRecurring() {
+		drafts, err = s.eventRepo.GetDraftsBySeriesID(ctx, *event.SeriesID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range drafts {
+		drafts[i].PublishAt = publishAt
+		drafts[i].AnnounceOnPublish = announce
+		if _, err = s.eventRepo.Update(ctx, &drafts[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	event.PublishAt = publishAt
+	event.AnnounceOnPublish = announce
+	return event, nil
+}
+
+// StartPublishScheduler starts the scheduler that publishes drafts at their PublishAt
+func (s *EventPublishService) StartPublishScheduler() {
+	s.logger.Debug("Starting publish scheduler")
+	go func() {
+		ticker := time.NewTicker(1 * time.Minute)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			s.publishScheduled(context.Background())
+		}
+	}()
+	s.logger.Info("Publish scheduler started")
+}
+
+func (s *EventPublishService) publishScheduled(ctx context.Context) {
+	drafts, err := s.eventRepo.GetScheduledDrafts(ctx, time.Now())
+	if err != nil {
+		s.logger.Errorf("failed to get scheduled drafts: %v", err)
+		return
+	}
+
+	for _, draft := range drafts {
+		// The draft may have been published with an earlier occurrence of its series
+		event, err := s.eventRepo.Get(ctx, draft.ID)
+		if err != nil {
+			s.logger.Errorf("failed to get draft %s: %v", draft.ID, err)
+			continue
+		}
+		if !event.IsDraft() {
+			continue
+		}
+
+		if _, err = s.publish(ctx, event); err != nil {
+			s.logger.Errorf("failed to publish draft %s: %v", event.ID, err)
+		}
+	}
+}
+
+// announce sends the club mailing about the published event to users who allow mailing from the club
+//
+// NOTE: localisation is hardcoded for now (ru)
+func (s *EventPublishService) announce(ctx context.Context, event entity.Event) error {
+	club, err := s.clubRepo.Get(ctx, event.ClubID)
+	if err != nil {
+		return err
+	}
+
+	users, err := s.userRepo.GetUsersByClubID(ctx, club.ID)
+	if err != nil {
+		return err
+	}
+
+	text := s.layout.TextLocale("ru", "event_announcement", struct {
+		ClubName  string
+		Name      string
+		StartTime string
+		Location  string
+		Link      string
+	}{
+		ClubName:  club.Name,
+		Name:      event.Name,
+		StartTime: event.StartTime.In(location.Location()).Format("02.01.2006 15:04"),
+		Location:  event.Location,
+		Link:      event.Link(s.bot.Me.Username),
+	})
+	markup := s.layout.MarkupLocale("ru", "mailing", struct {
+		ClubID  string
+		Allowed bool
+	}{
+		ClubID:  club.ID,
+		Allowed: true,
+	})
+
+	for _, user := range users {
+		if !user.IsMailingAllowed(club.ID) {
+			continue
+		}
+		chat, errGetChat := s.bot.ChatByID(user.ID)
+		if errGetChat != nil {
+			continue
+		}
+		if _, errSend := s.bot.Send(chat, text, markup); errSend != nil {
+			s.logger.Errorf("failed to send event announcement to user %d: %v", user.ID, errSend)
+		}
+	}
+
+	s.logger.Infof("Event announcement sent (event_id=%s, club_id=%s)", event.ID, club.ID)
+	return nil
+}
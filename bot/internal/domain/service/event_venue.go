@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/common/errorz"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+)
+
+// GetVenueBookings returns events that have already booked the venue of the event at its time
+func (s *EventService) GetVenueBookings(ctx context.Context, event entity.Event) ([]entity.Event, error) {
+	if !event.BooksVenue() {
+		return nil, nil
+	}
+	return s.repo.GetVenueBookings(ctx, *event.VenueID, event.StartTime, event.End(), event.ID)
+}
+
+// checkVenue checks that the event fits into its venue and reports if the venue has to be booked again,
+// the repository checks that the venue is free while saving the event, so concurrent bookings don't overlap.
+//
+// previous is the saved version of the event, nil for new events. Only changed fields are checked,
+// so events that already violate the rules (e.g. the admin has reduced the capacity) can still be edited
+func (s *EventService) checkVenue(ctx context.Context, event, previous *entity.Event) (bool, error) {
+	if !event.BooksVenue() {
+		return false, nil
+	}
+
+	venueChanged := previous == nil ||
+		!previous.BooksVenue() ||
+		*previous.VenueID != *event.VenueID
+	capacityChanged := venueChanged || previous.MaxParticipants != event.MaxParticipants
+	timeChanged := venueChanged ||
+		!previous.StartTime.Equal(event.StartTime) ||
+		!previous.End().Equal(event.End())
+
+	if capacityChanged {
+		venue, err := s.venueRepo.Get(ctx, *event.VenueID)
+		if err != nil {
+			return false, err
+		}
+		if !venue.Fits(event.MaxParticipants) {
+			return false, errorz.ErrVenueCapacityExceeded
+		}
+	}
+
+	return timeChanged, nil
+}
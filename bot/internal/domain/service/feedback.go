@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	tele "gopkg.in/telebot.v3"
+	"gopkg.in/telebot.v3/layout"
+	"gorm.io/gorm"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/common/errorz"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/dto"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/ports/secondary"
+	"github.com/Badsnus/cu-clubs-bot/bot/pkg/logger/types"
+)
+
+// feedbackRequestWindow limits how long after the end of the event feedback is still requested,
+// so participants of long-past events are not asked after a restart
+const feedbackRequestWindow = 24 * time.Hour
+
+type FeedbackService struct {
+	repo                 secondary.EventFeedbackRepository
+	eventRepo            secondary.EventRepository
+	eventParticipantRepo secondary.EventParticipantRepository
+	notificationRepo     secondary.NotificationRepository
+
+	bot    *tele.Bot
+	layout *layout.Layout
+	logger *types.Logger
+
+	delay time.Duration
+}
+
+func NewFeedbackService(
+	bot *tele.Bot,
+	layout *layout.Layout,
+	logger *types.Logger,
+	storage secondary.EventFeedbackRepository,
+	eventStorage secondary.EventRepository,
+	eventParticipantStorage secondary.EventParticipantRepository,
+	notificationStorage secondary.NotificationRepository,
+	delay time.Duration,
+) *FeedbackService {
+	return &FeedbackService{
+		repo:                 storage,
+		eventRepo:            eventStorage,
+		eventParticipantRepo: eventParticipantStorage,
+		notificationRepo:     notificationStorage,
+		bot:                  bot,
+		layout:               layout,
+		logger:               logger,
+		delay:                delay,
+	}
+}
+
+// Rate saves the rating of the event by the user, only participants who visited the event can rate it
+func (s *FeedbackService) Rate(ctx context.Context, eventID string, userID int64, rating int) (*entity.EventFeedback, error) {
+	if !entity.IsValidFeedbackRating(rating) {
+		return nil, errorz.ErrInvalidFeedbackRating
+	}
+
+	participant, err := s.eventParticipantRepo.Get(ctx, eventID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorz.ErrFeedbackNotAllowed
+		}
+		return nil, err
+	}
+	if !participant.IsUserQr && !participant.IsEventQr {
+		return nil, errorz.ErrFeedbackNotAllowed
+	}
+
+	feedback, err := s.repo.Get(ctx, eventID, userID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		feedback = &entity.EventFeedback{EventID: eventID, UserID: userID}
+	}
+
+	feedback.Rating = rating
+	return s.repo.Save(ctx, feedback)
+}
+
+// Comment adds a comment to the feedback, the event must be rated first
+func (s *FeedbackService) Comment(ctx context.Context, eventID string, userID int64, comment string) (*entity.EventFeedback, error) {
+	feedback, err := s.repo.Get(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	feedback.Comment = strings.TrimSpace(comment)
+	return s.repo.Save(ctx, feedback)
+}
+
+func (s *FeedbackService) GetByEventID(ctx context.Context, eventID string) ([]entity.EventFeedback, error) {
+	return s.repo.GetByEventID(ctx, eventID)
+}
+
+func (s *FeedbackService) GetEventSummary(ctx context.Context, eventID string) (dto.FeedbackSummary, error) {
+	return s.repo.GetSummaryByEventID(ctx, eventID)
+}
+
+func (s *FeedbackService) GetClubSummary(ctx context.Context, clubID string) (dto.FeedbackSummary, error) {
+	return s.repo.GetSummaryByClubID(ctx, clubID)
+}
+
+// StartFeedbackScheduler starts the scheduler that asks visitors of ended events for feedback
+func (s *FeedbackService) StartFeedbackScheduler() {
+	s.logger.Debug("Starting feedback scheduler")
+	go func() {
+		ticker := time.NewTicker(1 * time.Minute)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			s.requestFeedback(context.Background())
+		}
+	}()
+	s.logger.Info("Feedback scheduler started")
+}
+
+// requestFeedback sends feedback requests for events that ended at least delay ago
+//
+// NOTE: localisation is hardcoded for now (ru)
+func (s *FeedbackService) requestFeedback(ctx context.Context) {
+	to := time.Now().Add(-s.delay)
+	events, err := s.eventRepo.GetEndedEvents(ctx, to.Add(-feedbackRequestWindow), to)
+	if err != nil {
+		s.logger.Errorf("failed to get ended events: %v", err)
+		return
+	}
+
+	for _, event := range events {
+		participants, err := s.notificationRepo.GetUnnotifiedUsers(ctx, event.ID, entity.NotificationTypeFeedback)
+		if err != nil {
+			s.logger.Errorf("failed to get users without feedback request for event %s: %v", event.ID, err)
+			continue
+		}
+
+		for _, participant := range participants {
+			if !participant.IsUserQr && !participant.IsEventQr {
+				continue
+			}
+
+			s.logger.Infof("Sending feedback request to user (user_id=%d, event_id=%s)", participant.UserID, event.ID)
+			chat, errGetChat := s.bot.ChatByID(participant.UserID)
+			if errGetChat != nil {
+				s.logger.Errorf("failed to get chat for user %d: %v", participant.UserID, errGetChat)
+				continue
+			}
+
+			_, errSend := s.bot.Send(chat,
+				s.layout.TextLocale("ru", "feedback_request", struct {
+					Name string
+				}{
+					Name: event.Name,
+				}),
+				s.layout.MarkupLocale("ru", "feedback:rate", struct {
+					ID string
+				}{
+					ID: event.ID,
+				}),
+			)
+			if errSend != nil {
+				s.logger.Errorf("failed to send feedback request to user %d: %v", participant.UserID, errSend)
+				continue
+			}
+
+			notification := &entity.EventNotification{
+				EventID: event.ID,
+				UserID:  participant.UserID,
+				Type:    entity.NotificationTypeFeedback,
+			}
+			if err := s.notificationRepo.Create(ctx, notification); err != nil {
+				s.logger.Errorf("failed to create notification record: %v", err)
+			}
+		}
+	}
+}
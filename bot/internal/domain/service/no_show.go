@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/common/errorz"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/dto"
+)
+
+// GetNoShowStanding returns the no-shows of the user within the period of the no-show policy
+func (s *EventParticipantService) GetNoShowStanding(ctx context.Context, userID int64) (dto.NoShowStanding, error) {
+	standings, err := s.noShowStandings(ctx, []int64{userID}, time.Now())
+	if err != nil {
+		return dto.NoShowStanding{}, err
+	}
+	return standings[userID], nil
+}
+
+func (s *EventParticipantService) noShowStandings(ctx context.Context, userIDs []int64, now time.Time) (map[int64]dto.NoShowStanding, error) {
+	policy := s.noShows
+
+	var noShows map[int64][]time.Time
+	if policy.IsEnabled() && len(userIDs) > 0 {
+		var err error
+		noShows, err = s.storage.GetNoShows(ctx, userIDs, now.Add(-policy.Period), now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	standings := make(map[int64]dto.NoShowStanding, len(userIDs))
+	for _, userID := range userIDs {
+		standings[userID] = policy.Standing(noShows[userID], now)
+	}
+	return standings, nil
+}
+
+// checkNoShows returns errorz.ErrRegistrationBlocked if the user is blocked by the no-show policy
+func (s *EventParticipantService) checkNoShows(ctx context.Context, userID int64) error {
+	standing, err := s.GetNoShowStanding(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if standing.IsBlocked(time.Now()) {
+		s.logger.Debugf("User %d is blocked for no-shows until %s", userID, standing.BlockedUntil.Format("2006-01-02 15:04:05"))
+		return errorz.ErrRegistrationBlocked
+	}
+	return nil
+}
+
+// restrictedWaitlistUsers returns the users in the event waitlist who reached the no-show limit,
+// nil if the policy doesn't move them to the end of waitlists
+func (s *EventParticipantService) restrictedWaitlistUsers(ctx context.Context, eventID string, now time.Time) ([]int64, error) {
+	if !s.noShows.IsEnabled() || !s.noShows.WaitlistLast {
+		return nil, nil
+	}
+
+	entries, err := s.waitlistStorage.GetByEventID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]int64, 0, len(entries))
+	for _, entry := range entries {
+		userIDs = append(userIDs, entry.UserID)
+	}
+
+	standings, err := s.noShowStandings(ctx, userIDs, now)
+	if err != nil {
+		return nil, err
+	}
+	var restricted []int64
+	for _, userID := range userIDs {
+		if standings[userID].Restricted {
+			restricted = append(restricted, userID)
+		}
+	}
+	return restricted, nil
+}
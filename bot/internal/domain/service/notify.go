@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"slices"
 	"strings"
 	"time"
 
@@ -23,6 +24,7 @@ type NotifyService struct {
 	eventRepo            secondary.EventRepository
 	notificationRepo     secondary.NotificationRepository
 	eventParticipantRepo secondary.EventParticipantRepository
+	userRepo             secondary.UserRepository
 
 	bot    *tele.Bot
 	layout *layout.Layout
@@ -37,12 +39,14 @@ func NewNotifyService(
 	eventRepo secondary.EventRepository,
 	notificationRepo secondary.NotificationRepository,
 	notifyEventParticipantRepo secondary.EventParticipantRepository,
+	userRepo secondary.UserRepository,
 ) *NotifyService {
 	return &NotifyService{
 		clubOwnerService:     clubOwnerService,
 		eventRepo:            eventRepo,
 		notificationRepo:     notificationRepo,
 		eventParticipantRepo: notifyEventParticipantRepo,
+		userRepo:             userRepo,
 		bot:                  bot,
 		layout:               layout,
 		logger:               logger,
@@ -105,6 +109,10 @@ func (s *NotifyService) SendEventUpdate(eventID string, what interface{}, opts .
 
 	var errors []error
 	for _, participant := range participants {
+		// Pending registrations are not participants yet, the update may contain the meeting link
+		if participant.IsPending() {
+			continue
+		}
 		chat, errGetChat := s.bot.ChatByID(participant.UserID)
 		if errGetChat != nil {
 			errors = append(errors, errGetChat)
@@ -121,6 +129,97 @@ func (s *NotifyService) SendEventUpdate(eventID string, what interface{}, opts .
 	return nil
 }
 
+// SendWaitlistOffer sends a waitlisted user an offer to take a free seat on the event
+//
+// NOTE: localisation is hardcoded for now (ru)
+func (s *NotifyService) SendWaitlistOffer(userID int64, event entity.Event, expiresAt time.Time) error {
+	chat, err := s.bot.ChatByID(userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.bot.Send(chat,
+		s.layout.TextLocale("ru", "waitlist_offer", struct {
+			Name      string
+			StartTime string
+			ExpiresAt string
+		}{
+			Name:      event.Name,
+			StartTime: event.StartTime.In(location.Location()).Format("02.01.2006 15:04"),
+			ExpiresAt: expiresAt.In(location.Location()).Format("02.01.2006 15:04"),
+		}),
+		s.layout.MarkupLocale("ru", "waitlist:offer", struct {
+			ID string
+		}{
+			ID: event.ID,
+		}),
+	)
+	return err
+}
+
+// SendApprovalRequest sends club owners a card to approve or reject the pending registration of the user
+//
+// NOTE: localisation is hardcoded for now (ru)
+func (s *NotifyService) SendApprovalRequest(event entity.Event, user entity.User) error {
+	return s.SendClubWarning(event.ClubID,
+		s.layout.TextLocale("ru", "approval_request", struct {
+			Name      string
+			StartTime string
+			FIO       string
+			Username  string
+			Email     string
+			Role      string
+		}{
+			Name:      event.Name,
+			StartTime: event.StartTime.In(location.Location()).Format("02.01.2006 15:04"),
+			FIO:       user.FIO.String(),
+			Username:  user.Username,
+			Email:     user.Email.String(),
+			Role:      user.Role.String(),
+		}),
+		s.layout.MarkupLocale("ru", "clubOwner:approval", struct {
+			ID     string
+			UserID int64
+		}{
+			ID:     event.ID,
+			UserID: user.ID,
+		}),
+	)
+}
+
+// SendApprovalResult notifies the user whether the registration has been approved, reason is shown only on rejection
+//
+// NOTE: localisation is hardcoded for now (ru)
+func (s *NotifyService) SendApprovalResult(userID int64, event entity.Event, approved bool, reason string) error {
+	chat, err := s.bot.ChatByID(userID)
+	if err != nil {
+		return err
+	}
+
+	messageKey := "approval_rejected"
+	if approved {
+		messageKey = "approval_approved"
+	}
+
+	_, err = s.bot.Send(chat,
+		s.layout.TextLocale("ru", messageKey, struct {
+			Name                  string
+			StartTime             string
+			AfterRegistrationText string
+			MeetingURL            string
+			Reason                string
+		}{
+			Name:                  event.Name,
+			StartTime:             event.StartTime.In(location.Location()).Format("02.01.2006 15:04"),
+			AfterRegistrationText: event.AfterRegistrationText,
+			MeetingURL:            event.MeetingURL,
+			Reason:                reason,
+		}),
+		s.layout.MarkupLocale("ru", "core:hide"),
+	)
+	return err
+}
+
 // StartNotifyScheduler starts the scheduler for sending notifications
 func (s *NotifyService) StartNotifyScheduler() {
 	s.logger.Debug("Starting notify scheduler")
@@ -136,15 +235,16 @@ func (s *NotifyService) StartNotifyScheduler() {
 	s.logger.Info("Notify scheduler started")
 }
 
-// checkAndNotify checks for events starting in the next 25 hours (to cover both day and hour notifications)
+// checkAndNotify sends reminders about events whose reminder offsets have come
 //
 // NOTE: localisation is hardcoded for now (ru)
 func (s *NotifyService) checkAndNotify(ctx context.Context) {
-	s.logger.Debugf("Checking for events starting in the next 25 hours")
+	// ReminderOffsetOptions starts with the earliest reminder
+	horizon := entity.ReminderOffsetOptions[0].Duration()
+	s.logger.Debugf("Checking for events starting in the next %s", horizon)
 	now := time.Now().In(location.Location())
 
-	// Get events starting in the next 25 hours (to cover both day and hour notifications)
-	events, err := s.eventRepo.GetUpcomingEvents(ctx, now.Add(25*time.Hour))
+	events, err := s.eventRepo.GetUpcomingEvents(ctx, now.Add(horizon))
 	if err != nil {
 		s.logger.Errorf("failed to get upcoming events: %v", err)
 		return
@@ -154,22 +254,38 @@ func (s *NotifyService) checkAndNotify(ctx context.Context) {
 		timeUntilStart := event.StartTime.Sub(now)
 		s.logger.Debugf("Event %s starts in %s", event.ID, timeUntilStart)
 
-		// Check for day notification (between 23-24 hours before start)
-		if timeUntilStart >= 23*time.Hour && timeUntilStart <= 24*time.Hour {
-			s.logger.Infof("Sending day notification for event (event_id=%s)", event.ID)
-			s.sendNotifications(ctx, event, entity.NotificationTypeDay)
+		// Participants may have chosen reminders the event doesn't have
+		var due entity.ReminderOffsets
+		for _, offset := range append(slices.Clone(event.Reminders()), entity.ReminderOffsetOptions...) {
+			if offset.IsDue(timeUntilStart) && !slices.Contains(due, offset) {
+				due = append(due, offset)
+			}
+		}
+		if len(due) == 0 {
+			continue
+		}
+
+		users, err := s.userRepo.GetUsersByEventID(ctx, event.ID)
+		if err != nil {
+			s.logger.Errorf("failed to get users of event %s: %v", event.ID, err)
+			continue
+		}
+		usersByID := make(map[int64]entity.User, len(users))
+		for _, user := range users {
+			usersByID[user.ID] = user
 		}
 
-		// Check for hour notification (between 55-60 minutes before start)
-		if timeUntilStart >= 55*time.Minute && timeUntilStart <= 60*time.Minute {
-			s.logger.Infof("Sending hour notification for event (event_id=%s)", event.ID)
-			s.sendNotifications(ctx, event, entity.NotificationTypeHour)
+		for _, offset := range due {
+			s.logger.Infof("Sending reminders for event (event_id=%s, offset=%dm)", event.ID, offset)
+			s.sendNotifications(ctx, event, offset, usersByID)
 		}
 	}
 }
 
-// sendNotifications sends notifications to users that have not been notified
-func (s *NotifyService) sendNotifications(ctx context.Context, event entity.Event, notificationType entity.NotificationType) {
+// sendNotifications sends the reminder to participants who want it and have not been reminded yet
+func (s *NotifyService) sendNotifications(ctx context.Context, event entity.Event, offset entity.ReminderOffset, users map[int64]entity.User) {
+	notificationType := offset.NotificationType()
+
 	// Get users who haven't been notified yet
 	participants, err := s.notificationRepo.GetUnnotifiedUsers(ctx, event.ID, notificationType)
 	if err != nil {
@@ -178,6 +294,11 @@ func (s *NotifyService) sendNotifications(ctx context.Context, event entity.Even
 	}
 
 	for _, participant := range participants {
+		user, ok := users[participant.UserID]
+		if !ok || !slices.Contains(user.Reminders(&event), offset) {
+			continue
+		}
+
 		s.logger.Infof(
 			"Sending %s notification to user (user_id=%d, event_id=%s, notification_type=%s)",
 			notificationType,
@@ -193,16 +314,16 @@ func (s *NotifyService) sendNotifications(ctx context.Context, event entity.Even
 			continue
 		}
 
-		var messageKey string
-		switch notificationType {
-		case entity.NotificationTypeDay:
-			messageKey = "event_notification_day"
-		case entity.NotificationTypeHour:
-			messageKey = "event_notification_hour"
-		}
-
 		_, errSend := s.bot.Send(chat,
-			s.layout.TextLocale("ru", messageKey, event),
+			s.layout.TextLocale("ru", "event_notification_reminder", struct {
+				entity.Event
+				Offset entity.ReminderOffset
+				When   string
+			}{
+				Event:  event,
+				Offset: offset,
+				When:   s.layout.TextLocale("ru", "reminder_offset", offset),
+			}),
 			s.layout.MarkupLocale("ru", "core:hide"),
 		)
 		if errSend != nil {
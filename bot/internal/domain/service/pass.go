@@ -48,6 +48,7 @@ type PassService struct {
 	passRepo   secondary.PassRepository
 	eventRepo  secondary.EventRepository
 	userRepo   secondary.UserRepository
+	guestRepo  secondary.EventGuestRepository
 	clubRepo   secondary.ClubRepository
 	smtpClient secondary.SMTPClient
 
@@ -62,6 +63,7 @@ func NewPassService(
 	passRepo secondary.PassRepository,
 	eventRepo secondary.EventRepository,
 	userRepo secondary.UserRepository,
+	guestRepo secondary.EventGuestRepository,
 	clubRepo secondary.ClubRepository,
 	smtpClient secondary.SMTPClient,
 	passEmails []string,
@@ -73,6 +75,7 @@ func NewPassService(
 		passRepo:         passRepo,
 		eventRepo:        eventRepo,
 		userRepo:         userRepo,
+		guestRepo:        guestRepo,
 		clubRepo:         clubRepo,
 		smtpClient:       smtpClient,
 		cron:             cron.New(cron.WithLocation(location.Location())),
@@ -428,7 +431,7 @@ func (s *PassService) generateConsolidatedPassExcel(ctx context.Context, eventsW
 		return nil, fmt.Errorf("failed to set sheet name: %w", err)
 	}
 
-	headers := []string{"Событие", "Дата", "Время", "Место", "ФИО", "Роль"}
+	headers := []string{"Событие", "Дата", "Время", "Место", "ФИО", "Роль", "Примечание"}
 	for i, header := range headers {
 		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
 		if err := f.SetCellValue(sheetName, cell, header); err != nil {
@@ -441,9 +444,15 @@ func (s *PassService) generateConsolidatedPassExcel(ctx context.Context, eventsW
 		event := eventWithPasses.Event
 		passes := eventWithPasses.Passes
 
-		var userIDs []int64
+		var (
+			userIDs  []int64
+			guestIDs []string
+		)
 		for _, pass := range passes {
 			userIDs = append(userIDs, pass.UserID)
+			if pass.IsGuestPass() {
+				guestIDs = append(guestIDs, *pass.GuestID)
+			}
 		}
 
 		users, err := s.userRepo.GetMany(ctx, userIDs)
@@ -457,6 +466,17 @@ func (s *PassService) generateConsolidatedPassExcel(ctx context.Context, eventsW
 			userMap[user.ID] = user
 		}
 
+		guestMap := make(map[string]entity.EventGuest)
+		if len(guestIDs) > 0 {
+			guests, err := s.guestRepo.GetMany(ctx, guestIDs)
+			if err != nil {
+				s.logger.Error("Failed to get guests for Excel", "error", err)
+			}
+			for _, guest := range guests {
+				guestMap[guest.ID] = guest
+			}
+		}
+
 		for _, pass := range passes {
 			user, exists := userMap[pass.UserID]
 			if !exists {
@@ -470,6 +490,17 @@ func (s *PassService) generateConsolidatedPassExcel(ctx context.Context, eventsW
 				event.Location,
 				user.FIO.String(),
 				user.Role,
+				"",
+			}
+			// Гость попадает в список под своим именем с пометкой, чей он гость
+			if pass.IsGuestPass() {
+				guest, exists := guestMap[*pass.GuestID]
+				if !exists {
+					continue
+				}
+				data[4] = guest.Name
+				data[5] = ""
+				data[6] = "гость " + user.FIO.String()
 			}
 
 			for i, value := range data {
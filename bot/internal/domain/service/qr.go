@@ -118,32 +118,58 @@ func (s *QrService) GetEventQR(ctx context.Context, eventID string) (qr tele.Fil
 	}
 
 	qrCodeID := uuid.New().String()
-	link := fmt.Sprintf("https://t.me/%s?start=eventQR_%s", s.botName, qrCodeID)
-	cfg := s.qrCFG
-	cfg.Content = link
-	qrData, err := cfg.Generate()
+	fileID, err := s.uploadQR(fmt.Sprintf("https://t.me/%s?start=eventQR_%s", s.botName, qrCodeID))
 	if err != nil {
 		return qr, err
 	}
-
-	tempQR := tele.FromReader(bytes.NewReader(qrData))
-	qrMsg, err := s.bot.Send(s.qrChat, &tele.Photo{
-		File: tempQR,
-	})
+	event.QRFileID = fileID
+	event.QRCodeID = qrCodeID
+	_, err = s.eventService.Update(ctx, event)
 	if err != nil {
 		return qr, err
 	}
-	event.QRFileID = qrMsg.Photo.FileID
-	event.QRCodeID = qrCodeID
-	_, err = s.eventService.Update(ctx, event)
+
+	return s.bot.FileByID(fileID)
+}
+
+// GetEventExitQR returns the QR code that participants scan when they leave the event
+func (s *QrService) GetEventExitQR(ctx context.Context, eventID string) (qr tele.File, err error) {
+	event, err := s.eventService.Get(ctx, eventID)
 	if err != nil {
 		return qr, err
 	}
+	if event.ExitQRFileID != "" {
+		return s.bot.FileByID(event.ExitQRFileID)
+	}
 
-	qr, err = s.bot.FileByID(qrMsg.Photo.FileID)
+	qrCodeID := uuid.New().String()
+	fileID, err := s.uploadQR(fmt.Sprintf("https://t.me/%s?start=eventExitQR_%s", s.botName, qrCodeID))
 	if err != nil {
 		return qr, err
 	}
+	event.ExitQRFileID = fileID
+	event.ExitQRCodeID = qrCodeID
+	if _, err = s.eventService.Update(ctx, event); err != nil {
+		return qr, err
+	}
 
-	return qr, err
+	return s.bot.FileByID(fileID)
+}
+
+// uploadQR generates the QR code with the link and sends it to the qr chat to get its file id
+func (s *QrService) uploadQR(link string) (string, error) {
+	cfg := s.qrCFG
+	cfg.Content = link
+	qrData, err := cfg.Generate()
+	if err != nil {
+		return "", err
+	}
+
+	qrMsg, err := s.bot.Send(s.qrChat, &tele.Photo{
+		File: tele.FromReader(bytes.NewReader(qrData)),
+	})
+	if err != nil {
+		return "", err
+	}
+	return qrMsg.Photo.FileID, nil
 }
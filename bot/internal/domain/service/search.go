@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/dto"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/ports/secondary"
+)
+
+type SearchService struct {
+	repo secondary.SearchRepository
+}
+
+func NewSearchService(storage secondary.SearchRepository) *SearchService {
+	return &SearchService{
+		repo: storage,
+	}
+}
+
+func (s *SearchService) Search(ctx context.Context, query string, role entity.Role, limit, offset int) ([]dto.SearchResult, error) {
+	return s.repo.Search(ctx, strings.TrimSpace(query), string(role), limit, offset)
+}
+
+func (s *SearchService) Count(ctx context.Context, query string, role entity.Role) (int64, error) {
+	return s.repo.Count(ctx, strings.TrimSpace(query), string(role))
+}
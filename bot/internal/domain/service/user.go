@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
+	"time"
 
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/dto"
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
@@ -89,6 +90,30 @@ func (s *UserService) Ban(ctx context.Context, userID int64) (*entity.User, erro
 	return s.Update(ctx, user)
 }
 
+// ResetNoShows forgives the no-shows of the user, only events that end after the reset are counted
+func (s *UserService) ResetNoShows(ctx context.Context, userID int64) (*entity.User, error) {
+	user, err := s.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	user.NoShowsResetAt = &now
+	return s.Update(ctx, user)
+}
+
+// UpdateReminders saves the reminder settings of the user, empty offsets mean the reminders of events
+func (s *UserService) UpdateReminders(ctx context.Context, userID int64, muted bool, offsets entity.ReminderOffsets) (*entity.User, error) {
+	user, err := s.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user.RemindersMuted = muted
+	user.ReminderOffsets = offsets
+	return s.Update(ctx, user)
+}
+
 func (s *UserService) GetUsersByEventID(ctx context.Context, eventID string) ([]entity.User, error) {
 	return s.userRepo.GetUsersByEventID(ctx, eventID)
 }
@@ -101,8 +126,18 @@ func (s *UserService) GetUsersByClubID(ctx context.Context, clubID string) ([]en
 	return s.userRepo.GetUsersByClubID(ctx, clubID)
 }
 
+// GetUserEvents returns events the user is registered on with pagination, upcoming events that overlap
+// other registrations of the user are marked with HasConflict
 func (s *UserService) GetUserEvents(ctx context.Context, userID int64, limit, offset int) ([]dto.UserEvent, error) {
-	return s.eventParticipantRepo.GetUserEvents(ctx, userID, limit, offset)
+	events, err := s.eventParticipantRepo.GetUserEvents(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = markConflicts(ctx, s.eventParticipantRepo, userID, events); err != nil {
+		return nil, err
+	}
+	return events, nil
 }
 
 func (s *UserService) CountUserEvents(ctx context.Context, userID int64) (int64, error) {
@@ -150,6 +185,56 @@ func (s *UserService) ChangeRole(
 	return err
 }
 
+// calendarTokenLength is the length of the secret calendar feed token in hex characters
+const calendarTokenLength = 32
+
+// GetByCalendarToken returns the owner of the calendar feed token
+func (s *UserService) GetByCalendarToken(ctx context.Context, token string) (*entity.User, error) {
+	return s.userRepo.GetByCalendarToken(ctx, token)
+}
+
+// GetCalendarToken returns the calendar feed token of the user, the token is generated on the first request
+func (s *UserService) GetCalendarToken(ctx context.Context, userID int64) (string, error) {
+	user, err := s.Get(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if user.CalendarToken != "" {
+		return user.CalendarToken, nil
+	}
+
+	return s.setCalendarToken(ctx, user)
+}
+
+// RotateCalendarToken replaces the calendar feed token of the user, the previous feed URL stops working
+func (s *UserService) RotateCalendarToken(ctx context.Context, userID int64) (string, error) {
+	user, err := s.Get(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	return s.setCalendarToken(ctx, user)
+}
+
+func (s *UserService) setCalendarToken(ctx context.Context, user *entity.User) (string, error) {
+	token, err := generateRandomCode(calendarTokenLength)
+	if err != nil {
+		return "", err
+	}
+
+	user.CalendarToken = token
+	if _, err = s.Update(ctx, user); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// GetCalendarEvents returns upcoming events the user is registered on (approved registrations only),
+// cancelled events are kept so that subscribed calendars remove them
+func (s *UserService) GetCalendarEvents(ctx context.Context, userID int64) ([]entity.Event, error) {
+	return s.eventParticipantRepo.GetUpcomingUserEvents(ctx, userID, time.Now())
+}
+
 func generateAuthLink(codeLength int, botUserName string) (link string, code string, err error) {
 	code, err = generateRandomCode(codeLength)
 	if err != nil {
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/ports/secondary"
+)
+
+type VenueService struct {
+	repo secondary.VenueRepository
+}
+
+func NewVenueService(storage secondary.VenueRepository) *VenueService {
+	return &VenueService{
+		repo: storage,
+	}
+}
+
+func (s *VenueService) Create(ctx context.Context, venue *entity.Venue) (*entity.Venue, error) {
+	return s.repo.Create(ctx, venue)
+}
+
+func (s *VenueService) Get(ctx context.Context, id string) (*entity.Venue, error) {
+	return s.repo.Get(ctx, id)
+}
+
+func (s *VenueService) GetAll(ctx context.Context) ([]entity.Venue, error) {
+	return s.repo.GetAll(ctx)
+}
+
+func (s *VenueService) Update(ctx context.Context, venue *entity.Venue) (*entity.Venue, error) {
+	return s.repo.Update(ctx, venue)
+}
+
+func (s *VenueService) Delete(ctx context.Context, id string) error {
+	return s.repo.Delete(ctx, id)
+}
@@ -17,15 +17,61 @@ import (
 // classification. Additionally, reminders are added for one day and one hour before
 // the event. The function returns the serialized iCalendar data as a byte slice or
 // an error if serialization fails.
-func ExportEventToICS(event entity.Event) ([]byte, error) {
+//
+// If series is not nil, the event is exported as the whole recurring series:
+// one VEVENT starting at the first occurrence with an RRULE, occurrences that were
+// deleted from the series or cancelled are added as EXDATE.
+func ExportEventToICS(event entity.Event, series *entity.EventSeries) ([]byte, error) {
+	cal := newCalendar(ics.MethodPublish)
+	addEvent(cal, event, series)
+	return serialize(cal)
+}
+
+// ExportEventsToICS converts the events into a single iCalendar (.ics) feed named name.
+// Each event is added as a separate VEVENT with the same UID as ExportEventToICS,
+// occurrences of recurring events are added one by one without an RRULE.
+// The feed asks calendar applications to refresh it every hour, so subscribers
+// get new registrations, changes and cancellations without importing files by hand.
+func ExportEventsToICS(name string, events []entity.Event) ([]byte, error) {
+	cal := newCalendar(ics.MethodPublish)
+	cal.SetName(name)
+	cal.SetXWRCalName(name)
+	cal.SetRefreshInterval("PT1H")
+	cal.SetXPublishedTTL("PT1H")
+
+	for _, event := range events {
+		addEvent(cal, event, nil)
+	}
+
+	return serialize(cal)
+}
+
+func newCalendar(method ics.Method) *ics.Calendar {
 	cal := ics.NewCalendar()
-	cal.SetMethod(ics.MethodPublish)
+	cal.SetMethod(method)
 	cal.SetProductId("-//CU Clubs Bot//EN")
 	cal.SetVersion("2.0")
 	cal.SetCalscale("GREGORIAN")
+	return cal
+}
+
+func serialize(cal *ics.Calendar) ([]byte, error) {
+	var buf bytes.Buffer
+	err := cal.SerializeTo(&buf)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing calendar: %w", err)
+	}
 
+	return buf.Bytes(), nil
+}
+
+// addEvent adds the event (or the whole series if it is not nil) to the calendar
+func addEvent(cal *ics.Calendar, event entity.Event, series *entity.EventSeries) {
 	// Создаем уникальный идентификатор события
 	uid := fmt.Sprintf("%s@cu-clubs-bot", event.ID)
+	if series != nil {
+		uid = fmt.Sprintf("%s@cu-clubs-bot", series.ID)
+	}
 	e := cal.AddEvent(uid)
 
 	// Устанавливаем время создания и изменения события
@@ -33,24 +79,53 @@ func ExportEventToICS(event entity.Event) ([]byte, error) {
 	e.SetCreatedTime(event.CreatedAt)
 	e.SetModifiedAt(event.UpdatedAt)
 
-	// Устанавливаем время начала с указанием временной зоны
-	e.SetStartAt(event.StartTime)
-
-	// Установка времени окончания
+	// Длительность события, если время окончания не указано - 1 час
+	duration := 1 * time.Hour
 	if !event.EndTime.IsZero() {
-		e.SetEndAt(event.EndTime)
-	} else {
-		// Если время окончания не указано, устанавливаем продолжительность 1 час
-		e.SetEndAt(event.StartTime.Add(1 * time.Hour))
+		duration = event.EndTime.Sub(event.StartTime)
+	}
+
+	// Устанавливаем время начала с указанием временной зоны
+	startTime := event.StartTime
+	if series != nil {
+		startTime = series.StartTime
+	}
+	e.SetStartAt(startTime)
+	e.SetEndAt(startTime.Add(duration))
+
+	// Добавляем правило повторения и исключаем удаленные и отмененные события серии
+	if series != nil {
+		e.AddRrule(series.RRule())
+
+		existing := make(map[int64]struct{}, len(series.Events))
+		for _, occurrence := range series.Events {
+			if occurrence.IsCancelled() {
+				continue
+			}
+			existing[occurrence.StartTime.Unix()] = struct{}{}
+		}
+		for _, occurrence := range series.Occurrences() {
+			if _, ok := existing[occurrence.Unix()]; !ok {
+				e.AddExdate(occurrence.UTC().Format("20060102T150405Z"))
+			}
+		}
 	}
 
 	// Устанавливаем основные свойства события
 	e.SetSummary(event.Name)
 	e.SetDescription(event.Description)
 	e.SetLocation(event.Location)
+	// Ссылка на онлайн-встречу, файл получают только участники мероприятия
+	if event.MeetingURL != "" {
+		e.SetURL(event.MeetingURL)
+	}
 
-	// Добавляем статус события
-	e.SetStatus(ics.ObjectStatusConfirmed)
+	// Добавляем статус события, отмененные события остаются в подписке, чтобы календарь их убрал
+	if event.IsCancelled() {
+		e.SetStatus(ics.ObjectStatusCancelled)
+	} else {
+		e.SetStatus(ics.ObjectStatusConfirmed)
+	}
 
 	// Добавляем прозрачность (показывает, занято ли время в календаре)
 	e.SetTimeTransparency(ics.TransparencyOpaque)
@@ -58,8 +133,9 @@ func ExportEventToICS(event entity.Event) ([]byte, error) {
 	// Добавляем класс доступности (публичное)
 	e.SetClass(ics.ClassificationPublic)
 
-	// Добавляем последовательность (для синхронизации)
-	e.SetSequence(0)
+	// Добавляем последовательность (для синхронизации): календари заменяют уже импортированное
+	// событие, только если SEQUENCE выросла, поэтому берём время последнего изменения
+	e.SetSequence(int(event.UpdatedAt.Unix()))
 
 	// Добавляем напоминание за день до события
 	dayAlarm := e.AddAlarm()
@@ -72,12 +148,57 @@ func ExportEventToICS(event entity.Event) ([]byte, error) {
 	hourAlarm.SetAction(ics.ActionDisplay)
 	hourAlarm.AddProperty("TRIGGER;VALUE=DURATION", "-PT1H")
 	hourAlarm.SetDescription(fmt.Sprintf("Напоминание: %s (через час)", event.Name))
+}
 
-	var buf bytes.Buffer
-	err := cal.SerializeTo(&buf)
-	if err != nil {
-		return nil, fmt.Errorf("error serializing calendar: %w", err)
+// ExportEventUpdateToICS converts the changed event into an iCalendar (.ics) format.
+// It is the same as ExportEventToICS, but an occurrence of a recurring event is exported
+// with the UID of its series and RECURRENCE-ID set to the start time before the change (previous),
+// so calendar applications move the imported occurrence instead of adding a new event.
+func ExportEventUpdateToICS(event entity.Event, previous entity.Event) ([]byte, error) {
+	cal := newCalendar(ics.MethodPublish)
+	addEvent(cal, event, nil)
+	if previous.IsRecurring() {
+		e := cal.Events()[0]
+		e.SetProperty(ics.ComponentPropertyUniqueId, fmt.Sprintf("%s@cu-clubs-bot", *previous.SeriesID))
+		e.SetProperty(ics.ComponentPropertyRecurrenceId, previous.StartTime.UTC().Format("20060102T150405Z"))
 	}
+	return serialize(cal)
+}
 
-	return buf.Bytes(), nil
+// ExportEventCancellationToICS creates an iCalendar (.ics) cancellation for the event.
+// The calendar has METHOD:CANCEL and the same UID as ExportEventToICS,
+// so calendar applications remove the previously imported event.
+// For an occurrence of a recurring event only this occurrence is cancelled (RECURRENCE-ID).
+func ExportEventCancellationToICS(event entity.Event) ([]byte, error) {
+	cal := newCalendar(ics.MethodCancel)
+
+	uid := fmt.Sprintf("%s@cu-clubs-bot", event.ID)
+	if event.IsRecurring() {
+		uid = fmt.Sprintf("%s@cu-clubs-bot", *event.SeriesID)
+	}
+	e := cal.AddEvent(uid)
+
+	e.SetDtStampTime(time.Now())
+	e.SetModifiedAt(event.UpdatedAt)
+	if event.IsRecurring() {
+		e.SetProperty(ics.ComponentPropertyRecurrenceId, event.StartTime.UTC().Format("20060102T150405Z"))
+	}
+
+	duration := 1 * time.Hour
+	if !event.EndTime.IsZero() {
+		duration = event.EndTime.Sub(event.StartTime)
+	}
+	e.SetStartAt(event.StartTime)
+	e.SetEndAt(event.StartTime.Add(duration))
+
+	e.SetSummary(event.Name)
+	e.SetLocation(event.Location)
+	if event.CancelReason != "" {
+		e.SetDescription(event.CancelReason)
+	}
+
+	e.SetStatus(ics.ObjectStatusCancelled)
+	e.SetSequence(int(event.UpdatedAt.Unix()))
+
+	return serialize(cal)
 }
@@ -0,0 +1,85 @@
+package deadline
+
+import (
+	"time"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/location"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/valueobject"
+)
+
+// Cutoff is the registration end relative to the event start: DaysBefore days before the start at Hour:Minute
+type Cutoff struct {
+	DaysBefore int
+	Hour       int
+	Minute     int
+}
+
+// At returns the cutoff time for the event starting at start
+func (c Cutoff) At(start time.Time) time.Time {
+	day := start.In(location.Location()).AddDate(0, 0, -c.DaysBefore)
+	return time.Date(day.Year(), day.Month(), day.Day(), c.Hour, c.Minute, 0, 0, location.Location())
+}
+
+// Rule describes when the registration ends for a role
+type Rule struct {
+	// RegistrationEnd - users register until the RegistrationEnd of the event, the cutoffs are ignored
+	RegistrationEnd bool
+	Cutoff          Cutoff
+	// Weekdays - cutoffs for events starting on the given weekday, Cutoff is used for the other days
+	Weekdays map[time.Weekday]Cutoff
+}
+
+// Deadline returns the registration end of the event for the rule, it is never later than registrationEnd
+func (r Rule) Deadline(start, registrationEnd time.Time) time.Time {
+	if r.RegistrationEnd {
+		return registrationEnd
+	}
+
+	cutoff, ok := r.Weekdays[start.In(location.Location()).Weekday()]
+	if !ok {
+		cutoff = r.Cutoff
+	}
+
+	if at := cutoff.At(start); at.Before(registrationEnd) {
+		return at
+	}
+	return registrationEnd
+}
+
+// Policy maps roles to their deadline rules
+type Policy struct {
+	Roles map[valueobject.Role]Rule
+	// Default - the rule for roles that are not listed in Roles and for guests
+	Default Rule
+}
+
+// Rule returns the rule of the role
+func (p Policy) Rule(role valueobject.Role) Rule {
+	if rule, ok := p.Roles[role]; ok {
+		return rule
+	}
+	return p.Default
+}
+
+// GuestRule returns the rule for guests, they have no role so the default rule is used
+func (p Policy) GuestRule() Rule {
+	return p.Default
+}
+
+// DefaultPolicy is used when the policy is not configured:
+// students register until the registration end, other roles need a pass, so their registration ends
+// on the previous day at 16:00, or on Saturday at 12:00 for events on Sunday and Monday
+func DefaultPolicy() Policy {
+	return Policy{
+		Roles: map[valueobject.Role]Rule{
+			valueobject.Student: {RegistrationEnd: true},
+		},
+		Default: Rule{
+			Cutoff: Cutoff{DaysBefore: 1, Hour: 16},
+			Weekdays: map[time.Weekday]Cutoff{
+				time.Sunday: {DaysBefore: 1, Hour: 12},
+				time.Monday: {DaysBefore: 2, Hour: 12},
+			},
+		},
+	}
+}
@@ -0,0 +1,125 @@
+package export
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/dto"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/location"
+)
+
+// UsersToXLSX builds the spreadsheet with the participants of the event: visits, check-in/out times
+// and answers to the registration form, guests and the waitlist go to separate sheets
+func UsersToXLSX(users []dto.EventUser, waitlist []entity.EventWaitlist, guests []entity.EventGuest, questions []entity.EventQuestion, answers []entity.EventAnswer) (*bytes.Buffer, error) {
+	f := excelize.NewFile()
+
+	// Ответы на вопросы регистрационной формы идут отдельными колонками после основных
+	userAnswers := make(map[int64]map[string]string)
+	for _, answer := range answers {
+		if userAnswers[answer.UserID] == nil {
+			userAnswers[answer.UserID] = make(map[string]string)
+		}
+		userAnswers[answer.UserID][answer.QuestionID] = answer.Answer
+	}
+	answerNames := map[string]string{
+		entity.AnswerYes: "Да",
+		entity.AnswerNo:  "Нет",
+	}
+	setAnswers := func(sheet string, firstCol, row int, userID int64) {
+		for i, question := range questions {
+			cell, _ := excelize.CoordinatesToCellName(firstCol+i, row)
+			if row == 1 {
+				_ = f.SetCellValue(sheet, cell, question.Text)
+				continue
+			}
+			answer := userAnswers[userID][question.ID]
+			if question.Type == entity.EventQuestionTypeYesNo {
+				answer = answerNames[answer]
+			}
+			_ = f.SetCellValue(sheet, cell, answer)
+		}
+	}
+
+	sheet := "Sheet1"
+	_ = f.SetCellValue(sheet, "A1", "ID")
+	_ = f.SetCellValue(sheet, "B1", "Фамилия")
+	_ = f.SetCellValue(sheet, "C1", "Имя")
+	_ = f.SetCellValue(sheet, "D1", "Username")
+	_ = f.SetCellValue(sheet, "E1", "Посетил")
+	_ = f.SetCellValue(sheet, "F1", "Вход")
+	_ = f.SetCellValue(sheet, "G1", "Выход")
+	_ = f.SetCellValue(sheet, "H1", "Длительность, мин")
+	setAnswers(sheet, 9, 1, 0)
+
+	for i, user := range users {
+		fio := strings.Split(user.User.FIO.String(), " ")
+
+		row := i + 2
+		_ = f.SetCellValue(sheet, "A"+strconv.Itoa(row), user.User.ID)
+		_ = f.SetCellValue(sheet, "B"+strconv.Itoa(row), fio[0])
+		_ = f.SetCellValue(sheet, "C"+strconv.Itoa(row), fio[1])
+		_ = f.SetCellValue(sheet, "D"+strconv.Itoa(row), user.User.Username)
+		_ = f.SetCellValue(sheet, "e"+strconv.Itoa(row), user.UserVisit)
+		if user.CheckedInAt != nil {
+			_ = f.SetCellValue(sheet, "F"+strconv.Itoa(row), user.CheckedInAt.In(location.Location()).Format("02.01.2006 15:04"))
+		}
+		if user.CheckedOutAt != nil {
+			_ = f.SetCellValue(sheet, "G"+strconv.Itoa(row), user.CheckedOutAt.In(location.Location()).Format("02.01.2006 15:04"))
+			_ = f.SetCellValue(sheet, "H"+strconv.Itoa(row), int(user.AttendanceDuration().Minutes()))
+		}
+		setAnswers(sheet, 9, row, user.User.ID)
+	}
+
+	if len(guests) > 0 {
+		guestsSheet := "Гости"
+		_, _ = f.NewSheet(guestsSheet)
+		_ = f.SetCellValue(guestsSheet, "A1", "Имя гостя")
+		_ = f.SetCellValue(guestsSheet, "B1", "Email")
+		_ = f.SetCellValue(guestsSheet, "C1", "Пригласил")
+		_ = f.SetCellValue(guestsSheet, "D1", "ID пригласившего")
+
+		for i, guest := range guests {
+			row := i + 2
+			_ = f.SetCellValue(guestsSheet, "A"+strconv.Itoa(row), guest.Name)
+			_ = f.SetCellValue(guestsSheet, "B"+strconv.Itoa(row), guest.Email)
+			_ = f.SetCellValue(guestsSheet, "C"+strconv.Itoa(row), guest.User.FIO.String())
+			_ = f.SetCellValue(guestsSheet, "D"+strconv.Itoa(row), guest.UserID)
+		}
+	}
+
+	if len(waitlist) > 0 {
+		waitlistSheet := "Лист ожидания"
+		_, _ = f.NewSheet(waitlistSheet)
+		_ = f.SetCellValue(waitlistSheet, "A1", "Место")
+		_ = f.SetCellValue(waitlistSheet, "B1", "ID")
+		_ = f.SetCellValue(waitlistSheet, "C1", "Фамилия")
+		_ = f.SetCellValue(waitlistSheet, "D1", "Имя")
+		_ = f.SetCellValue(waitlistSheet, "E1", "Username")
+		_ = f.SetCellValue(waitlistSheet, "F1", "Дата записи")
+		setAnswers(waitlistSheet, 7, 1, 0)
+
+		for i, entry := range waitlist {
+			fio := strings.Split(entry.User.FIO.String(), " ")
+
+			row := i + 2
+			_ = f.SetCellValue(waitlistSheet, "A"+strconv.Itoa(row), i+1)
+			_ = f.SetCellValue(waitlistSheet, "B"+strconv.Itoa(row), entry.User.ID)
+			_ = f.SetCellValue(waitlistSheet, "C"+strconv.Itoa(row), fio[0])
+			_ = f.SetCellValue(waitlistSheet, "D"+strconv.Itoa(row), fio[1])
+			_ = f.SetCellValue(waitlistSheet, "E"+strconv.Itoa(row), entry.User.Username)
+			_ = f.SetCellValue(waitlistSheet, "F"+strconv.Itoa(row), entry.CreatedAt.In(location.Location()).Format("02.01.2006 15:04"))
+			setAnswers(waitlistSheet, 7, row, entry.User.ID)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := f.Write(&buf); err != nil {
+		return nil, err
+	}
+
+	return &buf, nil
+}
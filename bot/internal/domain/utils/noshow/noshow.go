@@ -0,0 +1,56 @@
+package noshow
+
+import (
+	"time"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/dto"
+)
+
+// Policy restricts users who don't come to the events they registered for.
+// A no-show is an approved registration for an ended offline event where the user was never checked in
+type Policy struct {
+	// Limit - the number of no-shows within Period after which the user is restricted, 0 disables the policy
+	Limit  int
+	Period time.Duration
+	// Block - how long the user can't register or join waitlists after the last no-show, 0 - registration is not blocked
+	Block time.Duration
+	// WaitlistLast - free seats are offered to restricted users only when nobody else is waiting
+	WaitlistLast bool
+}
+
+// IsEnabled checks if no-shows are tracked
+func (p Policy) IsEnabled() bool {
+	return p.Limit > 0
+}
+
+// Standing returns the standing of the user with the given no-shows, they must be ended within Period before now
+func (p Policy) Standing(noShows []time.Time, now time.Time) dto.NoShowStanding {
+	standing := dto.NoShowStanding{
+		NoShows:      len(noShows),
+		Limit:        p.Limit,
+		PeriodDays:   int(p.Period.Hours() / 24),
+		WaitlistLast: p.WaitlistLast,
+	}
+	if !p.IsEnabled() || len(noShows) < p.Limit {
+		return standing
+	}
+
+	standing.Restricted = true
+	if p.Block > 0 {
+		last := noShows[0]
+		for _, noShow := range noShows[1:] {
+			if noShow.After(last) {
+				last = noShow
+			}
+		}
+		if blockedUntil := last.Add(p.Block); blockedUntil.After(now) {
+			standing.BlockedUntil = blockedUntil
+		}
+	}
+	return standing
+}
+
+// DefaultPolicy is used when the policy is not configured, no-shows are not tracked
+func DefaultPolicy() Policy {
+	return Policy{}
+}
@@ -2,11 +2,8 @@ package utils
 
 import (
 	"slices"
-	"time"
 
 	tele "gopkg.in/telebot.v3"
-
-	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/utils/location"
 )
 
 func IsAdmin(userID int64, adminIDs []int64) bool {
@@ -43,13 +40,3 @@ func GetMessageText(msg *tele.Message) string {
 		return ""
 	}
 }
-
-func GetMaxRegisteredEndTime(startTime time.Time) time.Time {
-	if startTime.Weekday() == time.Sunday {
-		return time.Date(startTime.Year(), startTime.Month(), startTime.Day()-1, 12, 0, 0, 0, location.Location())
-	} else if startTime.Weekday() == time.Monday {
-		return time.Date(startTime.Year(), startTime.Month(), startTime.Day()-2, 12, 0, 0, 0, location.Location())
-	}
-
-	return time.Date(startTime.Year(), startTime.Month(), startTime.Day(), 0, 0, 0, 0, startTime.Location()).Add(-24 * time.Hour).Add(16 * time.Hour)
-}
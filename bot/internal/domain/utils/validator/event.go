@@ -1,7 +1,9 @@
 package validator
 
 import (
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
 	"unicode/utf8"
 
@@ -20,6 +22,16 @@ func EventLocation(location string, _ map[string]interface{}) bool {
 	return utf8.RuneCountInString(location) >= 5 && utf8.RuneCountInString(location) <= 75
 }
 
+// EventMeetingURL checks that the meeting link is an absolute http(s) url
+func EventMeetingURL(link string, _ map[string]interface{}) bool {
+	u, err := url.ParseRequestURI(link)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return false
+	}
+
+	return utf8.RuneCountInString(link) <= 250
+}
+
 func EventStartTime(start string, _ map[string]interface{}) bool {
 	const layout = "02.01.2006 15:04"
 
@@ -80,15 +92,64 @@ func EventRegisteredEndTime(registeredEnd string, params map[string]interface{})
 	return registeredEndTime.After(now.Add(time.Hour))
 }
 
+func EventCancelReason(reason string, _ map[string]interface{}) bool {
+	return utf8.RuneCountInString(reason) >= 5 && utf8.RuneCountInString(reason) <= 250
+}
+
+// EventPublishTime checks that the draft is published in the future and before its registration ends
+func EventPublishTime(publish string, params map[string]interface{}) bool {
+	const layout = "02.01.2006 15:04"
+
+	registrationEndStr, ok := params["registrationEnd"].(string)
+	if !ok {
+		return false
+	}
+	registrationEnd, _ := time.ParseInLocation(layout, registrationEndStr, location.Location())
+
+	publishTime, err := time.ParseInLocation(layout, publish, location.Location())
+	if err != nil {
+		return false
+	}
+
+	return publishTime.After(time.Now()) && publishTime.Before(registrationEnd)
+}
+
+// EventRoleDeadline checks that the registration deadline of a role is in the future and not after the registration end
+func EventRoleDeadline(deadline string, params map[string]interface{}) bool {
+	const layout = "02.01.2006 15:04"
+
+	registrationEndStr, ok := params["registrationEnd"].(string)
+	if !ok {
+		return false
+	}
+	registrationEnd, _ := time.ParseInLocation(layout, registrationEndStr, location.Location())
+
+	deadlineTime, err := time.ParseInLocation(layout, deadline, location.Location())
+	if err != nil {
+		return false
+	}
+
+	return deadlineTime.After(time.Now()) && !deadlineTime.After(registrationEnd)
+}
+
+func ApprovalRejectReason(reason string, _ map[string]interface{}) bool {
+	return utf8.RuneCountInString(reason) >= 5 && utf8.RuneCountInString(reason) <= 250
+}
+
 func EventAfterRegistrationText(afterRegistrationText string, _ map[string]interface{}) bool {
 	return utf8.RuneCountInString(afterRegistrationText) >= 10 && utf8.RuneCountInString(afterRegistrationText) <= 150
 }
 
-func EventMaxParticipants(maxParticipantsStr string, _ map[string]interface{}) bool {
+// EventMaxParticipants checks the max participants of a new event, if the venue capacity is passed in params,
+// the limit is required and can't exceed the capacity
+func EventMaxParticipants(maxParticipantsStr string, params map[string]interface{}) bool {
 	maxParticipants, err := strconv.Atoi(maxParticipantsStr)
 	if err != nil {
 		return false
 	}
+	if capacity, ok := params["capacity"].(int); ok && capacity > 0 {
+		return maxParticipants > 0 && maxParticipants <= capacity
+	}
 	return maxParticipants >= 0
 }
 
@@ -114,3 +175,104 @@ func EventEditMaxParticipants(maxParticipantsStr string, params map[string]inter
 	}
 	return maxParticipants > 0 && maxParticipants > previousMaxParticipants
 }
+
+// EventRecurrenceEnd checks the end of the recurring series: either the date of the last event
+// (after the start date) or the number of events in the series (from 2 to maxCount)
+// EventRoleQuota checks the quota of a role: 0 removes the quota, otherwise it can't be less than the seats
+// already taken by the role and more than the max participants of the event
+func EventRoleQuota(quotaStr string, params map[string]interface{}) bool {
+	taken, ok := params["taken"].(int)
+	if !ok {
+		return false
+	}
+	maxParticipants, ok := params["maxParticipants"].(int)
+	if !ok {
+		return false
+	}
+
+	quota, err := strconv.Atoi(quotaStr)
+	if err != nil || quota < 0 {
+		return false
+	}
+	if quota == 0 {
+		return true
+	}
+	return quota >= taken && (maxParticipants == 0 || quota <= maxParticipants)
+}
+
+func EventRecurrenceEnd(end string, params map[string]interface{}) bool {
+	if count, err := strconv.Atoi(end); err == nil {
+		maxCount, ok := params["maxCount"].(int)
+		if !ok {
+			return false
+		}
+		return count >= 2 && count <= maxCount
+	}
+
+	startTime, ok := params["startTime"].(time.Time)
+	if !ok {
+		return false
+	}
+
+	untilDate, err := time.ParseInLocation("02.01.2006", end, location.Location())
+	if err != nil {
+		return false
+	}
+
+	startDate := startTime.In(location.Location())
+	startDate = time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, location.Location())
+
+	return untilDate.After(startDate)
+}
+
+func FeedbackComment(comment string, _ map[string]interface{}) bool {
+	comment = strings.TrimSpace(comment)
+	return utf8.RuneCountInString(comment) >= 1 && utf8.RuneCountInString(comment) <= 1000
+}
+
+func EventQuestionText(text string, _ map[string]interface{}) bool {
+	text = strings.TrimSpace(text)
+	return utf8.RuneCountInString(text) >= 1 && utf8.RuneCountInString(text) <= 200
+}
+
+// EventQuestionOptions checks the options of the choice question, one option per line (from 2 to 10 options)
+func EventQuestionOptions(options string, _ map[string]interface{}) bool {
+	lines := strings.Split(strings.TrimSpace(options), "\n")
+	if len(lines) < 2 || len(lines) > 10 {
+		return false
+	}
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if utf8.RuneCountInString(line) < 1 || utf8.RuneCountInString(line) > 50 {
+			return false
+		}
+	}
+	return true
+}
+
+func EventAnswer(answer string, _ map[string]interface{}) bool {
+	answer = strings.TrimSpace(answer)
+	return utf8.RuneCountInString(answer) >= 1 && utf8.RuneCountInString(answer) <= 500
+}
+
+func GuestName(name string, _ map[string]interface{}) bool {
+	name = strings.TrimSpace(name)
+	return utf8.RuneCountInString(name) >= 2 && utf8.RuneCountInString(name) <= 100
+}
+
+func GuestEmail(email string, _ map[string]interface{}) bool {
+	return emailFormat(strings.TrimSpace(email))
+}
+
+// EventMaxGuests checks the number of guests a participant can bring (from 0 to maxGuests)
+func EventMaxGuests(maxGuestsStr string, params map[string]interface{}) bool {
+	limit, ok := params["maxGuests"].(int)
+	if !ok {
+		return false
+	}
+	maxGuests, err := strconv.Atoi(maxGuestsStr)
+	if err != nil {
+		return false
+	}
+	return maxGuests >= 0 && maxGuests <= limit
+}
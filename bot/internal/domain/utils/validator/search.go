@@ -0,0 +1,11 @@
+package validator
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+func SearchQuery(query string, _ map[string]interface{}) bool {
+	query = strings.TrimSpace(query)
+	return utf8.RuneCountInString(query) >= 2 && utf8.RuneCountInString(query) <= 100
+}
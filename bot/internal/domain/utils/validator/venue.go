@@ -0,0 +1,23 @@
+package validator
+
+import (
+	"strconv"
+	"unicode/utf8"
+)
+
+func VenueName(name string, _ map[string]interface{}) bool {
+	return utf8.RuneCountInString(name) >= 3 && utf8.RuneCountInString(name) <= 40
+}
+
+func VenueBuilding(building string, _ map[string]interface{}) bool {
+	return utf8.RuneCountInString(building) >= 2 && utf8.RuneCountInString(building) <= 30
+}
+
+// VenueCapacity checks the capacity of the venue, 0 means that the capacity is not limited
+func VenueCapacity(capacityStr string, _ map[string]interface{}) bool {
+	capacity, err := strconv.Atoi(capacityStr)
+	if err != nil {
+		return false
+	}
+	return capacity >= 0 && capacity <= 10000
+}
@@ -0,0 +1,6 @@
+package primary
+
+// AttendanceReportService defines the interface for sending attendance reports of ended events to club owners
+type AttendanceReportService interface {
+	StartReportScheduler()
+}
@@ -0,0 +1,16 @@
+package primary
+
+import (
+	"context"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+)
+
+// EventCoHostService defines the interface for co-hosting events by several clubs
+type EventCoHostService interface {
+	Invite(ctx context.Context, eventID, clubID string) (*entity.EventCoHost, error)
+	Accept(ctx context.Context, eventID, clubID string) (*entity.EventCoHost, error)
+	Remove(ctx context.Context, eventID, clubID string) error
+	Get(ctx context.Context, eventID, clubID string) (*entity.EventCoHost, error)
+	GetByEventID(ctx context.Context, eventID string) ([]entity.EventCoHost, error)
+}
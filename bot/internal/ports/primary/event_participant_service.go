@@ -9,7 +9,11 @@ import (
 
 // EventParticipantService defines the interface for event participant-related use cases
 type EventParticipantService interface {
-	Register(ctx context.Context, eventID string, userID int64) (*entity.EventParticipant, error)
+	Register(ctx context.Context, eventID string, userID int64, answers []entity.EventAnswer) (*entity.EventParticipant, error)
+	RegisterOnSite(ctx context.Context, eventID string, userID int64) (*entity.EventParticipant, error)
+	Approve(ctx context.Context, eventID string, userID int64) (*entity.EventParticipant, error)
+	Reject(ctx context.Context, eventID string, userID int64, reason string) error
+	SyncPasses(ctx context.Context, eventID string) error
 	Get(ctx context.Context, eventID string, userID int64) (*entity.EventParticipant, error)
 	Update(ctx context.Context, eventParticipant *entity.EventParticipant) (*entity.EventParticipant, error)
 	Delete(ctx context.Context, eventID string, userID int64) error
@@ -18,9 +22,26 @@ type EventParticipantService interface {
 	CountVisitedByEventID(ctx context.Context, eventID string) (int, error)
 	GetUserEvents(ctx context.Context, userID int64, limit, offset int) ([]dto.UserEvent, error)
 	CountUserEvents(ctx context.Context, userID int64) (int64, error)
+	GetConflicts(ctx context.Context, eventID string, userID int64) ([]dto.UserEvent, error)
 	MarkAsVisited(ctx context.Context, eventID string, userID int64, isUserQR, isEventQR bool) error
 	IsUserRegistered(ctx context.Context, eventID string, userID int64) (bool, error)
+	GetQuestions(ctx context.Context, eventID string) ([]entity.EventQuestion, error)
+	GetAnswersByEventID(ctx context.Context, eventID string) ([]entity.EventAnswer, error)
+	AddGuest(ctx context.Context, eventID string, userID int64, name, email string) (*entity.EventGuest, error)
+	RemoveGuest(ctx context.Context, eventID string, userID int64, guestID string) error
+	GetGuests(ctx context.Context, eventID string, userID int64) ([]entity.EventGuest, error)
+	GetGuestsByEventID(ctx context.Context, eventID string) ([]entity.EventGuest, error)
 	BulkRegister(ctx context.Context, eventID string, userIDs []int64) ([]entity.EventParticipant, error)
 	GetVisitedParticipants(ctx context.Context, eventID string) ([]entity.EventParticipant, error)
 	GetNotVisitedParticipants(ctx context.Context, eventID string) ([]entity.EventParticipant, error)
+	GetNoShowStanding(ctx context.Context, userID int64) (dto.NoShowStanding, error)
+	JoinWaitlist(ctx context.Context, eventID string, userID int64, answers []entity.EventAnswer) (*entity.EventWaitlist, error)
+	LeaveWaitlist(ctx context.Context, eventID string, userID int64) error
+	ConfirmWaitlistOffer(ctx context.Context, eventID string, userID int64) (*entity.EventParticipant, error)
+	GetWaitlistPosition(ctx context.Context, eventID string, userID int64) (int, error)
+	GetWaitlist(ctx context.Context, eventID string) ([]entity.EventWaitlist, error)
+	CountWaitlistByEventID(ctx context.Context, eventID string) (int, error)
+	CountOfferedSeats(ctx context.Context, eventID string) (int, error)
+	GetSeatsByRole(ctx context.Context, eventID string) ([]dto.RoleSeats, error)
+	StartWaitlistScheduler()
 }
@@ -0,0 +1,15 @@
+package primary
+
+import (
+	"context"
+	"time"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+)
+
+// EventPublishService defines the interface for publishing event drafts
+type EventPublishService interface {
+	Publish(ctx context.Context, eventID string) (*entity.Event, error)
+	Schedule(ctx context.Context, eventID string, publishAt *time.Time, announce bool) (*entity.Event, error)
+	StartPublishScheduler()
+}
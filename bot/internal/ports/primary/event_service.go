@@ -12,15 +12,26 @@ import (
 // EventService defines the interface for event-related use cases
 type EventService interface {
 	Create(ctx context.Context, event *entity.Event) (*entity.Event, error)
+	CreateSeries(ctx context.Context, event entity.Event, series *entity.EventSeries) (*entity.EventSeries, error)
+	GetSeries(ctx context.Context, id string) (*entity.EventSeries, error)
+	RescheduleSeries(ctx context.Context, seriesID string, from, startTime time.Time, eventIDs []string) (*entity.EventSeries, error)
+	GetFutureBySeriesID(ctx context.Context, seriesID string, from time.Time) ([]entity.Event, error)
+	GetVenueBookings(ctx context.Context, event entity.Event) ([]entity.Event, error)
 	Get(ctx context.Context, id string) (*entity.Event, error)
 	GetByQRCodeID(ctx context.Context, qrCodeID string) (*entity.Event, error)
+	GetByExitQRCodeID(ctx context.Context, qrCodeID string) (*entity.Event, error)
 	GetMany(ctx context.Context, ids []string) ([]entity.Event, error)
 	GetAll(ctx context.Context) ([]entity.Event, error)
 	GetByClubID(ctx context.Context, limit, offset int, clubID string) ([]entity.Event, error)
 	CountByClubID(ctx context.Context, clubID string) (int64, error)
 	GetFutureByClubID(ctx context.Context, limit, offset int, order string, clubID string, additionalTime time.Duration) ([]entity.Event, error)
 	Update(ctx context.Context, event *entity.Event) (*entity.Event, error)
+	RegistrationDeadline(event *entity.Event, role valueobject.Role) time.Time
+	IsRegistrationOpen(event *entity.Event, role valueobject.Role, now time.Time) bool
+	Cancel(ctx context.Context, id string, reason string) (*entity.Event, error)
 	Delete(ctx context.Context, id string) error
-	Count(ctx context.Context, role valueobject.Role) (int64, error)
-	GetWithPagination(ctx context.Context, limit, offset int, order string, role valueobject.Role, userID int64) ([]dto.Event, error)
+	Count(ctx context.Context, role valueobject.Role, filter dto.EventFilter) (int64, error)
+	CountByFilters(ctx context.Context, role valueobject.Role, filter dto.EventFilter) (dto.EventFilterCounts, error)
+	GetWithPagination(ctx context.Context, limit, offset int, order string, role valueobject.Role, userID int64, filter dto.EventFilter) ([]dto.Event, error)
+	GetPublic(ctx context.Context, role valueobject.Role, filter dto.EventFilter) ([]entity.Event, error)
 }
@@ -0,0 +1,18 @@
+package primary
+
+import (
+	"context"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/dto"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+)
+
+// FeedbackService defines the interface for collecting feedback on ended events
+type FeedbackService interface {
+	Rate(ctx context.Context, eventID string, userID int64, rating int) (*entity.EventFeedback, error)
+	Comment(ctx context.Context, eventID string, userID int64, comment string) (*entity.EventFeedback, error)
+	GetByEventID(ctx context.Context, eventID string) ([]entity.EventFeedback, error)
+	GetEventSummary(ctx context.Context, eventID string) (dto.FeedbackSummary, error)
+	GetClubSummary(ctx context.Context, clubID string) (dto.FeedbackSummary, error)
+	StartFeedbackScheduler()
+}
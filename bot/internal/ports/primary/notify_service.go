@@ -1,8 +1,11 @@
 package primary
 
 import (
+	"time"
+
 	"go.uber.org/zap/zapcore"
 
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
 	"github.com/Badsnus/cu-clubs-bot/bot/pkg/logger/types"
 )
 
@@ -11,5 +14,8 @@ type NotifyService interface {
 	LogHook(channelID int64, locale string, level zapcore.Level) (types.LogHook, error)
 	SendClubWarning(clubID string, what interface{}, opts ...interface{}) error
 	SendEventUpdate(eventID string, what interface{}, opts ...interface{}) error
+	SendWaitlistOffer(userID int64, event entity.Event, expiresAt time.Time) error
+	SendApprovalRequest(event entity.Event, user entity.User) error
+	SendApprovalResult(userID int64, event entity.Event, approved bool, reason string) error
 	StartNotifyScheduler()
 }
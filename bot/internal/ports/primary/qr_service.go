@@ -11,4 +11,5 @@ type QrService interface {
 	GetUserQR(ctx context.Context, userID int64) (qr tele.File, err error)
 	RevokeUserQR(ctx context.Context, userID int64) error
 	GetEventQR(ctx context.Context, eventID string) (qr tele.File, err error)
+	GetEventExitQR(ctx context.Context, eventID string) (qr tele.File, err error)
 }
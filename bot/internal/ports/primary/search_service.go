@@ -0,0 +1,14 @@
+package primary
+
+import (
+	"context"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/dto"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/valueobject"
+)
+
+// SearchService defines the interface for search use cases
+type SearchService interface {
+	Search(ctx context.Context, query string, role valueobject.Role, limit, offset int) ([]dto.SearchResult, error)
+	Count(ctx context.Context, query string, role valueobject.Role) (int64, error)
+}
@@ -22,6 +22,8 @@ type UserService interface {
 	Count(ctx context.Context) (int64, error)
 	GetWithPagination(ctx context.Context, limit int, offset int, order string) ([]entity.User, error)
 	Ban(ctx context.Context, userID int64) (*entity.User, error)
+	ResetNoShows(ctx context.Context, userID int64) (*entity.User, error)
+	UpdateReminders(ctx context.Context, userID int64, muted bool, offsets entity.ReminderOffsets) (*entity.User, error)
 	GetUsersByEventID(ctx context.Context, eventID string) ([]entity.User, error)
 	GetEventUsers(ctx context.Context, eventID string) ([]dto.EventUser, error)
 	GetUsersByClubID(ctx context.Context, clubID string) ([]entity.User, error)
@@ -30,4 +32,8 @@ type UserService interface {
 	SendAuthCode(ctx context.Context, email valueobject.Email, botUserName string) (string, error)
 	IgnoreMailing(ctx context.Context, userID int64, clubID string) (bool, error)
 	ChangeRole(ctx context.Context, userID int64, role valueobject.Role, email valueobject.Email) error
+	GetByCalendarToken(ctx context.Context, token string) (*entity.User, error)
+	GetCalendarToken(ctx context.Context, userID int64) (string, error)
+	RotateCalendarToken(ctx context.Context, userID int64) (string, error)
+	GetCalendarEvents(ctx context.Context, userID int64) ([]entity.Event, error)
 }
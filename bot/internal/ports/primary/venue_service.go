@@ -0,0 +1,16 @@
+package primary
+
+import (
+	"context"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+)
+
+// VenueService defines the interface for managing the venue catalogue
+type VenueService interface {
+	Create(ctx context.Context, venue *entity.Venue) (*entity.Venue, error)
+	Get(ctx context.Context, id string) (*entity.Venue, error)
+	GetAll(ctx context.Context) ([]entity.Venue, error)
+	Update(ctx context.Context, venue *entity.Venue) (*entity.Venue, error)
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,16 @@
+package secondary
+
+import (
+	"context"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+)
+
+// EventCoHostRepository defines the interface for event co-host data access
+type EventCoHostRepository interface {
+	Create(ctx context.Context, coHost *entity.EventCoHost) (*entity.EventCoHost, error)
+	Get(ctx context.Context, eventID, clubID string) (*entity.EventCoHost, error)
+	Update(ctx context.Context, coHost *entity.EventCoHost) (*entity.EventCoHost, error)
+	Delete(ctx context.Context, eventID, clubID string) error
+	GetByEventID(ctx context.Context, eventID string) ([]entity.EventCoHost, error)
+}
@@ -0,0 +1,17 @@
+package secondary
+
+import (
+	"context"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/dto"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+)
+
+// EventFeedbackRepository defines the interface for event feedback data access
+type EventFeedbackRepository interface {
+	Save(ctx context.Context, feedback *entity.EventFeedback) (*entity.EventFeedback, error)
+	Get(ctx context.Context, eventID string, userID int64) (*entity.EventFeedback, error)
+	GetByEventID(ctx context.Context, eventID string) ([]entity.EventFeedback, error)
+	GetSummaryByEventID(ctx context.Context, eventID string) (dto.FeedbackSummary, error)
+	GetSummaryByClubID(ctx context.Context, clubID string) (dto.FeedbackSummary, error)
+}
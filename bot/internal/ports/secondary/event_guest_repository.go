@@ -0,0 +1,18 @@
+package secondary
+
+import (
+	"context"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+)
+
+// EventGuestRepository defines the interface for event guests data access
+type EventGuestRepository interface {
+	Create(ctx context.Context, guest *entity.EventGuest) (*entity.EventGuest, error)
+	Get(ctx context.Context, id string) (*entity.EventGuest, error)
+	Delete(ctx context.Context, id string) error
+	DeleteByEventAndUser(ctx context.Context, eventID string, userID int64) error
+	GetByEventAndUser(ctx context.Context, eventID string, userID int64) ([]entity.EventGuest, error)
+	GetByEventID(ctx context.Context, eventID string) ([]entity.EventGuest, error)
+	GetMany(ctx context.Context, ids []string) ([]entity.EventGuest, error)
+}
@@ -0,0 +1,15 @@
+package secondary
+
+import (
+	"context"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+)
+
+// EventQuestionRepository defines the interface for registration form questions and answers data access
+type EventQuestionRepository interface {
+	GetByEventID(ctx context.Context, eventID string) ([]entity.EventQuestion, error)
+	SaveAnswers(ctx context.Context, eventID string, userID int64, answers []entity.EventAnswer) error
+	DeleteAnswers(ctx context.Context, eventID string, userID int64) error
+	GetAnswersByEventID(ctx context.Context, eventID string) ([]entity.EventAnswer, error)
+}
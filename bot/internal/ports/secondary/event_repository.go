@@ -14,14 +14,24 @@ type EventRepository interface {
 	Get(ctx context.Context, id string) (*entity.Event, error)
 	GetEventByID(ctx context.Context, eventID string) (*entity.Event, error)
 	GetByQRCodeID(ctx context.Context, qrCodeID string) (*entity.Event, error)
+	GetByExitQRCodeID(ctx context.Context, qrCodeID string) (*entity.Event, error)
 	GetMany(ctx context.Context, ids []string) ([]entity.Event, error)
 	GetAll(ctx context.Context) ([]entity.Event, error)
 	GetByClubID(ctx context.Context, limit, offset int, clubID string) ([]entity.Event, error)
 	GetFutureByClubID(ctx context.Context, limit, offset int, order string, clubID string, additionalTime time.Duration) ([]entity.Event, error)
+	GetFutureBySeriesID(ctx context.Context, seriesID string, from time.Time) ([]entity.Event, error)
+	GetVenueBookings(ctx context.Context, venueID string, start, end time.Time, excludeID string) ([]entity.Event, error)
 	GetUpcomingEvents(ctx context.Context, before time.Time) ([]entity.Event, error)
+	GetEndedEvents(ctx context.Context, from, to time.Time) ([]entity.Event, error)
+	GetDraftsBySeriesID(ctx context.Context, seriesID string) ([]entity.Event, error)
+	GetScheduledDrafts(ctx context.Context, before time.Time) ([]entity.Event, error)
 	Update(ctx context.Context, event *entity.Event) (*entity.Event, error)
+	UpdateAndBookVenue(ctx context.Context, event *entity.Event) (*entity.Event, error)
 	Delete(ctx context.Context, id string) error
-	Count(ctx context.Context, role string) (int64, error)
+	WithLock(ctx context.Context, id string, fn func(ctx context.Context) error) error
+	Count(ctx context.Context, role string, filter dto.EventFilter) (int64, error)
+	CountByFilters(ctx context.Context, role string, filter dto.EventFilter) (dto.EventFilterCounts, error)
 	CountByClubID(ctx context.Context, clubID string) (int64, error)
-	GetWithPagination(ctx context.Context, limit, offset int, order string, role string, userID int64) ([]dto.Event, error)
+	GetWithPagination(ctx context.Context, limit, offset int, order string, role string, userID int64, filter dto.EventFilter) ([]dto.Event, error)
+	GetPublic(ctx context.Context, role string, filter dto.EventFilter) ([]entity.Event, error)
 }
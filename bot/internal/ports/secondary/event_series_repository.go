@@ -0,0 +1,15 @@
+package secondary
+
+import (
+	"context"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+)
+
+// EventSeriesRepository defines the interface for recurring event series data access
+type EventSeriesRepository interface {
+	Create(ctx context.Context, series *entity.EventSeries) (*entity.EventSeries, error)
+	Get(ctx context.Context, id string) (*entity.EventSeries, error)
+	Update(ctx context.Context, series *entity.EventSeries) (*entity.EventSeries, error)
+	Split(ctx context.Context, series *entity.EventSeries, next *entity.EventSeries, eventIDs []string) (*entity.EventSeries, error)
+}
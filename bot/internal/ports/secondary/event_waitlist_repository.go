@@ -0,0 +1,24 @@
+package secondary
+
+import (
+	"context"
+	"time"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+)
+
+// EventWaitlistRepository defines the interface for event waitlist data access
+type EventWaitlistRepository interface {
+	Create(ctx context.Context, entry *entity.EventWaitlist) (*entity.EventWaitlist, error)
+	Get(ctx context.Context, eventID string, userID int64) (*entity.EventWaitlist, error)
+	Update(ctx context.Context, entry *entity.EventWaitlist) (*entity.EventWaitlist, error)
+	Delete(ctx context.Context, eventID string, userID int64) error
+	GetByEventID(ctx context.Context, eventID string) ([]entity.EventWaitlist, error)
+	GetNext(ctx context.Context, eventID string, excludedRoles entity.Roles, lastUserIDs []int64) (*entity.EventWaitlist, error)
+	GetPosition(ctx context.Context, eventID string, userID int64) (int64, error)
+	CountByEventID(ctx context.Context, eventID string) (int64, error)
+	CountActiveOffers(ctx context.Context, eventID string, now time.Time) (int64, error)
+	CountActiveOffersByRole(ctx context.Context, eventID string, now time.Time) (map[entity.Role]int64, error)
+	GetExpiredOffers(ctx context.Context, now time.Time) ([]entity.EventWaitlist, error)
+	GetWaitingEventIDs(ctx context.Context) ([]string, error)
+}
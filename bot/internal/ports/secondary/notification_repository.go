@@ -9,5 +9,7 @@ import (
 // NotificationRepository defines the interface for notification data access
 type NotificationRepository interface {
 	Create(ctx context.Context, notification *entity.EventNotification) error
+	DeleteRemindersByEventID(ctx context.Context, eventID string) error
 	GetUnnotifiedUsers(ctx context.Context, eventID string, notificationType entity.NotificationType) ([]entity.EventParticipant, error)
+	GetNotifiedUserIDs(ctx context.Context, eventID string, notificationType entity.NotificationType) ([]int64, error)
 }
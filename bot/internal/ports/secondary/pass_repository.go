@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/entity"
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/valueobject"
 )
 
 // PassRepository defines the interface for pass data access
@@ -20,7 +21,12 @@ type PassRepository interface {
 	CreateBulkPasses(ctx context.Context, passes []entity.Pass) error
 	GetActivePassForUser(ctx context.Context, eventID string, userID int64) (*entity.Pass, error)
 	HasActivePass(ctx context.Context, eventID string, userID int64) (bool, error)
+	HasActiveGuestPass(ctx context.Context, guestID string) (bool, error)
+	CancelGuestPasses(ctx context.Context, guestID string) error
 	CancelPassesByEventAndUser(ctx context.Context, eventID string, userID int64) error
+	CancelPendingPassesByEventID(ctx context.Context, eventID string) error
+	ReschedulePendingPasses(ctx context.Context, eventID string, role valueobject.Role, scheduledAt time.Time) error
+	RescheduleGuestPasses(ctx context.Context, eventID string, scheduledAt time.Time) error
 	GetPassesByRequester(ctx context.Context, requesterType entity.PassRequesterType, requesterID string, limit, offset int) ([]entity.Pass, error)
 	CountPassesByRequester(ctx context.Context, requesterType entity.PassRequesterType, requesterID string) (int64, error)
 	GetPassesByEventAndRequester(ctx context.Context, eventID string, requesterType entity.PassRequesterType, requesterID string) ([]entity.Pass, error)
@@ -0,0 +1,13 @@
+package secondary
+
+import (
+	"context"
+
+	"github.com/Badsnus/cu-clubs-bot/bot/internal/domain/dto"
+)
+
+// SearchRepository defines the interface for full-text search over events and clubs
+type SearchRepository interface {
+	Search(ctx context.Context, query string, role string, limit, offset int) ([]dto.SearchResult, error)
+	Count(ctx context.Context, query string, role string) (int64, error)
+}
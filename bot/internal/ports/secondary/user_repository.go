@@ -14,6 +14,7 @@ type UserRepository interface {
 	Get(ctx context.Context, id uint) (*entity.User, error)
 	GetUserByID(ctx context.Context, userID int64) (*entity.User, error)
 	GetByQRCodeID(ctx context.Context, qrCodeID string) (*entity.User, error)
+	GetByCalendarToken(ctx context.Context, token string) (*entity.User, error)
 	GetMany(ctx context.Context, ids []int64) ([]entity.User, error)
 	GetByEmail(ctx context.Context, email valueobject.Email) (*entity.User, error)
 	GetAll(ctx context.Context) ([]entity.User, error)